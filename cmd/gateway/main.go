@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/dipendra-mule/microservice-with-grpc/internal/gateway"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/logger"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/metrics"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/mtls"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/tracing"
+	"go.uber.org/zap"
+)
+
+func main() {
+	l := logger.Init("gateway")
+	defer l.Sync()
+
+	shutdownTracing, err := tracing.Init(context.Background(), tracing.ConfigFromEnv("gateway"))
+	if err != nil {
+		l.Fatal("Failed to initialize tracing", zap.Error(err))
+	}
+	defer shutdownTracing(context.Background())
+
+	metricsServer := metrics.Serve(":" + getEnv("METRICS_PORT", "9090"))
+	defer metricsServer.Shutdown(context.Background())
+
+	tlsSource, err := mtls.Load(mtls.ConfigFromEnv())
+	if err != nil {
+		l.Fatal("Failed to load TLS configuration", zap.Error(err))
+	}
+	defer tlsSource.Close()
+
+	gw := gateway.NewGateway(
+		getEnv("USER_SERVICE_ADDR", "localhost:50051"),
+		getEnv("ORDER_SERVICE_ADDR", "localhost:50052"),
+		getEnv("PRODUCT_SERVICE_ADDR", "localhost:50053"),
+		tlsSource,
+	)
+
+	// Carrier webhooks, e.g. CARRIER_WEBHOOK_SECRETS=ups=s3cret,dhl=0ther,
+	// each signed with an HMAC of the body under the carrier's secret.
+	header := getEnv("CARRIER_WEBHOOK_SIGNATURE_HEADER", "X-Signature")
+	for _, entry := range strings.Split(getEnv("CARRIER_WEBHOOK_SECRETS", ""), ",") {
+		carrier, secret, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok || carrier == "" || secret == "" {
+			if entry != "" {
+				l.Warn("Ignoring malformed carrier webhook secret", zap.String("carrier", carrier))
+			}
+			continue
+		}
+		gw.RegisterCarrier(carrier, gateway.HMACVerifier{Secret: []byte(secret), Header: header})
+	}
+
+	port := getEnv("GATEWAY_PORT", "8080")
+	l.Info("Gateway starting", zap.String("port", port))
+	if err := gw.Start(port); err != nil {
+		l.Fatal("Gateway failed", zap.Error(err))
+	}
+}
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
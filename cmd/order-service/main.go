@@ -1,20 +1,57 @@
 package main
 
 import (
-	"log"
+	"context"
 	"net"
 	"os"
 	"time"
 
+	"buf.build/go/protovalidate"
+	"github.com/dipendra-mule/microservice-with-grpc/internal/cart"
 	"github.com/dipendra-mule/microservice-with-grpc/internal/order"
+	"github.com/dipendra-mule/microservice-with-grpc/internal/promotion"
+	"github.com/dipendra-mule/microservice-with-grpc/internal/shipment"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/auth"
 	"github.com/dipendra-mule/microservice-with-grpc/pkg/database"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/errs"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/exchange"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/grpcclient"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/healthcheck"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/logger"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/metrics"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/mtls"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/recovery"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/shutdown"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/tax"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/tracing"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/validation"
+	cartv1 "github.com/dipendra-mule/microservice-with-grpc/proto/cart"
 	orderv1 "github.com/dipendra-mule/microservice-with-grpc/proto/order"
 	productv1 "github.com/dipendra-mule/microservice-with-grpc/proto/product"
+	promotionv1 "github.com/dipendra-mule/microservice-with-grpc/proto/promotion"
+	shipmentv1 "github.com/dipendra-mule/microservice-with-grpc/proto/shipment"
 	userv1 "github.com/dipendra-mule/microservice-with-grpc/proto/user"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
 	"google.golang.org/grpc"
 )
 
 func main() {
+	l := logger.Init("order-service")
+	defer l.Sync()
+
+	shutdownTracing, err := tracing.Init(context.Background(), tracing.ConfigFromEnv("order-service"))
+	if err != nil {
+		l.Fatal("Failed to initialize tracing", zap.Error(err))
+	}
+	defer shutdownTracing(context.Background())
+
+	tlsSource, err := mtls.Load(mtls.ConfigFromEnv())
+	if err != nil {
+		l.Fatal("Failed to load TLS configuration", zap.Error(err))
+	}
+	defer tlsSource.Close()
+
 	// Database configuration
 	dbConfig := database.Config{
 		Host:     getEnv("DB_HOST", "localhost"),
@@ -27,57 +64,175 @@ func main() {
 
 	db, err := database.NewPostgresConnection(dbConfig)
 	if err != nil {
-		log.Fatalf("Failed to connect to database: %v", err)
+		l.Fatal("Failed to connect to database", zap.Error(err))
 	}
 	defer db.Close()
 
+	// Health checking
+	healthChecker := healthcheck.New(orderv1.OrderService_ServiceDesc.ServiceName)
+	dbHealth := database.NewHealthChecker(db, dbConfig.DBName, database.HealthConfig{})
+	dbHealth.OnChange(func(s database.HealthStatus) {
+		healthChecker.SetDependency("postgres", s.Healthy)
+	})
+	dbHealth.Start()
+	defer dbHealth.Stop()
+	prometheus.MustRegister(dbHealth)
+
 	// Initialize repository
 	orderRepo := order.NewRepository(db)
 
 	// Create gRPC connections to other services
-	userConn, err := grpc.Dial(
-		getEnv("USER_SERVICE_ADDR", "localhost:50051"),
-		grpc.WithInsecure(),
-		grpc.WithTimeout(5*time.Second),
-	)
+	downstreamTimeout := getDurationEnv("DOWNSTREAM_TIMEOUT", 3*time.Second)
+	userConn, err := grpcclient.Dial(grpcclient.Config{
+		Name:           "user-service",
+		Target:         getEnv("USER_SERVICE_ADDR", "localhost:50051"),
+		Service:        userv1.UserService_ServiceDesc.ServiceName,
+		DefaultTimeout: downstreamTimeout,
+		RetryMethods:   []string{"GetUser", "BatchGetUsers", "GetAddress", "ListAddresses"},
+		Credentials:    tlsSource.ClientCredentials("user-service"),
+	})
 	if err != nil {
-		log.Fatalf("Failed to connect to user service: %v", err)
+		l.Fatal("Failed to connect to user service", zap.Error(err))
 	}
 	defer userConn.Close()
 
-	productConn, err := grpc.Dial(
-		getEnv("PRODUCT_SERVICE_ADDR", "localhost:50053"),
-		grpc.WithInsecure(),
-		grpc.WithTimeout(5*time.Second),
-	)
+	productConn, err := grpcclient.Dial(grpcclient.Config{
+		Name:           "product-service",
+		Target:         getEnv("PRODUCT_SERVICE_ADDR", "localhost:50053"),
+		Service:        productv1.ProductService_ServiceDesc.ServiceName,
+		DefaultTimeout: downstreamTimeout,
+		RetryMethods:   []string{"GetProduct", "ValidateProducts"},
+		Credentials:    tlsSource.ClientCredentials("product-service"),
+	})
 	if err != nil {
-		log.Fatalf("Failed to connect to product service: %v", err)
+		l.Fatal("Failed to connect to product service", zap.Error(err))
 	}
 	defer productConn.Close()
 
+	// Track reachability of downstream services
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	healthChecker.WatchService(ctx, "user-service", userConn, 10*time.Second)
+	healthChecker.WatchService(ctx, "product-service", productConn, 10*time.Second)
+
 	// Create service clients
 	userClient := userv1.NewUserServiceClient(userConn)
 	productClient := productv1.NewProductServiceClient(productConn)
 
-	// Initialize service and server
-	orderService := order.NewService(orderRepo, productClient, userClient)
+	// Exchange rates for orders priced in another currency than their
+	// products. Without a rate file orders use the products' currency.
+	var rates exchange.Provider = exchange.None{}
+	if path := getEnv("EXCHANGE_RATES_FILE", ""); path != "" {
+		if rates, err = exchange.LoadFile(path); err != nil {
+			l.Fatal("Failed to load exchange rates", zap.Error(err))
+		}
+	}
+
+	// Tax rules by shipping region and product category. Without a rule
+	// file orders carry no tax.
+	var taxes tax.Calculator = tax.None{}
+	if path := getEnv("TAX_RULES_FILE", ""); path != "" {
+		if taxes, err = tax.LoadFile(path); err != nil {
+			l.Fatal("Failed to load tax rules", zap.Error(err))
+		}
+	}
+
+	// Promotions share the orders database so that redemptions are
+	// recorded in the transaction that creates the order.
+	promotionService := promotion.NewService(promotion.NewRepository(db))
+
+	// Initialize services and servers
+	orderService := order.NewService(orderRepo, productClient, userClient, rates, taxes, promotionService,
+		getDurationEnv("IDEMPOTENCY_KEY_TTL", 24*time.Hour))
+	orderService.StartIdempotencyExpiry(ctx, getDurationEnv("IDEMPOTENCY_EXPIRY_INTERVAL", time.Hour))
+	orderService.StartStockRelease(ctx, getDurationEnv("STOCK_RELEASE_INTERVAL", time.Minute))
 	orderServer := order.NewServer(orderService)
 
+	// Carts check out through the order service. Expired carts are hidden
+	// at once and deleted in the background.
+	cartService := cart.NewService(cart.NewRepository(db), productClient, orderService,
+		getDurationEnv("CART_TTL", 30*24*time.Hour))
+	cartService.StartExpiry(ctx, getDurationEnv("CART_EXPIRY_INTERVAL", time.Hour))
+
+	// Shipments move orders to shipped and delivered in the orders
+	// database's transactions.
+	shipmentService := shipment.NewService(shipment.NewRepository(db))
+
+	// Tokens are issued by the user service; verified callers are recorded
+	// as the actors in order history.
+	jwtManager := auth.NewJWTManager(getEnv("JWT_SECRET", "secret"), 24*time.Hour)
+
+	// The gateway forwards every RPC, so admin operations are guarded here.
+	// Tracking events come from the gateway's carrier webhook, which
+	// verifies the carrier's signature and has no user token.
+	adminOnly := auth.Rule{Roles: []string{auth.RoleAdmin}}
+	policy := auth.Policy{
+		orderv1.OrderService_UpdateOrderStatus_FullMethodName:           adminOnly,
+		orderv1.OrderService_RefundOrder_FullMethodName:                 adminOnly,
+		promotionv1.PromotionService_CreatePromotion_FullMethodName:     adminOnly,
+		promotionv1.PromotionService_DeactivatePromotion_FullMethodName: adminOnly,
+		shipmentv1.ShipmentService_CreateShipment_FullMethodName:        adminOnly,
+		shipmentv1.ShipmentService_RecordTrackingEvent_FullMethodName: {
+			Roles: []string{auth.RoleAdmin},
+			Peer:  tlsSource.PeerIs("gateway"),
+		},
+	}
+
+	validator, err := protovalidate.New()
+	if err != nil {
+		l.Fatal("Failed to create request validator", zap.Error(err))
+	}
+
 	// Create gRPC server
-	grpcServer := grpc.NewServer()
+	serverOpts := append(grpcclient.ServerKeepaliveOptions(),
+		tlsSource.ServerOption(),
+		tracing.ServerOption(),
+		grpc.ChainUnaryInterceptor(
+			logger.UnaryServerInterceptor(l),
+			metrics.UnaryServerInterceptor(),
+			errs.UnaryServerInterceptor(),
+			recovery.UnaryServerInterceptor(),
+			tlsSource.UnaryServerInterceptor(),
+			auth.UnaryServerInterceptor(jwtManager),
+			policy.UnaryServerInterceptor(),
+			validation.UnaryServerInterceptor(validator),
+		),
+		grpc.ChainStreamInterceptor(
+			logger.StreamServerInterceptor(l),
+			metrics.StreamServerInterceptor(),
+			errs.StreamServerInterceptor(),
+			recovery.StreamServerInterceptor(),
+			tlsSource.StreamServerInterceptor(),
+			auth.StreamServerInterceptor(jwtManager),
+			policy.StreamServerInterceptor(),
+			validation.StreamServerInterceptor(validator),
+		),
+	)
+	grpcServer := grpc.NewServer(serverOpts...)
 	orderv1.RegisterOrderServiceServer(grpcServer, orderServer)
+	promotionv1.RegisterPromotionServiceServer(grpcServer, promotion.NewServer(promotionService))
+	cartv1.RegisterCartServiceServer(grpcServer, cart.NewServer(cartService))
+	shipmentv1.RegisterShipmentServiceServer(grpcServer, shipment.NewServer(shipmentService))
+	healthChecker.Register(grpcServer)
 
 	// Start server
 	port := getEnv("ORDER_SERVICE_PORT", "50052")
 	lis, err := net.Listen("tcp", ":"+port)
 	if err != nil {
-		log.Fatalf("Failed to listen: %v", err)
+		l.Fatal("Failed to listen", zap.Error(err))
 	}
 
-	log.Printf("Order service starting on port %s", port)
-	if err := grpcServer.Serve(lis); err != nil {
-		log.Fatalf("Failed to serve: %v", err)
+	metricsServer := metrics.Serve(":" + getEnv("METRICS_PORT", "9092"))
+	defer metricsServer.Shutdown(context.Background())
+
+	l.Info("Order service starting", zap.String("port", port))
+	shutdownTimeout := getDurationEnv("SHUTDOWN_TIMEOUT", 30*time.Second)
+	if err := shutdown.Serve(grpcServer, lis, shutdownTimeout, healthChecker.Shutdown); err != nil {
+		l.Fatal("Failed to serve", zap.Error(err))
 	}
+	// Deferred cleanup now runs in reverse order: health watchers, outbound
+	// client connections, the DB health checker and finally the DB pool.
+	l.Info("Order service stopped")
 }
 
 func getEnv(key, defaultValue string) string {
@@ -86,3 +241,14 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
+	if value := os.Getenv(key); value != "" {
+		if d, err := time.ParseDuration(value); err == nil {
+			return d
+		}
+		zap.L().Warn("Invalid duration, using default",
+			zap.String("key", key), zap.String("value", value), zap.Duration("default", defaultValue))
+	}
+	return defaultValue
+}
@@ -1,19 +1,47 @@
 package main
 
 import (
-	"log"
+	"context"
 	"net"
 	"os"
 	"time"
 
+	"buf.build/go/protovalidate"
 	"github.com/dipendra-mule/microservice-with-grpc/internal/user"
 	"github.com/dipendra-mule/microservice-with-grpc/pkg/auth"
 	"github.com/dipendra-mule/microservice-with-grpc/pkg/database"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/errs"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/grpcclient"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/healthcheck"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/logger"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/metrics"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/mtls"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/recovery"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/shutdown"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/tracing"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/validation"
 	userv1 "github.com/dipendra-mule/microservice-with-grpc/proto/user"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
 	"google.golang.org/grpc"
 )
 
 func main() {
+	l := logger.Init("user-service")
+	defer l.Sync()
+
+	shutdownTracing, err := tracing.Init(context.Background(), tracing.ConfigFromEnv("user-service"))
+	if err != nil {
+		l.Fatal("Failed to initialize tracing", zap.Error(err))
+	}
+	defer shutdownTracing(context.Background())
+
+	tlsSource, err := mtls.Load(mtls.ConfigFromEnv())
+	if err != nil {
+		l.Fatal("Failed to load TLS configuration", zap.Error(err))
+	}
+	defer tlsSource.Close()
+
 	// Database configuration
 	dbConfig := database.Config{
 		Host:     getEnv("DB_HOST", "localhost"),
@@ -26,10 +54,20 @@ func main() {
 
 	db, err := database.NewPostgresConnection(dbConfig)
 	if err != nil {
-		log.Fatalf("Failed to connect to database: %v", err)
+		l.Fatal("Failed to connect to database", zap.Error(err))
 	}
 	defer db.Close()
 
+	// Health checking
+	healthChecker := healthcheck.New(userv1.UserService_ServiceDesc.ServiceName)
+	dbHealth := database.NewHealthChecker(db, dbConfig.DBName, database.HealthConfig{})
+	dbHealth.OnChange(func(s database.HealthStatus) {
+		healthChecker.SetDependency("postgres", s.Healthy)
+	})
+	dbHealth.Start()
+	defer dbHealth.Stop()
+	prometheus.MustRegister(dbHealth)
+
 	// Initialize repository and service
 	userRepo := user.NewRepository(db)
 	jwtManager := auth.NewJWTManager(
@@ -37,24 +75,59 @@ func main() {
 		24*time.Hour, // 24 hours
 	)
 
-	userService := user.NewService(userRepo, jwtManager)
+	// The order service checks the user placing an order before reading
+	// their address book.
+	userService := user.NewService(userRepo, jwtManager, tlsSource.PeerIs("order-service"))
 	userServer := user.NewServer(userService)
 
+	validator, err := protovalidate.New()
+	if err != nil {
+		l.Fatal("Failed to create request validator", zap.Error(err))
+	}
+
 	// Create gRPC server
-	grpcServer := grpc.NewServer()
+	serverOpts := append(grpcclient.ServerKeepaliveOptions(),
+		tlsSource.ServerOption(),
+		tracing.ServerOption(),
+		grpc.ChainUnaryInterceptor(
+			logger.UnaryServerInterceptor(l),
+			metrics.UnaryServerInterceptor(),
+			errs.UnaryServerInterceptor(),
+			recovery.UnaryServerInterceptor(),
+			tlsSource.UnaryServerInterceptor(),
+			auth.UnaryServerInterceptor(jwtManager),
+			validation.UnaryServerInterceptor(validator),
+		),
+		grpc.ChainStreamInterceptor(
+			logger.StreamServerInterceptor(l),
+			metrics.StreamServerInterceptor(),
+			errs.StreamServerInterceptor(),
+			recovery.StreamServerInterceptor(),
+			tlsSource.StreamServerInterceptor(),
+			auth.StreamServerInterceptor(jwtManager),
+			validation.StreamServerInterceptor(validator),
+		),
+	)
+	grpcServer := grpc.NewServer(serverOpts...)
 	userv1.RegisterUserServiceServer(grpcServer, userServer)
+	healthChecker.Register(grpcServer)
 
 	// Start server
 	port := getEnv("USER_SERVICE_PORT", "50051")
 	lis, err := net.Listen("tcp", ":"+port)
 	if err != nil {
-		log.Fatalf("Failed to listen: %v", err)
+		l.Fatal("Failed to listen", zap.Error(err))
 	}
 
-	log.Printf("User service starting on port %s", port)
-	if err := grpcServer.Serve(lis); err != nil {
-		log.Fatalf("Failed to serve: %v", err)
+	metricsServer := metrics.Serve(":" + getEnv("METRICS_PORT", "9091"))
+	defer metricsServer.Shutdown(context.Background())
+
+	l.Info("User service starting", zap.String("port", port))
+	shutdownTimeout := getDurationEnv("SHUTDOWN_TIMEOUT", 30*time.Second)
+	if err := shutdown.Serve(grpcServer, lis, shutdownTimeout, healthChecker.Shutdown); err != nil {
+		l.Fatal("Failed to serve", zap.Error(err))
 	}
+	l.Info("User service stopped")
 }
 
 func getEnv(key, defaultValue string) string {
@@ -63,3 +136,14 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
+	if value := os.Getenv(key); value != "" {
+		if d, err := time.ParseDuration(value); err == nil {
+			return d
+		}
+		zap.L().Warn("Invalid duration, using default",
+			zap.String("key", key), zap.String("value", value), zap.Duration("default", defaultValue))
+	}
+	return defaultValue
+}
@@ -0,0 +1,28 @@
+package cart
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	checkouts = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "cart_checkouts_total",
+		Help: "Total number of carts converted to orders.",
+	})
+
+	cartsMerged = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "carts_merged_total",
+		Help: "Total number of guest carts merged into a user's cart.",
+	})
+
+	cartsExpired = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "carts_expired_total",
+		Help: "Total number of expired carts deleted.",
+	})
+
+	cartPricingFailures = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "cart_pricing_failures_total",
+		Help: "Total number of carts returned without prices.",
+	})
+)
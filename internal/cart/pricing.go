@@ -0,0 +1,110 @@
+package cart
+
+import (
+	"context"
+
+	"github.com/dipendra-mule/microservice-with-grpc/internal/order"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/logger"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/money"
+	"github.com/dipendra-mule/microservice-with-grpc/proto/cart"
+	"github.com/dipendra-mule/microservice-with-grpc/proto/product"
+	"go.uber.org/zap"
+)
+
+// Warnings reported with a cart that could not be fully priced.
+const (
+	WarningProductUnavailable = "PRODUCT_UNAVAILABLE"
+	WarningPricingUnavailable = "PRICING_UNAVAILABLE"
+	WarningMixedCurrencies    = "MIXED_CURRENCIES"
+)
+
+// price sets current names and prices on the cart's items and the cart
+// subtotal. Like order enrichment it never fails the request: items that
+// cannot be priced are returned as they are, with warnings.
+func (s *Service) price(ctx context.Context, c *cart.Cart) []*cart.CartWarning {
+	if len(c.Items) == 0 {
+		return nil
+	}
+
+	reqs := make([]*product.ProductValidation, len(c.Items))
+	for i, item := range c.Items {
+		reqs[i] = &product.ProductValidation{ProductId: item.ProductId, Quantity: item.Quantity}
+	}
+	resp, err := s.productClient.ValidateProducts(ctx, &product.ValidateProductsRequest{Items: reqs})
+	if err != nil {
+		cartPricingFailures.Inc()
+		logger.FromContext(ctx).Warn("Returning cart without prices", zap.Error(err))
+		return []*cart.CartWarning{{
+			Reason:  WarningPricingUnavailable,
+			Message: "prices are temporarily unavailable",
+		}}
+	}
+
+	var warnings []*cart.CartWarning
+	reported := make(map[string]bool, len(resp.Errors))
+	for _, ve := range resp.Errors {
+		reported[ve.ProductId] = true
+		warnings = append(warnings, &cart.CartWarning{
+			Reason:    WarningProductUnavailable,
+			Message:   ve.Message,
+			ProductId: ve.ProductId,
+		})
+	}
+	products := make(map[string]*product.Product, len(resp.Products))
+	for _, p := range resp.Products {
+		products[p.Id] = p
+	}
+
+	var subtotal *money.Amount
+	mixed := false
+	priced := 0
+	for _, item := range c.Items {
+		p, ok := products[item.ProductId]
+		if !ok {
+			if !reported[item.ProductId] {
+				warnings = append(warnings, &cart.CartWarning{
+					Reason:    WarningProductUnavailable,
+					Message:   "product not found",
+					ProductId: item.ProductId,
+				})
+			}
+			continue
+		}
+		item.ProductName = p.Name
+		price, err := order.ProductPrice(p)
+		if err != nil {
+			logger.FromContext(ctx).Warn("Skipping cart item with invalid price",
+				zap.String("product_id", p.Id), zap.Error(err))
+			continue
+		}
+		line, err := price.Mul(int64(item.Quantity))
+		if err != nil {
+			continue
+		}
+		item.UnitPrice = price.Proto()
+		item.LineTotal = line.Proto()
+		priced++
+
+		switch {
+		case subtotal == nil:
+			subtotal = &line
+		case subtotal.Currency != line.Currency:
+			mixed = true
+		default:
+			if sum, err := subtotal.Add(line); err == nil {
+				subtotal = &sum
+			}
+		}
+	}
+
+	switch {
+	case mixed:
+		warnings = append(warnings, &cart.CartWarning{
+			Reason:  WarningMixedCurrencies,
+			Message: "items are priced in different currencies; choose one at checkout",
+		})
+	case priced == len(c.Items):
+		c.Subtotal = subtotal.Proto()
+	}
+	return warnings
+}
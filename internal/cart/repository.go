@@ -0,0 +1,364 @@
+package cart
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/database"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/errs"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/tracing"
+	"github.com/dipendra-mule/microservice-with-grpc/proto/cart"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// MaxCartItems is the most distinct products a cart holds, matching the
+// item limit of CreateOrderRequest. MaxItemQuantity caps one item.
+const (
+	MaxCartItems    = 100
+	MaxItemQuantity = 1000
+)
+
+var (
+	ErrCartNotFound     = errs.New(errs.NotFound, "CART_NOT_FOUND", "cart not found")
+	ErrCartItemNotFound = errs.New(errs.NotFound, "CART_ITEM_NOT_FOUND", "product is not in the cart")
+	ErrCartFull         = errs.New(errs.FailedPrecondition, "CART_FULL", fmt.Sprintf("a cart holds at most %d products", MaxCartItems))
+	ErrQuantityTooLarge = errs.New(errs.FailedPrecondition, "QUANTITY_TOO_LARGE", fmt.Sprintf("at most %d of a product per cart", MaxItemQuantity))
+
+	// errUserCartExists means another request created the user's cart first.
+	errUserCartExists = errs.New(errs.AlreadyExists, "CART_EXISTS", "user already has a cart")
+)
+
+type Repository struct {
+	db *sql.DB
+}
+
+func NewRepository(db *sql.DB) *Repository {
+	return &Repository{
+		db: db,
+	}
+}
+
+// GetCart returns an unexpired cart with its items, unpriced.
+func (r *Repository) GetCart(ctx context.Context, id string) (*cart.Cart, error) {
+	return r.getCart(ctx, "id", id)
+}
+
+// GetCartByUser returns the user's unexpired cart.
+func (r *Repository) GetCartByUser(ctx context.Context, userID string) (*cart.Cart, error) {
+	return r.getCart(ctx, "user_id", userID)
+}
+
+func (r *Repository) getCart(ctx context.Context, column, value string) (*cart.Cart, error) {
+	query := `
+		SELECT ` + cartColumns + `
+		FROM carts
+		WHERE ` + column + ` = $1 AND expires_at > now()
+	`
+	qctx, end := tracing.Query(ctx, "SELECT", "carts")
+	c, err := scanCart(r.db.QueryRowContext(qctx, query, value))
+	end(err)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrCartNotFound.WithResource("cart", value)
+		}
+		return nil, fmt.Errorf("failed to get cart: %w", err)
+	}
+
+	qctx, end = tracing.Query(ctx, "SELECT", "cart_items")
+	rows, err := r.db.QueryContext(qctx, `
+		SELECT product_id, quantity, added_at
+		FROM cart_items
+		WHERE cart_id = $1
+		ORDER BY added_at, product_id
+	`, c.Id)
+	end(err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cart items: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item cart.CartItem
+		if err := rows.Scan(&item.ProductId, &item.Quantity, database.Timestamp(&item.AddedAt)); err != nil {
+			return nil, fmt.Errorf("failed to scan cart item: %w", err)
+		}
+		c.Items = append(c.Items, &item)
+	}
+	return c, rows.Err()
+}
+
+// CreateCart creates an empty cart for userID, or a guest cart if userID
+// is empty.
+func (r *Repository) CreateCart(ctx context.Context, userID string, expiresAt time.Time) (*cart.Cart, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var owner sql.NullString
+	if userID != "" {
+		owner = sql.NullString{String: userID, Valid: true}
+		// An expired cart still holds the user_id.
+		qctx, end := tracing.Query(ctx, "DELETE", "carts")
+		_, err := tx.ExecContext(qctx, "DELETE FROM carts WHERE user_id = $1 AND expires_at <= now()", userID)
+		end(err)
+		if err != nil {
+			return nil, fmt.Errorf("failed to delete expired cart: %w", err)
+		}
+	}
+
+	now := time.Now()
+	query := `
+		INSERT INTO carts (id, user_id, created_at, updated_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING ` + cartColumns
+	qctx, end := tracing.Query(ctx, "INSERT", "carts")
+	c, err := scanCart(tx.QueryRowContext(qctx, query, uuid.New().String(), owner, now, now, expiresAt))
+	end(err)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return nil, errUserCartExists
+		}
+		return nil, fmt.Errorf("failed to create cart: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return c, nil
+}
+
+// AddItem adds quantity of a product to a cart, on top of any already
+// there.
+func (r *Repository) AddItem(ctx context.Context, cartID, productID string, quantity int32, expiresAt time.Time) error {
+	return r.update(ctx, cartID, expiresAt, func(tx *sql.Tx) error {
+		var total int32
+		qctx, end := tracing.Query(ctx, "INSERT", "cart_items")
+		err := tx.QueryRowContext(qctx, `
+			INSERT INTO cart_items (cart_id, product_id, quantity, added_at)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (cart_id, product_id) DO UPDATE SET quantity = cart_items.quantity + EXCLUDED.quantity
+			RETURNING quantity
+		`, cartID, productID, quantity, time.Now()).Scan(&total)
+		end(err)
+		if err != nil {
+			return fmt.Errorf("failed to add cart item: %w", err)
+		}
+		if total > MaxItemQuantity {
+			return ErrQuantityTooLarge.WithField("quantity", fmt.Sprintf("cart would hold %d", total))
+		}
+		return checkItemCount(ctx, tx, cartID)
+	})
+}
+
+// SetItemQuantity replaces the quantity of a product already in a cart.
+func (r *Repository) SetItemQuantity(ctx context.Context, cartID, productID string, quantity int32, expiresAt time.Time) error {
+	return r.update(ctx, cartID, expiresAt, func(tx *sql.Tx) error {
+		qctx, end := tracing.Query(ctx, "UPDATE", "cart_items")
+		res, err := tx.ExecContext(qctx,
+			"UPDATE cart_items SET quantity = $3 WHERE cart_id = $1 AND product_id = $2",
+			cartID, productID, quantity)
+		end(err)
+		if err != nil {
+			return fmt.Errorf("failed to update cart item: %w", err)
+		}
+		return requireRow(res, productID)
+	})
+}
+
+// RemoveItem removes a product from a cart.
+func (r *Repository) RemoveItem(ctx context.Context, cartID, productID string, expiresAt time.Time) error {
+	return r.update(ctx, cartID, expiresAt, func(tx *sql.Tx) error {
+		qctx, end := tracing.Query(ctx, "DELETE", "cart_items")
+		res, err := tx.ExecContext(qctx,
+			"DELETE FROM cart_items WHERE cart_id = $1 AND product_id = $2", cartID, productID)
+		end(err)
+		if err != nil {
+			return fmt.Errorf("failed to remove cart item: %w", err)
+		}
+		return requireRow(res, productID)
+	})
+}
+
+// MergeCarts moves a guest cart into the user's cart and returns the ID of
+// the user's cart. If the user has no cart the guest cart becomes theirs.
+func (r *Repository) MergeCarts(ctx context.Context, guestID, userID string, expiresAt time.Time) (string, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	qctx, end := tracing.Query(ctx, "SELECT", "carts")
+	err = tx.QueryRowContext(qctx, `
+		SELECT id FROM carts
+		WHERE id = $1 AND user_id IS NULL AND expires_at > now()
+		FOR UPDATE
+	`, guestID).Scan(&guestID)
+	end(err)
+	if err == sql.ErrNoRows {
+		return "", ErrCartNotFound.WithResource("cart", guestID)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get guest cart: %w", err)
+	}
+
+	qctx, end = tracing.Query(ctx, "DELETE", "carts")
+	_, err = tx.ExecContext(qctx, "DELETE FROM carts WHERE user_id = $1 AND expires_at <= now()", userID)
+	end(err)
+	if err != nil {
+		return "", fmt.Errorf("failed to delete expired cart: %w", err)
+	}
+
+	var userCartID string
+	qctx, end = tracing.Query(ctx, "SELECT", "carts")
+	err = tx.QueryRowContext(qctx, "SELECT id FROM carts WHERE user_id = $1 FOR UPDATE", userID).Scan(&userCartID)
+	end(err)
+	switch {
+	case err == sql.ErrNoRows:
+		// Adopt the guest cart.
+		userCartID = guestID
+		qctx, end := tracing.Query(ctx, "UPDATE", "carts")
+		_, err := tx.ExecContext(qctx,
+			"UPDATE carts SET user_id = $2, updated_at = $3, expires_at = $4 WHERE id = $1",
+			guestID, userID, time.Now(), expiresAt)
+		end(err)
+		if err != nil {
+			return "", fmt.Errorf("failed to assign guest cart: %w", err)
+		}
+	case err != nil:
+		return "", fmt.Errorf("failed to get user cart: %w", err)
+	default:
+		qctx, end := tracing.Query(ctx, "INSERT", "cart_items")
+		_, err := tx.ExecContext(qctx, `
+			INSERT INTO cart_items (cart_id, product_id, quantity, added_at)
+			SELECT $2, product_id, quantity, added_at FROM cart_items WHERE cart_id = $1
+			ON CONFLICT (cart_id, product_id) DO UPDATE
+			SET quantity = LEAST(cart_items.quantity + EXCLUDED.quantity, $3)
+		`, guestID, userCartID, MaxItemQuantity)
+		end(err)
+		if err != nil {
+			return "", fmt.Errorf("failed to merge cart items: %w", err)
+		}
+		if err := checkItemCount(ctx, tx, userCartID); err != nil {
+			return "", err
+		}
+
+		qctx, end = tracing.Query(ctx, "DELETE", "carts")
+		_, err = tx.ExecContext(qctx, "DELETE FROM carts WHERE id = $1", guestID)
+		end(err)
+		if err != nil {
+			return "", fmt.Errorf("failed to delete guest cart: %w", err)
+		}
+		qctx, end = tracing.Query(ctx, "UPDATE", "carts")
+		_, err = tx.ExecContext(qctx,
+			"UPDATE carts SET updated_at = $2, expires_at = $3 WHERE id = $1", userCartID, time.Now(), expiresAt)
+		end(err)
+		if err != nil {
+			return "", fmt.Errorf("failed to update cart: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return userCartID, nil
+}
+
+// DeleteCart deletes a cart and its items.
+func (r *Repository) DeleteCart(ctx context.Context, id string) error {
+	qctx, end := tracing.Query(ctx, "DELETE", "carts")
+	_, err := r.db.ExecContext(qctx, "DELETE FROM carts WHERE id = $1", id)
+	end(err)
+	if err != nil {
+		return fmt.Errorf("failed to delete cart: %w", err)
+	}
+	return nil
+}
+
+// DeleteExpired deletes expired carts and returns how many there were.
+func (r *Repository) DeleteExpired(ctx context.Context) (int64, error) {
+	qctx, end := tracing.Query(ctx, "DELETE", "carts")
+	res, err := r.db.ExecContext(qctx, "DELETE FROM carts WHERE expires_at <= now()")
+	end(err)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired carts: %w", err)
+	}
+	return res.RowsAffected()
+}
+
+// update runs fn in a transaction after extending the cart's expiry,
+// failing with ErrCartNotFound if the cart does not exist or has expired.
+func (r *Repository) update(ctx context.Context, cartID string, expiresAt time.Time, fn func(tx *sql.Tx) error) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	qctx, end := tracing.Query(ctx, "UPDATE", "carts")
+	res, err := tx.ExecContext(qctx, `
+		UPDATE carts SET updated_at = $2, expires_at = $3
+		WHERE id = $1 AND expires_at > now()
+	`, cartID, time.Now(), expiresAt)
+	end(err)
+	if err != nil {
+		return fmt.Errorf("failed to update cart: %w", err)
+	}
+	if n, err := res.RowsAffected(); err != nil || n == 0 {
+		return ErrCartNotFound.WithResource("cart", cartID)
+	}
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+func checkItemCount(ctx context.Context, tx *sql.Tx, cartID string) error {
+	var count int
+	qctx, end := tracing.Query(ctx, "SELECT COUNT", "cart_items")
+	err := tx.QueryRowContext(qctx, "SELECT COUNT(*) FROM cart_items WHERE cart_id = $1", cartID).Scan(&count)
+	end(err)
+	if err != nil {
+		return fmt.Errorf("failed to count cart items: %w", err)
+	}
+	if count > MaxCartItems {
+		return ErrCartFull
+	}
+	return nil
+}
+
+func requireRow(res sql.Result, productID string) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrCartItemNotFound.WithResource("product", productID)
+	}
+	return nil
+}
+
+// cartColumns are the carts columns read by scanCart, in order.
+const cartColumns = "id, COALESCE(user_id::text, ''), created_at, updated_at, expires_at"
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanCart(row rowScanner) (*cart.Cart, error) {
+	var c cart.Cart
+	if err := row.Scan(&c.Id, &c.UserId, database.Timestamp(&c.CreatedAt),
+		database.Timestamp(&c.UpdatedAt), database.Timestamp(&c.ExpiresAt)); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
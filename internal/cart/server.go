@@ -0,0 +1,51 @@
+package cart
+
+import (
+	"context"
+
+	"github.com/dipendra-mule/microservice-with-grpc/proto/cart"
+)
+
+type Server struct {
+	cart.UnimplementedCartServiceServer
+	service *Service
+}
+
+func NewServer(s *Service) *Server {
+	return &Server{service: s}
+}
+
+func (s *Server) GetCart(ctx context.Context, r *cart.GetCartRequest) (*cart.CartResponse, error) {
+	return response(s.service.GetCart(ctx, r))
+}
+
+func (s *Server) AddCartItem(ctx context.Context, r *cart.AddCartItemRequest) (*cart.CartResponse, error) {
+	return response(s.service.AddCartItem(ctx, r))
+}
+
+func (s *Server) UpdateCartItem(ctx context.Context, r *cart.UpdateCartItemRequest) (*cart.CartResponse, error) {
+	return response(s.service.UpdateCartItem(ctx, r))
+}
+
+func (s *Server) RemoveCartItem(ctx context.Context, r *cart.RemoveCartItemRequest) (*cart.CartResponse, error) {
+	return response(s.service.RemoveCartItem(ctx, r))
+}
+
+func (s *Server) MergeCarts(ctx context.Context, r *cart.MergeCartsRequest) (*cart.CartResponse, error) {
+	return response(s.service.MergeCarts(ctx, r))
+}
+
+func (s *Server) Checkout(ctx context.Context, r *cart.CheckoutRequest) (*cart.CheckoutResponse, error) {
+	o, err := s.service.Checkout(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	return &cart.CheckoutResponse{Order: o}, nil
+}
+
+func response(c *cart.Cart, warnings []*cart.CartWarning, err error) (*cart.CartResponse, error) {
+	if err != nil {
+		return nil, err
+	}
+	return &cart.CartResponse{Cart: c, Warnings: warnings}, nil
+}
@@ -0,0 +1,242 @@
+package cart
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dipendra-mule/microservice-with-grpc/internal/order"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/auth"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/errs"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/logger"
+	"github.com/dipendra-mule/microservice-with-grpc/proto/cart"
+	orderv1 "github.com/dipendra-mule/microservice-with-grpc/proto/order"
+	"github.com/dipendra-mule/microservice-with-grpc/proto/product"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/metadata"
+)
+
+var ErrCartEmpty = errs.New(errs.FailedPrecondition, "CART_EMPTY", "cart is empty")
+
+type Service struct {
+	repo          *Repository
+	productClient product.ProductServiceClient
+	orders        *order.Service
+	ttl           time.Duration
+}
+
+// NewService returns a cart service whose carts expire ttl after they were
+// last changed.
+func NewService(repo *Repository, pc product.ProductServiceClient, orders *order.Service, ttl time.Duration) *Service {
+	return &Service{
+		repo:          repo,
+		productClient: pc,
+		orders:        orders,
+		ttl:           ttl,
+	}
+}
+
+// GetCart returns a cart priced at current product prices. A user without
+// a cart gets an empty one, which is only stored once an item is added.
+func (s *Service) GetCart(ctx context.Context, r *cart.GetCartRequest) (*cart.Cart, []*cart.CartWarning, error) {
+	var c *cart.Cart
+	var err error
+	if r.GetUserId() != "" {
+		c, err = s.repo.GetCartByUser(ctx, r.GetUserId())
+		if errors.Is(err, ErrCartNotFound) {
+			return &cart.Cart{UserId: r.GetUserId()}, nil, nil
+		}
+	} else {
+		c, err = s.repo.GetCart(ctx, r.GetCartId())
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return c, s.price(ctx, c), nil
+}
+
+func (s *Service) AddCartItem(ctx context.Context, r *cart.AddCartItemRequest) (*cart.Cart, []*cart.CartWarning, error) {
+	// Only known products go into carts; stock is checked at checkout.
+	if _, err := s.productClient.GetProduct(ctx, &product.GetProductRequest{Id: r.ProductId}); err != nil {
+		return nil, nil, errs.FromRemote(fmt.Errorf("failed to get product: %w", err), "product-service")
+	}
+
+	cartID := r.GetCartId()
+	if cartID == "" {
+		c, err := s.userCart(ctx, r.GetUserId())
+		if err != nil {
+			return nil, nil, err
+		}
+		cartID = c.Id
+	}
+	if err := s.repo.AddItem(ctx, cartID, r.ProductId, r.Quantity, s.expiry()); err != nil {
+		return nil, nil, err
+	}
+	return s.load(ctx, cartID)
+}
+
+func (s *Service) UpdateCartItem(ctx context.Context, r *cart.UpdateCartItemRequest) (*cart.Cart, []*cart.CartWarning, error) {
+	cartID, err := s.cartID(ctx, r.GetCartId(), r.GetUserId())
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := s.repo.SetItemQuantity(ctx, cartID, r.ProductId, r.Quantity, s.expiry()); err != nil {
+		return nil, nil, err
+	}
+	return s.load(ctx, cartID)
+}
+
+func (s *Service) RemoveCartItem(ctx context.Context, r *cart.RemoveCartItemRequest) (*cart.Cart, []*cart.CartWarning, error) {
+	cartID, err := s.cartID(ctx, r.GetCartId(), r.GetUserId())
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := s.repo.RemoveItem(ctx, cartID, r.ProductId, s.expiry()); err != nil {
+		return nil, nil, err
+	}
+	return s.load(ctx, cartID)
+}
+
+// MergeCarts moves a guest cart into the user's cart, typically right
+// after the guest logged in. Only the user and admins may merge into the
+// user's cart.
+func (s *Service) MergeCarts(ctx context.Context, r *cart.MergeCartsRequest) (*cart.Cart, []*cart.CartWarning, error) {
+	if _, err := auth.RequireUser(ctx, r.UserId); err != nil {
+		return nil, nil, err
+	}
+	cartID, err := s.repo.MergeCarts(ctx, r.GuestCartId, r.UserId, s.expiry())
+	if err != nil {
+		return nil, nil, err
+	}
+	cartsMerged.Inc()
+	return s.load(ctx, cartID)
+}
+
+// Checkout places an order for the items in the user's cart through
+// order.Service.CreateOrder, which checks stock and prices the order, and
+// then deletes the cart. Unless the client sent its own idempotency key,
+// the order is keyed by the cart's contents, so concurrent or retried
+// checkouts of the same cart get the one order back instead of another.
+// Only the user and admins may check out the user's cart.
+func (s *Service) Checkout(ctx context.Context, r *cart.CheckoutRequest) (*orderv1.Order, error) {
+	if _, err := auth.RequireUser(ctx, r.UserId); err != nil {
+		return nil, err
+	}
+	c, err := s.repo.GetCartByUser(ctx, r.UserId)
+	if errors.Is(err, ErrCartNotFound) {
+		return nil, ErrCartEmpty.WithResource("user", r.UserId)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(c.Items) == 0 {
+		return nil, ErrCartEmpty.WithResource("cart", c.Id)
+	}
+
+	req := &orderv1.CreateOrderRequest{
+		UserId:         r.UserId,
+		Items:          make([]*orderv1.OrderItemRequest, len(c.Items)),
+		Currency:       r.Currency,
+		ShippingRegion: r.ShippingRegion,
+		CouponCodes:    r.CouponCodes,
+	}
+	for i, item := range c.Items {
+		req.Items[i] = &orderv1.OrderItemRequest{ProductId: item.ProductId, Quantity: item.Quantity}
+	}
+	if md, _ := metadata.FromIncomingContext(ctx); len(md.Get(order.IdempotencyKeyHeader)) == 0 {
+		req.IdempotencyKey = checkoutKey(c)
+	}
+	switch shipping := r.Shipping.(type) {
+	case *cart.CheckoutRequest_ShippingAddressId:
+		req.Shipping = &orderv1.CreateOrderRequest_ShippingAddressId{ShippingAddressId: shipping.ShippingAddressId}
+	case *cart.CheckoutRequest_ShippingAddress:
+		req.Shipping = &orderv1.CreateOrderRequest_ShippingAddress{ShippingAddress: shipping.ShippingAddress}
+	}
+	o, err := s.orders.CreateOrder(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	checkouts.Inc()
+
+	// The order exists at this point, so a cart left behind is only
+	// reported; it expires on its own.
+	if err := s.repo.DeleteCart(ctx, c.Id); err != nil {
+		logger.FromContext(ctx).Warn("Failed to delete checked out cart",
+			zap.String("cart_id", c.Id), zap.String("order_id", o.Id), zap.Error(err))
+	}
+	return o, nil
+}
+
+// checkoutKey is the idempotency key of an order placed from c. Every
+// change to the cart moves its updated_at, so a cart changed after a
+// failed checkout gets a new key.
+func checkoutKey(c *cart.Cart) string {
+	return fmt.Sprintf("cart:%s:%d", c.Id, c.UpdatedAt.AsTime().UnixMicro())
+}
+
+// StartExpiry deletes expired carts every interval until ctx is cancelled.
+// Expired carts are already invisible to reads; this only reclaims space.
+func (s *Service) StartExpiry(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				n, err := s.repo.DeleteExpired(ctx)
+				if err != nil {
+					logger.FromContext(ctx).Warn("Failed to delete expired carts", zap.Error(err))
+					continue
+				}
+				cartsExpired.Add(float64(n))
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// load reads and prices a cart after a change.
+func (s *Service) load(ctx context.Context, id string) (*cart.Cart, []*cart.CartWarning, error) {
+	c, err := s.repo.GetCart(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	return c, s.price(ctx, c), nil
+}
+
+// cartID returns cartID, or the ID of the user's cart if cartID is empty.
+func (s *Service) cartID(ctx context.Context, cartID, userID string) (string, error) {
+	if cartID != "" {
+		return cartID, nil
+	}
+	c, err := s.repo.GetCartByUser(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	return c.Id, nil
+}
+
+// userCart returns the user's cart, creating it if needed, or a new guest
+// cart if userID is empty.
+func (s *Service) userCart(ctx context.Context, userID string) (*cart.Cart, error) {
+	if userID == "" {
+		return s.repo.CreateCart(ctx, "", s.expiry())
+	}
+	c, err := s.repo.GetCartByUser(ctx, userID)
+	if !errors.Is(err, ErrCartNotFound) {
+		return c, err
+	}
+	c, err = s.repo.CreateCart(ctx, userID, s.expiry())
+	if errors.Is(err, errUserCartExists) {
+		// A concurrent request created it.
+		return s.repo.GetCartByUser(ctx, userID)
+	}
+	return c, err
+}
+
+func (s *Service) expiry() time.Time {
+	return time.Now().Add(s.ttl)
+}
@@ -3,56 +3,163 @@ package gateway
 import (
 	"context"
 	"net/http"
+	"path"
+	"strings"
 
-	"github.com/dipendra-mule/microservice-with-grpc/internal/user"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/grpcclient"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/metrics"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/mtls"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/recovery"
+	"github.com/dipendra-mule/microservice-with-grpc/proto/cart"
+	"github.com/dipendra-mule/microservice-with-grpc/proto/order"
+	"github.com/dipendra-mule/microservice-with-grpc/proto/product"
+	"github.com/dipendra-mule/microservice-with-grpc/proto/promotion"
+	"github.com/dipendra-mule/microservice-with-grpc/proto/shipment"
 	"github.com/dipendra-mule/microservice-with-grpc/proto/user"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 )
 
+// internalMethods are RPCs the gateway does not publish. Carriers report
+// tracking events through carrierWebhookPath, which verifies them.
+var internalMethods = map[string]bool{
+	shipment.ShipmentService_RecordTrackingEvent_FullMethodName: true,
+}
+
 type Gateway struct {
 	userServiceAddr    string
 	orderServiceAddr   string
 	productServiceAddr string
+	tls                *mtls.Source
+	carriers           map[string]SignatureVerifier
 }
 
-func NewGateway(userAddr, orderAddr, productAddr string) *Gateway {
+func NewGateway(userAddr, orderAddr, productAddr string, tls *mtls.Source) *Gateway {
 	return &Gateway{
 		userServiceAddr:    userAddr,
 		orderServiceAddr:   orderAddr,
 		productServiceAddr: productAddr,
+		tls:                tls,
+		carriers:           make(map[string]SignatureVerifier),
 	}
 }
 
+// RegisterCarrier accepts tracking webhooks from carrier, verified by v.
+// It must be called before Start.
+func (g *Gateway) RegisterCarrier(carrier string, v SignatureVerifier) {
+	g.carriers[carrier] = v
+}
+
 func (g *Gateway) Start(port string) error {
 	ctx := context.Background()
-	mux := runtime.NewServeMux()
+	mux := runtime.NewServeMux(
+		runtime.WithMiddlewares(metrics.GatewayMiddleware),
+		runtime.WithIncomingHeaderMatcher(headerMatcher),
+	)
 
-	opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	// Dial every backend through grpcclient so the gateway resolves and
+	// balances across replicas the same way the services do.
+	userConn, err := grpcclient.Dial(grpcclient.Config{
+		Name:         "user-service",
+		Target:       g.userServiceAddr,
+		Service:      "user.UserService",
+		RetryMethods: []string{"GetUser", "BatchGetUsers", "GetAddress", "ListAddresses"},
+		Credentials:  g.tls.ClientCredentials("user-service"),
+	})
+	if err != nil {
+		return err
+	}
+	defer userConn.Close()
+
+	orderConn, err := grpcclient.Dial(grpcclient.Config{
+		Name:         "order-service",
+		Target:       g.orderServiceAddr,
+		Service:      "order.OrderService",
+		RetryMethods: []string{"GetOrder", "ListOrders", "GetOrderHistory"},
+		Credentials:  g.tls.ClientCredentials("order-service"),
+	})
+	if err != nil {
+		return err
+	}
+	defer orderConn.Close()
+
+	productConn, err := grpcclient.Dial(grpcclient.Config{
+		Name:         "product-service",
+		Target:       g.productServiceAddr,
+		Service:      "product.ProductService",
+		RetryMethods: []string{"GetProduct", "ListProducts"},
+		Credentials:  g.tls.ClientCredentials("product-service"),
+	})
+	if err != nil {
+		return err
+	}
+	defer productConn.Close()
 
 	// Register services
-	if err := user.RegisterUserServiceHandlerFromEndpoint(ctx, mux, g.userServiceAddr, opts); err != nil {
+	if err := user.RegisterUserServiceHandler(ctx, mux, userConn); err != nil {
+		return err
+	}
+	if err := order.RegisterOrderServiceHandler(ctx, mux, orderConn); err != nil {
 		return err
 	}
-	if err := order.RegisterOrderServiceHandlerFromEndpoint(ctx, mux, g.orderServiceAddr, opts); err != nil {
+	if err := product.RegisterProductServiceHandler(ctx, mux, productConn); err != nil {
 		return err
 	}
-	if err := product.RegisterProductServiceHandlerFromEndpoint(ctx, mux, g.productServiceAddr, opts); err != nil {
+	// Promotions, carts and shipments are served by the order service.
+	if err := promotion.RegisterPromotionServiceHandler(ctx, mux, orderConn); err != nil {
 		return err
 	}
+	if err := cart.RegisterCartServiceHandler(ctx, mux, orderConn); err != nil {
+		return err
+	}
+	if err := shipment.RegisterShipmentServiceHandler(ctx, mux, orderConn); err != nil {
+		return err
+	}
+	if err := mux.HandlePath(http.MethodPost, carrierWebhookPath,
+		carrierWebhook(shipment.NewShipmentServiceClient(orderConn), g.carriers)); err != nil {
+		return err
+	}
+
+	// Add CORS and panic recovery middleware
+	handler := corsMiddleware(recovery.HTTPMiddleware(publicOnly(mux)))
 
-	// Add CORS middleware
-	handler := corsMiddleware(mux)
+	// Start a server span per request, continuing any trace sent by the
+	// client; the grpcclient connections propagate it to the backends.
+	handler = otelhttp.NewHandler(handler, "gateway",
+		otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+			return r.Method + " " + r.URL.Path
+		}),
+	)
 
 	return http.ListenAndServe(":"+port, handler)
 }
 
+// headerMatcher forwards the Idempotency-Key header to the services as
+// metadata, in addition to the headers grpc-gateway forwards by default.
+func headerMatcher(key string) (string, bool) {
+	if strings.EqualFold(key, "Idempotency-Key") {
+		return "idempotency-key", true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+// publicOnly answers requests for internalMethods with 404, as if they
+// were not routed at all.
+func publicOnly(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if internalMethods[path.Clean(r.URL.Path)] {
+			http.NotFound(w, r)
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
 func corsMiddleware(h http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
 		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, Idempotency-Key")
 
 		if r.Method == "OPTIONS" {
 			w.WriteHeader(http.StatusOK)
@@ -0,0 +1,131 @@
+package gateway
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dipendra-mule/microservice-with-grpc/proto/shipment"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+)
+
+// fakeShipments records the tracking events it receives.
+type fakeShipments struct {
+	shipment.ShipmentServiceClient
+	events []*shipment.RecordTrackingEventRequest
+}
+
+func (f *fakeShipments) GetShipment(context.Context, *shipment.GetShipmentRequest, ...grpc.CallOption) (*shipment.ShipmentResponse, error) {
+	return &shipment.ShipmentResponse{Shipment: &shipment.Shipment{Id: "s1"}}, nil
+}
+
+func (f *fakeShipments) RecordTrackingEvent(_ context.Context, r *shipment.RecordTrackingEventRequest, _ ...grpc.CallOption) (*shipment.ShipmentResponse, error) {
+	f.events = append(f.events, r)
+	return &shipment.ShipmentResponse{}, nil
+}
+
+func newTestMux(t *testing.T, client *fakeShipments, verifiers map[string]SignatureVerifier) http.Handler {
+	t.Helper()
+	mux := runtime.NewServeMux()
+	if err := shipment.RegisterShipmentServiceHandlerClient(context.Background(), mux, client); err != nil {
+		t.Fatal(err)
+	}
+	if err := mux.HandlePath(http.MethodPost, carrierWebhookPath, carrierWebhook(client, verifiers)); err != nil {
+		t.Fatal(err)
+	}
+	return publicOnly(mux)
+}
+
+func sign(secret, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestPublicOnly(t *testing.T) {
+	client := &fakeShipments{}
+	h := newTestMux(t, client, nil)
+
+	tests := []struct {
+		path string
+		want int
+	}{
+		{"/shipment.ShipmentService/GetShipment", http.StatusOK},
+		{"/shipment.ShipmentService/RecordTrackingEvent", http.StatusNotFound},
+		{"/shipment.ShipmentService/./RecordTrackingEvent", http.StatusNotFound},
+		{"/shipment.ShipmentService/RecordTrackingEven%74", http.StatusNotFound},
+		{"/shipment.ShipmentService/RecordTrackingEvent/", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(`{"shipment_id":"s1","status":"delivered"}`))
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Errorf("POST %s = %d, want %d", tt.path, rec.Code, tt.want)
+			}
+		})
+	}
+	if len(client.events) != 0 {
+		t.Errorf("tracking events reached the service: %v", client.events)
+	}
+}
+
+func TestCarrierWebhook(t *testing.T) {
+	const secret = "s3cret"
+	const body = `{"tracking_number":"1Z999","event_id":"e1","status":"delivered"}`
+	verifiers := map[string]SignatureVerifier{
+		"ups": HMACVerifier{Secret: []byte(secret), Header: "X-Signature"},
+	}
+
+	tests := []struct {
+		name      string
+		carrier   string
+		signature string
+		body      string
+		want      int
+	}{
+		{"valid", "ups", sign(secret, body), body, http.StatusNoContent},
+		{"unknown carrier", "dhl", sign(secret, body), body, http.StatusNotFound},
+		{"missing signature", "ups", "", body, http.StatusUnauthorized},
+		{"wrong secret", "ups", sign("other", body), body, http.StatusUnauthorized},
+		{"tampered body", "ups", sign(secret, body), strings.Replace(body, "e1", "e2", 1), http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &fakeShipments{}
+			h := newTestMux(t, client, verifiers)
+
+			req := httptest.NewRequest(http.MethodPost, "/webhooks/carriers/"+tt.carrier, strings.NewReader(tt.body))
+			if tt.signature != "" {
+				req.Header.Set("X-Signature", tt.signature)
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.want)
+			}
+
+			if tt.want != http.StatusNoContent {
+				if len(client.events) != 0 {
+					t.Errorf("rejected webhook recorded %d events", len(client.events))
+				}
+				return
+			}
+			if len(client.events) != 1 {
+				t.Fatalf("recorded %d events, want 1", len(client.events))
+			}
+			e := client.events[0]
+			if e.GetTracking().GetCarrier() != "ups" || e.GetTracking().GetTrackingNumber() != "1Z999" ||
+				e.EventId != "e1" || e.Status != "delivered" {
+				t.Errorf("recorded %v", e)
+			}
+		})
+	}
+}
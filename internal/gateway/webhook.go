@@ -0,0 +1,123 @@
+package gateway
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/logger"
+	"github.com/dipendra-mule/microservice-with-grpc/proto/shipment"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// carrierWebhookPath receives tracking events from carriers.
+const carrierWebhookPath = "/webhooks/carriers/{carrier}"
+
+// maxWebhookBody bounds the request bodies read from carriers.
+const maxWebhookBody = 1 << 20
+
+var ErrInvalidSignature = errors.New("invalid webhook signature")
+
+// SignatureVerifier checks that a webhook request comes from the carrier
+// it claims to, typically by a signature over the body. Carriers sign in
+// different ways, so each registered carrier has its own verifier.
+type SignatureVerifier interface {
+	Verify(header http.Header, body []byte) error
+}
+
+// HMACVerifier accepts requests whose Header holds the hex HMAC-SHA256 of
+// the body under Secret, optionally prefixed with "sha256=".
+type HMACVerifier struct {
+	Secret []byte
+	Header string
+}
+
+func (v HMACVerifier) Verify(header http.Header, body []byte) error {
+	got, err := hex.DecodeString(strings.TrimPrefix(header.Get(v.Header), "sha256="))
+	if err != nil || len(got) == 0 {
+		return ErrInvalidSignature
+	}
+	mac := hmac.New(sha256.New, v.Secret)
+	mac.Write(body)
+	if !hmac.Equal(got, mac.Sum(nil)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// trackingWebhook is the body carriers post: one tracking event of a
+// shipment. status takes the values of RecordTrackingEventRequest.status.
+type trackingWebhook struct {
+	TrackingNumber string    `json:"tracking_number"`
+	EventID        string    `json:"event_id"`
+	Status         string    `json:"status"`
+	Description    string    `json:"description"`
+	Location       string    `json:"location"`
+	OccurredAt     time.Time `json:"occurred_at"` // RFC 3339; defaults to now
+}
+
+// carrierWebhook verifies a carrier's tracking event and records it
+// through ShipmentService.RecordTrackingEvent. Carriers retry on any
+// non-2xx response, which is safe because events are deduplicated by
+// event_id.
+func carrierWebhook(client shipment.ShipmentServiceClient, verifiers map[string]SignatureVerifier) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		carrier := params["carrier"]
+		verifier, ok := verifiers[carrier]
+		if !ok {
+			http.Error(w, "unknown carrier", http.StatusNotFound)
+			return
+		}
+
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxWebhookBody+1))
+		if err != nil {
+			http.Error(w, "failed to read body", http.StatusBadRequest)
+			return
+		}
+		if len(body) > maxWebhookBody {
+			http.Error(w, "body too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		if err := verifier.Verify(r.Header, body); err != nil {
+			logger.FromContext(r.Context()).Warn("Rejected carrier webhook",
+				zap.String("carrier", carrier), zap.Error(err))
+			http.Error(w, "invalid signature", http.StatusUnauthorized)
+			return
+		}
+
+		var event trackingWebhook
+		if err := json.Unmarshal(body, &event); err != nil {
+			http.Error(w, "invalid body", http.StatusBadRequest)
+			return
+		}
+		req := &shipment.RecordTrackingEventRequest{
+			Shipment: &shipment.RecordTrackingEventRequest_Tracking{Tracking: &shipment.CarrierTracking{
+				Carrier:        carrier,
+				TrackingNumber: event.TrackingNumber,
+			}},
+			EventId:     event.EventID,
+			Status:      event.Status,
+			Description: event.Description,
+			Location:    event.Location,
+		}
+		if !event.OccurredAt.IsZero() {
+			req.OccurredAt = timestamppb.New(event.OccurredAt)
+		}
+
+		if _, err := client.RecordTrackingEvent(r.Context(), req); err != nil {
+			st := status.Convert(err)
+			http.Error(w, st.Message(), runtime.HTTPStatusFromCode(st.Code()))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,75 @@
+package order
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/errs"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/logger"
+	"github.com/dipendra-mule/microservice-with-grpc/proto/order"
+	"github.com/dipendra-mule/microservice-with-grpc/proto/user"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var ErrShippingAddressNotFound = errs.New(errs.NotFound, "SHIPPING_ADDRESS_NOT_FOUND", "shipping address not found")
+
+// subdivisionCode matches the region part of a tax region such as "US-CA".
+var subdivisionCode = regexp.MustCompile(`^[A-Z0-9]{1,3}$`)
+
+// shippingAddress returns the address an order ships to and the address
+// book entry it comes from: the one the request names or gives inline, or
+// else the user's default shipping address. It returns nil if there is
+// none, or if the default cannot be looked up because the user service is
+// unavailable; such an order is placed without an address rather than
+// failed. CreateOrder has checked that the caller may act for r.UserId, so
+// the user service trusts this service to read the address book.
+func (s *Service) shippingAddress(ctx context.Context, r *order.CreateOrderRequest) (*user.PostalAddress, string, error) {
+	switch shipping := r.Shipping.(type) {
+	case *order.CreateOrderRequest_ShippingAddress:
+		return shipping.ShippingAddress, "", nil
+	case *order.CreateOrderRequest_ShippingAddressId:
+		resp, err := s.userClient.GetAddress(ctx, &user.GetAddressRequest{
+			Id:     shipping.ShippingAddressId,
+			UserId: r.UserId,
+		})
+		if status.Code(err) == codes.NotFound {
+			return nil, "", ErrShippingAddressNotFound.WithResource("address", shipping.ShippingAddressId).
+				WithField("shipping_address_id", "not in the user's address book")
+		}
+		if err != nil {
+			return nil, "", errs.FromRemote(fmt.Errorf("failed to get address: %w", err), "user-service")
+		}
+		return resp.Address.Address, resp.Address.Id, nil
+	}
+
+	resp, err := s.userClient.ListAddresses(ctx, &user.ListAddressesRequest{UserId: r.UserId})
+	if err != nil {
+		remote := errs.FromRemote(fmt.Errorf("failed to list addresses: %w", err), "user-service")
+		if remote.Kind == errs.Unavailable {
+			logger.FromContext(ctx).Warn("Placing order without default shipping address", zap.Error(err))
+			return nil, "", nil
+		}
+		return nil, "", remote
+	}
+	for _, a := range resp.Addresses {
+		if a.DefaultShipping {
+			return a.Address, a.Id, nil
+		}
+	}
+	return nil, "", nil
+}
+
+// taxRegion returns the tax region of an address, e.g. "US-CA" for a
+// Californian address, or just the country when the address has no
+// subdivision code.
+func taxRegion(a *user.PostalAddress) string {
+	region := strings.ToUpper(strings.TrimSpace(a.Region))
+	if subdivisionCode.MatchString(region) {
+		return a.CountryCode + "-" + region
+	}
+	return a.CountryCode
+}
@@ -0,0 +1,276 @@
+package order
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/auth"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/errs"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/logger"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/money"
+	"github.com/dipendra-mule/microservice-with-grpc/proto/order"
+	"github.com/dipendra-mule/microservice-with-grpc/proto/product"
+	"go.uber.org/zap"
+)
+
+// Order statuses.
+const (
+	StatusPending   = "pending"
+	StatusConfirmed = "confirmed"
+	StatusShipped   = "shipped"
+	StatusDelivered = "delivered"
+	StatusCancelled = "cancelled"
+)
+
+// Who cancelled an order: its owner or an admin.
+const (
+	ActorCustomer = "customer"
+	ActorAdmin    = "admin"
+)
+
+// stockReleaseBatch is the number of pending releases sent per run.
+const stockReleaseBatch = 100
+
+var (
+	ErrNotOrderOwner       = errs.New(errs.PermissionDenied, "NOT_ORDER_OWNER", "order belongs to another user")
+	ErrOrderNotCancellable = errs.New(errs.FailedPrecondition, "ORDER_NOT_CANCELLABLE", "order can no longer be cancelled")
+	ErrOrderNotRefundable  = errs.New(errs.FailedPrecondition, "ORDER_NOT_REFUNDABLE", "order cannot be refunded")
+	ErrInvalidRefund       = errs.New(errs.InvalidArgument, "INVALID_REFUND", "invalid refund")
+
+	ErrInvalidStatusTransition = errs.New(errs.FailedPrecondition, "INVALID_STATUS_TRANSITION", "order cannot move to this status")
+)
+
+// transitions lists the status changes UpdateOrderStatus may make. Orders
+// become shipped and delivered through their shipments and cancelled
+// through CancelOrder, which release stock and check what has shipped; a
+// cancelled order stays cancelled.
+var transitions = map[string][]string{
+	StatusPending: {StatusConfirmed},
+}
+
+func canTransition(from, to string) bool {
+	for _, s := range transitions[from] {
+		if s == to {
+			return true
+		}
+	}
+	return false
+}
+
+// cancellable reports whether an order in status may be cancelled: only
+// before it ships.
+func cancellable(status string) bool {
+	return status == StatusPending || status == StatusConfirmed
+}
+
+func shipped(status string) bool {
+	return status == StatusShipped || status == StatusDelivered
+}
+
+// CancelOrder cancels an order that has not shipped and releases its stock.
+// Only the order's owner and admins may cancel it.
+func (s *Service) CancelOrder(ctx context.Context, r *order.CancelOrderRequest) (*order.Order, error) {
+	actor, err := callerActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cancelledBy := ActorAdmin
+	if actor.Role != auth.RoleAdmin {
+		cancelledBy = ActorCustomer
+		o, err := s.repo.GetOrderByID(ctx, r.OrderId)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkOrderOwner(actor, o); err != nil {
+			return nil, err
+		}
+	}
+
+	o, err := s.repo.CancelOrder(ctx, r.OrderId, r.Reason, cancelledBy, actor)
+	if err != nil {
+		return nil, err
+	}
+	ordersCancelled.WithLabelValues(cancelledBy).Inc()
+	orderStatusUpdates.WithLabelValues(o.Status).Inc()
+	s.releaseStock(ctx, o.Id)
+	return o, nil
+}
+
+// RefundOrder refunds the requested quantities, or everything not refunded
+// yet, of a confirmed, shipped or delivered order.
+func (s *Service) RefundOrder(ctx context.Context, r *order.RefundOrderRequest) (*order.Order, *order.Refund, error) {
+	actor, err := callerActor(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	o, refund, err := s.repo.RefundOrder(ctx, r.OrderId, actor, func(o *order.Order) (*order.Refund, error) {
+		return planRefund(o, r)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	amount, err := money.FromProto(refund.Amount)
+	if err != nil {
+		return nil, nil, err
+	}
+	refunds.Inc()
+	refundedAmount.WithLabelValues(amount.Currency).Observe(float64(amount.Float()))
+	s.releaseStock(ctx, o.Id)
+	return o, refund, nil
+}
+
+// fullyRefunded reports whether every unit of o has been refunded.
+func fullyRefunded(o *order.Order) bool {
+	for _, item := range o.Items {
+		if item.RefundedQuantity < item.Quantity {
+			return false
+		}
+	}
+	return true
+}
+
+// planRefund works out the lines and amount of a refund against o. A line
+// refunds its share of what was paid for the item, discounts and tax
+// included; refunding the last units of an item returns exactly what is
+// left of it, so rounding never adds up to more than was paid.
+func planRefund(o *order.Order, r *order.RefundOrderRequest) (*order.Refund, error) {
+	if o.Status != StatusConfirmed && !shipped(o.Status) {
+		return nil, ErrOrderNotRefundable.WithResource("order", o.Id).WithMetadata("status", o.Status)
+	}
+
+	items := make(map[string]*order.OrderItem, len(o.Items))
+	for _, item := range o.Items {
+		items[item.ProductId] = item
+	}
+
+	requested := r.Items
+	if len(requested) == 0 {
+		for _, item := range o.Items {
+			if n := item.Quantity - item.RefundedQuantity; n > 0 {
+				requested = append(requested, &order.RefundItem{ProductId: item.ProductId, Quantity: n})
+			}
+		}
+		if len(requested) == 0 {
+			return nil, ErrOrderNotRefundable.WithResource("order", o.Id).WithMetadata("status", o.Status).
+				WithField("items", "everything was already refunded")
+		}
+	}
+
+	invalid := ErrInvalidRefund
+	valid := true
+	seen := make(map[string]bool, len(requested))
+	for i, ri := range requested {
+		item, ok := items[ri.ProductId]
+		switch {
+		case !ok:
+			invalid, valid = invalid.WithField(fmt.Sprintf("items[%d].product_id", i), "not in the order"), false
+		case seen[ri.ProductId]:
+			invalid, valid = invalid.WithField(fmt.Sprintf("items[%d].product_id", i), "listed more than once"), false
+		case ri.Quantity > item.Quantity-item.RefundedQuantity:
+			invalid, valid = invalid.WithField(fmt.Sprintf("items[%d].quantity", i),
+				fmt.Sprintf("at most %d left to refund", item.Quantity-item.RefundedQuantity)), false
+		}
+		seen[ri.ProductId] = true
+	}
+	if !valid {
+		return nil, invalid
+	}
+
+	total := money.Zero(o.Total.CurrencyCode)
+	refund := &order.Refund{Reason: r.Reason}
+	for _, ri := range requested {
+		amount, err := refundAmount(items[ri.ProductId], ri.Quantity)
+		if err != nil {
+			return nil, err
+		}
+		if total, err = total.Add(amount); err != nil {
+			return nil, err
+		}
+		refund.Items = append(refund.Items, &order.RefundLine{
+			ProductId: ri.ProductId,
+			Quantity:  ri.Quantity,
+			Amount:    amount.Proto(),
+		})
+	}
+	refund.Amount = total.Proto()
+	return refund, nil
+}
+
+// refundAmount returns the amount refunded for quantity units of item.
+func refundAmount(item *order.OrderItem, quantity int32) (money.Amount, error) {
+	subtotal, err := money.FromProto(item.Subtotal)
+	if err != nil {
+		return money.Amount{}, err
+	}
+	itemTax, err := money.FromProto(item.Tax)
+	if err != nil {
+		return money.Amount{}, err
+	}
+	paid, err := subtotal.Add(itemTax)
+	if err != nil {
+		return money.Amount{}, err
+	}
+	if quantity == item.Quantity-item.RefundedQuantity {
+		refunded, err := money.FromProto(item.RefundedAmount)
+		if err != nil {
+			return money.Amount{}, err
+		}
+		return paid.Sub(refunded)
+	}
+	return paid.MulRat(big.NewRat(int64(quantity), int64(item.Quantity)))
+}
+
+// releaseStock sends the order's queued stock releases to the product
+// service. The order change is already committed, so failures are only
+// logged; StartStockRelease retries them.
+func (s *Service) releaseStock(ctx context.Context, orderID string) {
+	if err := s.sendStockReleases(ctx, orderID); err != nil {
+		logger.FromContext(ctx).Warn("Failed to release stock, will retry",
+			zap.String("order_id", orderID), zap.Error(err))
+	}
+}
+
+// StartStockRelease sends pending stock releases every interval until ctx
+// is cancelled.
+func (s *Service) StartStockRelease(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if err := s.sendStockReleases(ctx, ""); err != nil {
+					logger.FromContext(ctx).Warn("Failed to release stock", zap.Error(err))
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// sendStockReleases sends pending releases, of one order or of any if
+// orderID is empty, and marks each one the product service accepted.
+func (s *Service) sendStockReleases(ctx context.Context, orderID string) error {
+	releases, err := s.repo.PendingStockReleases(ctx, orderID, stockReleaseBatch)
+	if err != nil {
+		return err
+	}
+	for _, release := range releases {
+		_, err := s.productClient.ReleaseStock(ctx, &product.ReleaseStockRequest{
+			Reference: release.Reference,
+			Items:     release.Items,
+		})
+		if err != nil {
+			stockReleaseFailures.Inc()
+			return errs.FromRemote(fmt.Errorf("failed to release stock for %s: %w", release.Reference, err), "product-service")
+		}
+		if err := s.repo.MarkStockReleased(ctx, release.Reference); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,205 @@
+package order
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/money"
+	"github.com/dipendra-mule/microservice-with-grpc/proto/order"
+)
+
+func usd(minor int64) money.Amount {
+	return money.New("USD", minor)
+}
+
+func orderItem(productID string, quantity int32, subtotal, tax int64) *order.OrderItem {
+	return &order.OrderItem{
+		ProductId:      productID,
+		Quantity:       quantity,
+		Subtotal:       usd(subtotal).Proto(),
+		Tax:            usd(tax).Proto(),
+		RefundedAmount: usd(0).Proto(),
+	}
+}
+
+func testOrder(status string, items ...*order.OrderItem) *order.Order {
+	return &order.Order{Id: "order-1", Status: status, Items: items, Total: usd(0).Proto()}
+}
+
+// applyRefund records refund on o the way the repository does.
+func applyRefund(t *testing.T, o *order.Order, refund *order.Refund) {
+	t.Helper()
+	for _, line := range refund.Items {
+		for _, item := range o.Items {
+			if item.ProductId != line.ProductId {
+				continue
+			}
+			refunded, _ := money.FromProto(item.RefundedAmount)
+			amount, _ := money.FromProto(line.Amount)
+			sum, err := refunded.Add(amount)
+			if err != nil {
+				t.Fatal(err)
+			}
+			item.RefundedQuantity += line.Quantity
+			item.RefundedAmount = sum.Proto()
+		}
+	}
+}
+
+func TestPlanRefundUnitByUnit(t *testing.T) {
+	// 3 units paid 11.00 in total: 3.67, 3.67 and what is left, 3.66.
+	o := testOrder(StatusDelivered, orderItem("p1", 3, 1000, 100))
+
+	var total int64
+	for _, want := range []int64{367, 367, 366} {
+		refund, err := planRefund(o, &order.RefundOrderRequest{
+			Items: []*order.RefundItem{{ProductId: "p1", Quantity: 1}},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if refund.Amount.MinorUnits != want || refund.Items[0].Amount.MinorUnits != want {
+			t.Errorf("refund = %d, want %d", refund.Amount.MinorUnits, want)
+		}
+		applyRefund(t, o, refund)
+		total += refund.Amount.MinorUnits
+	}
+	if total != 1100 {
+		t.Errorf("refunded %d in total, want exactly the 1100 paid", total)
+	}
+}
+
+func TestPlanRefund(t *testing.T) {
+	tests := []struct {
+		name  string
+		order *order.Order
+		items []*order.RefundItem
+		want  map[string]int64 // product: amount
+	}{
+		{
+			name:  "everything",
+			order: testOrder(StatusConfirmed, orderItem("p1", 2, 2000, 380), orderItem("p2", 1, 500, 0)),
+			want:  map[string]int64{"p1": 2380, "p2": 500},
+		},
+		{
+			name: "everything left",
+			order: func() *order.Order {
+				item := orderItem("p1", 4, 1000, 0)
+				item.RefundedQuantity, item.RefundedAmount = 1, usd(250).Proto()
+				return testOrder(StatusShipped, item, orderItem("p2", 1, 500, 0))
+			}(),
+			want: map[string]int64{"p1": 750, "p2": 500},
+		},
+		{
+			name:  "some units",
+			order: testOrder(StatusShipped, orderItem("p1", 3, 999, 0), orderItem("p2", 1, 500, 0)),
+			items: []*order.RefundItem{{ProductId: "p1", Quantity: 2}},
+			want:  map[string]int64{"p1": 666},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			refund, err := planRefund(tt.order, &order.RefundOrderRequest{Reason: "damaged", Items: tt.items})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var sum int64
+			got := make(map[string]int64)
+			for _, line := range refund.Items {
+				got[line.ProductId] = line.Amount.MinorUnits
+				sum += line.Amount.MinorUnits
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("refund lines = %v, want %v", got, tt.want)
+			}
+			for p, want := range tt.want {
+				if got[p] != want {
+					t.Errorf("refund lines = %v, want %v", got, tt.want)
+					break
+				}
+			}
+			if refund.Amount.MinorUnits != sum || refund.Reason != "damaged" {
+				t.Errorf("refund = %d %q, want the lines' sum %d", refund.Amount.MinorUnits, refund.Reason, sum)
+			}
+		})
+	}
+}
+
+func TestPlanRefundInvalid(t *testing.T) {
+	refunded := orderItem("p1", 1, 1000, 0)
+	refunded.RefundedQuantity, refunded.RefundedAmount = 1, usd(1000).Proto()
+
+	tests := []struct {
+		name    string
+		order   *order.Order
+		items   []*order.RefundItem
+		wantErr error
+	}{
+		{"pending order", testOrder(StatusPending, orderItem("p1", 1, 1000, 0)), nil, ErrOrderNotRefundable},
+		{"cancelled order", testOrder(StatusCancelled, orderItem("p1", 1, 1000, 0)), nil, ErrOrderNotRefundable},
+		{"already refunded", testOrder(StatusDelivered, refunded), nil, ErrOrderNotRefundable},
+		{
+			"unknown product", testOrder(StatusDelivered, orderItem("p1", 1, 1000, 0)),
+			[]*order.RefundItem{{ProductId: "p9", Quantity: 1}}, ErrInvalidRefund,
+		},
+		{
+			"product listed twice", testOrder(StatusDelivered, orderItem("p1", 2, 1000, 0)),
+			[]*order.RefundItem{{ProductId: "p1", Quantity: 1}, {ProductId: "p1", Quantity: 1}}, ErrInvalidRefund,
+		},
+		{
+			"more than left", testOrder(StatusDelivered, orderItem("p1", 2, 1000, 0)),
+			[]*order.RefundItem{{ProductId: "p1", Quantity: 3}}, ErrInvalidRefund,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := planRefund(tt.order, &order.RefundOrderRequest{Items: tt.items})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("planRefund() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCanTransition(t *testing.T) {
+	tests := []struct {
+		from, to string
+		want     bool
+	}{
+		{StatusPending, StatusConfirmed, true},
+		{StatusConfirmed, StatusPending, false},
+		{StatusConfirmed, StatusShipped, false},
+		{StatusShipped, StatusDelivered, false},
+		{StatusCancelled, StatusPending, false},
+		{StatusCancelled, StatusConfirmed, false},
+	}
+	for _, tt := range tests {
+		if got := canTransition(tt.from, tt.to); got != tt.want {
+			t.Errorf("canTransition(%s, %s) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestFullyRefunded(t *testing.T) {
+	partly := orderItem("p2", 2, 1000, 0)
+	partly.RefundedQuantity = 1
+	refunded := orderItem("p1", 1, 1000, 0)
+	refunded.RefundedQuantity = 1
+	allOfP2 := orderItem("p2", 2, 1000, 0)
+	allOfP2.RefundedQuantity = 2
+
+	tests := []struct {
+		name  string
+		order *order.Order
+		want  bool
+	}{
+		{"nothing refunded", testOrder(StatusConfirmed, orderItem("p1", 1, 1000, 0)), false},
+		{"one unit left", testOrder(StatusConfirmed, refunded, partly), false},
+		{"everything", testOrder(StatusConfirmed, refunded, allOfP2), true},
+	}
+	for _, tt := range tests {
+		if got := fullyRefunded(tt.order); got != tt.want {
+			t.Errorf("%s: fullyRefunded() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
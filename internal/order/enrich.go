@@ -0,0 +1,69 @@
+package order
+
+import (
+	"context"
+
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/logger"
+	"github.com/dipendra-mule/microservice-with-grpc/proto/order"
+	"github.com/dipendra-mule/microservice-with-grpc/proto/user"
+	"go.uber.org/zap"
+)
+
+// userBatchSize is the largest batch user-service accepts.
+const userBatchSize = 100
+
+// WarningUserUnavailable is reported when orders are returned without the
+// requested user info.
+const WarningUserUnavailable = "USER_UNAVAILABLE"
+
+// enrichUsers sets Order.User on each order, looking every distinct user up
+// once. Failing lookups never fail the request: the orders are returned as
+// they are, with a warning for the caller.
+func (s *Service) enrichUsers(ctx context.Context, orders []*order.Order) []*order.Warning {
+	var ids []string
+	seen := make(map[string]bool)
+	for _, o := range orders {
+		if o.UserId != "" && !seen[o.UserId] {
+			seen[o.UserId] = true
+			ids = append(ids, o.UserId)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	users, err := s.lookupUsers(ctx, ids)
+	for _, o := range orders {
+		o.User = users[o.UserId]
+	}
+	if err == nil {
+		return nil
+	}
+
+	userEnrichmentFailures.Inc()
+	logger.FromContext(ctx).Warn("Returning orders without user info", zap.Error(err))
+	return []*order.Warning{{
+		Reason:  WarningUserUnavailable,
+		Message: "user info is temporarily unavailable",
+	}}
+}
+
+// lookupUsers fetches the given users with as few BatchGetUsers calls as
+// the batch limit allows. Users that no longer exist are left out without
+// an error; any other failure is returned along with the users that were
+// found.
+func (s *Service) lookupUsers(ctx context.Context, ids []string) (map[string]*user.User, error) {
+	users := make(map[string]*user.User, len(ids))
+	for len(ids) > 0 {
+		n := min(len(ids), userBatchSize)
+		resp, err := s.userClient.BatchGetUsers(ctx, &user.BatchGetUsersRequest{Ids: ids[:n]})
+		if err != nil {
+			return users, err
+		}
+		for _, u := range resp.Users {
+			users[u.Id] = u
+		}
+		ids = ids[n:]
+	}
+	return users, nil
+}
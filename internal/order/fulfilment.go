@@ -0,0 +1,95 @@
+package order
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/tracing"
+	"github.com/dipendra-mule/microservice-with-grpc/proto/order"
+)
+
+// ShippedQuantities returns the quantities of an order already in
+// shipments, by product. An order has a single line per product, which
+// CreateOrder and the order_items unique index ensure.
+func ShippedQuantities(ctx context.Context, tx *sql.Tx, orderID string) (map[string]int32, error) {
+	qctx, end := tracing.Query(ctx, "SELECT", "shipment_items")
+	rows, err := tx.QueryContext(qctx, `
+		SELECT si.product_id, SUM(si.quantity)
+		FROM shipment_items si
+		JOIN shipments s ON s.id = si.shipment_id
+		WHERE s.order_id = $1
+		GROUP BY si.product_id
+	`, orderID)
+	end(err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shipped quantities: %w", err)
+	}
+	defer rows.Close()
+
+	shipped := make(map[string]int32)
+	for rows.Next() {
+		var productID string
+		var quantity int32
+		if err := rows.Scan(&productID, &quantity); err != nil {
+			return nil, fmt.Errorf("failed to scan shipped quantity: %w", err)
+		}
+		shipped[productID] = quantity
+	}
+	return shipped, rows.Err()
+}
+
+// unshipped returns how many units of item are still in the warehouse.
+// Refunds take unshipped units first, so once a refund covers shipped
+// units none are left.
+func unshipped(item *order.OrderItem, shipped map[string]int32) int32 {
+	return max(item.Quantity-item.RefundedQuantity-shipped[item.ProductId], 0)
+}
+
+// fullyShipped reports whether shipped covers every unit of o that was not
+// refunded.
+func fullyShipped(o *order.Order, shipped map[string]int32) bool {
+	for _, item := range o.Items {
+		if unshipped(item, shipped) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// AdvanceStatus moves o, locked in tx by LockOrder, to shipped once every
+// unit not refunded is in a shipment, and on to delivered once every
+// shipment is delivered. It is called after each change that can complete
+// either: new shipments, delivery events and refunds.
+func AdvanceStatus(ctx context.Context, tx *sql.Tx, o *order.Order, actor *order.Actor) error {
+	if o.Status != StatusConfirmed && o.Status != StatusShipped {
+		return nil
+	}
+	shipped, err := ShippedQuantities(ctx, tx, o.Id)
+	if err != nil || len(shipped) == 0 {
+		return err
+	}
+
+	if o.Status == StatusConfirmed {
+		if !fullyShipped(o, shipped) {
+			return nil
+		}
+		if err := SetStatus(ctx, tx, o, StatusShipped, "all items shipped", actor); err != nil {
+			return err
+		}
+	}
+
+	var pending bool
+	qctx, end := tracing.Query(ctx, "SELECT", "shipments")
+	err = tx.QueryRowContext(qctx, `
+		SELECT EXISTS (SELECT 1 FROM shipments WHERE order_id = $1 AND status <> 'delivered')
+	`, o.Id).Scan(&pending)
+	end(err)
+	if err != nil {
+		return fmt.Errorf("failed to check shipments: %w", err)
+	}
+	if pending {
+		return nil
+	}
+	return SetStatus(ctx, tx, o, StatusDelivered, "all shipments delivered", actor)
+}
@@ -0,0 +1,35 @@
+package order
+
+import (
+	"testing"
+
+	"github.com/dipendra-mule/microservice-with-grpc/proto/order"
+)
+
+func TestUnshipped(t *testing.T) {
+	tests := []struct {
+		name               string
+		quantity, refunded int32
+		shipped            int32
+		want               int32
+	}{
+		{"nothing shipped", 3, 0, 0, 3},
+		{"partly shipped", 3, 0, 2, 1},
+		{"unshipped unit refunded", 3, 1, 2, 0},
+		{"shipped units refunded", 3, 2, 3, 0},
+		{"all shipped", 3, 0, 3, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := &order.OrderItem{ProductId: "p1", Quantity: tt.quantity, RefundedQuantity: tt.refunded}
+			shipped := map[string]int32{"p1": tt.shipped}
+			if got := unshipped(item, shipped); got != tt.want {
+				t.Errorf("unshipped() = %d, want %d", got, tt.want)
+			}
+			o := &order.Order{Items: []*order.OrderItem{item}}
+			if got := fullyShipped(o, shipped); got != (tt.want == 0) {
+				t.Errorf("fullyShipped() = %v, want %v", got, tt.want == 0)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package order
+
+import (
+	"context"
+
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/auth"
+	"github.com/dipendra-mule/microservice-with-grpc/proto/order"
+)
+
+// Types of order events.
+const (
+	EventCreated       = "created"
+	EventStatusChanged = "status_changed"
+	EventCancelled     = "cancelled"
+	EventRefunded      = "refunded"
+)
+
+// ActorFromContext returns who is making a change: the authenticated
+// caller, or for requests without a token only role, the part the request
+// itself implies.
+func ActorFromContext(ctx context.Context, role string) *order.Actor {
+	claims, ok := auth.FromContext(ctx)
+	if !ok {
+		return &order.Actor{Role: role}
+	}
+	return &order.Actor{UserId: claims.UserID, Email: claims.Email, Role: claims.Role}
+}
+
+// callerActor returns the authenticated caller as the actor of a change
+// that must not be made anonymously.
+func callerActor(ctx context.Context) (*order.Actor, error) {
+	claims, ok := auth.FromContext(ctx)
+	if !ok {
+		return nil, auth.ErrTokenRequired
+	}
+	return &order.Actor{UserId: claims.UserID, Email: claims.Email, Role: claims.Role}, nil
+}
+
+// checkOrderOwner allows actor to act on o if actor owns it or is an admin.
+func checkOrderOwner(actor *order.Actor, o *order.Order) error {
+	if actor.Role != auth.RoleAdmin && o.UserId != actor.UserId {
+		return ErrNotOrderOwner.WithResource("order", o.Id)
+	}
+	return nil
+}
+
+// GetOrderHistory returns every recorded change to an order, oldest first.
+// Only the order's owner and admins may read it, and only admins see who
+// made each change by email.
+func (s *Service) GetOrderHistory(ctx context.Context, r *order.GetOrderHistoryRequest) ([]*order.OrderEvent, error) {
+	actor, err := callerActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if actor.Role != auth.RoleAdmin {
+		o, err := s.repo.GetOrderByID(ctx, r.OrderId)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkOrderOwner(actor, o); err != nil {
+			return nil, err
+		}
+	}
+	events, err := s.repo.GetOrderHistory(ctx, r.OrderId)
+	if err != nil {
+		return nil, err
+	}
+	return redactActors(actor, events), nil
+}
+
+// redactActors removes the actors' emails from events unless actor is an
+// admin.
+func redactActors(actor *order.Actor, events []*order.OrderEvent) []*order.OrderEvent {
+	if actor.Role == auth.RoleAdmin {
+		return events
+	}
+	for _, e := range events {
+		if e.Actor != nil {
+			e.Actor.Email = ""
+		}
+	}
+	return events
+}
@@ -0,0 +1,51 @@
+package order
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/auth"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/errs"
+	"github.com/dipendra-mule/microservice-with-grpc/proto/order"
+)
+
+func TestCheckOrderOwner(t *testing.T) {
+	o := &order.Order{Id: "order-1", UserId: "u1"}
+	tests := []struct {
+		name    string
+		actor   *order.Actor
+		wantErr error
+	}{
+		{"owner", &order.Actor{UserId: "u1", Role: "user"}, nil},
+		{"admin", &order.Actor{UserId: "a1", Role: auth.RoleAdmin}, nil},
+		{"other user", &order.Actor{UserId: "u2", Role: "user"}, ErrNotOrderOwner},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkOrderOwner(tt.actor, o)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("checkOrderOwner() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil && errs.KindOf(err) != errs.PermissionDenied {
+				t.Errorf("kind = %v, want permission denied", errs.KindOf(err))
+			}
+		})
+	}
+}
+
+func TestRedactActors(t *testing.T) {
+	events := func() []*order.OrderEvent {
+		return []*order.OrderEvent{
+			{Actor: &order.Actor{UserId: "a1", Email: "ops@example.com", Role: auth.RoleAdmin}},
+			{Actor: &order.Actor{}},
+		}
+	}
+	for _, e := range redactActors(&order.Actor{UserId: "u1", Role: "user"}, events()) {
+		if e.Actor.Email != "" {
+			t.Errorf("owner sees email %q", e.Actor.Email)
+		}
+	}
+	if got := redactActors(&order.Actor{Role: auth.RoleAdmin}, events()); got[0].Actor.Email != "ops@example.com" {
+		t.Errorf("admin sees email %q", got[0].Actor.Email)
+	}
+}
@@ -0,0 +1,105 @@
+package order
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"time"
+
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/errs"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/logger"
+	"github.com/dipendra-mule/microservice-with-grpc/proto/order"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+// IdempotencyKeyHeader is the metadata key, and through the gateway the
+// HTTP header, that may carry CreateOrderRequest.idempotency_key.
+// Replayed responses carry the idempotent-replayed header.
+const (
+	IdempotencyKeyHeader = "idempotency-key"
+	replayedHeader       = "idempotent-replayed"
+)
+
+var (
+	ErrInvalidIdempotencyKey  = errs.New(errs.InvalidArgument, "INVALID_IDEMPOTENCY_KEY", "invalid idempotency key")
+	ErrIdempotencyKeyReused   = errs.New(errs.InvalidArgument, "IDEMPOTENCY_KEY_REUSED", "idempotency key was already used for a different request")
+	ErrIdempotencyKeyMismatch = errs.New(errs.InvalidArgument, "IDEMPOTENCY_KEY_MISMATCH", "idempotency key in request and metadata differ")
+)
+
+// idempotencyKey returns the request's idempotency key from the request
+// field or metadata, or "" if the client sent none.
+func idempotencyKey(ctx context.Context, r *order.CreateOrderRequest) (string, error) {
+	var fromMetadata string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if v := md.Get(IdempotencyKeyHeader); len(v) > 0 {
+			fromMetadata = v[0]
+		}
+	}
+	switch {
+	case r.IdempotencyKey == "":
+		if len(fromMetadata) > 128 {
+			return "", ErrInvalidIdempotencyKey.WithField(IdempotencyKeyHeader, "at most 128 characters")
+		}
+		return fromMetadata, nil
+	case fromMetadata != "" && fromMetadata != r.IdempotencyKey:
+		return "", ErrIdempotencyKeyMismatch.WithField("idempotency_key", "differs from the "+IdempotencyKeyHeader+" metadata")
+	default:
+		return r.IdempotencyKey, nil
+	}
+}
+
+// requestHash fingerprints a request so that a key reused for a different
+// order can be told apart from a retry.
+func requestHash(r *order.CreateOrderRequest) ([]byte, error) {
+	c := proto.Clone(r).(*order.CreateOrderRequest)
+	c.IdempotencyKey = ""
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(c)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash request: %w", err)
+	}
+	sum := sha256.Sum256(b)
+	return sum[:], nil
+}
+
+// replay returns the order recorded under key, or nil if the key is
+// unused. The response is marked with the idempotent-replayed header.
+func (s *Service) replay(ctx context.Context, userID, key string, hash []byte) (*order.Order, error) {
+	storedHash, o, ok, err := s.repo.GetIdempotentResponse(ctx, userID, key)
+	if err != nil || !ok {
+		return nil, err
+	}
+	if !bytes.Equal(storedHash, hash) {
+		return nil, ErrIdempotencyKeyReused.WithField("idempotency_key", "used for a different request")
+	}
+
+	idempotentReplays.Inc()
+	if err := grpc.SetHeader(ctx, metadata.Pairs(replayedHeader, "true")); err != nil {
+		logger.FromContext(ctx).Debug("Failed to set replay header", zap.Error(err))
+	}
+	return o, nil
+}
+
+// StartIdempotencyExpiry deletes expired idempotency keys every interval
+// until ctx is cancelled. Expired keys are already ignored; this only
+// reclaims space.
+func (s *Service) StartIdempotencyExpiry(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if _, err := s.repo.DeleteExpiredIdempotencyKeys(ctx); err != nil {
+					logger.FromContext(ctx).Warn("Failed to delete expired idempotency keys", zap.Error(err))
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
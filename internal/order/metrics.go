@@ -0,0 +1,55 @@
+package order
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	ordersCreated = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "orders_created_total",
+		Help: "Total number of orders created, by initial status.",
+	}, []string{"status"})
+
+	orderStatusUpdates = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "order_status_updates_total",
+		Help: "Total number of order status changes, by new status.",
+	}, []string{"status"})
+
+	orderValue = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "order_value",
+		Help:    "Total amount of created orders, by currency.",
+		Buckets: prometheus.ExponentialBuckets(5, 2, 12),
+	}, []string{"currency"})
+
+	userEnrichmentFailures = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "order_user_enrichment_failures_total",
+		Help: "Total number of responses returned without the requested user info.",
+	})
+
+	idempotentReplays = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "order_idempotent_replays_total",
+		Help: "Total number of CreateOrder retries answered with the original order.",
+	})
+
+	ordersCancelled = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "orders_cancelled_total",
+		Help: "Total number of orders cancelled, by who cancelled them.",
+	}, []string{"cancelled_by"})
+
+	refunds = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "order_refunds_total",
+		Help: "Total number of refunds issued.",
+	})
+
+	refundedAmount = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "order_refunded_amount",
+		Help:    "Amount of issued refunds, by currency.",
+		Buckets: prometheus.ExponentialBuckets(5, 2, 12),
+	}, []string{"currency"})
+
+	stockReleaseFailures = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "order_stock_release_failures_total",
+		Help: "Total number of failed attempts to return stock to the product service.",
+	})
+)
@@ -0,0 +1,41 @@
+package order
+
+import (
+	"fmt"
+
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/errs"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/money"
+	"github.com/dipendra-mule/microservice-with-grpc/proto/order"
+	"github.com/dipendra-mule/microservice-with-grpc/proto/product"
+)
+
+var ErrMixedCurrencies = errs.New(errs.FailedPrecondition, "MIXED_CURRENCIES", "all products in an order must be priced in the same currency")
+
+// ProductPrice returns the exact unit price of p, falling back to the
+// deprecated float price for products written before unit_price existed.
+func ProductPrice(p *product.Product) (money.Amount, error) {
+	if p.UnitPrice == nil {
+		price, err := money.FromFloat(money.DefaultCurrency, p.Price)
+		if err != nil {
+			return money.Amount{}, errs.Wrap(fmt.Errorf("product %s has an invalid legacy price: %w", p.Id, err), errs.Internal, "", "")
+		}
+		return price, nil
+	}
+	price, err := money.FromProto(p.UnitPrice)
+	if err != nil {
+		return money.Amount{}, errs.Wrap(fmt.Errorf("product %s has an invalid price: %w", p.Id, err), errs.Internal, "", "")
+	}
+	return price, nil
+}
+
+// setTotal sets the order total along with its deprecated float mirror.
+func setTotal(o *order.Order, total money.Amount) {
+	o.Total = total.Proto()
+	o.TotalAmount = total.Float()
+}
+
+// setUnitPrice sets the item price along with its deprecated float mirror.
+func setUnitPrice(item *order.OrderItem, price money.Amount) {
+	item.UnitPrice = price.Proto()
+	item.Price = price.Float()
+}
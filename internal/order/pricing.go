@@ -0,0 +1,211 @@
+package order
+
+import (
+	"context"
+	"sort"
+
+	"github.com/dipendra-mule/microservice-with-grpc/internal/promotion"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/exchange"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/money"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/tax"
+	"github.com/dipendra-mule/microservice-with-grpc/proto/order"
+	"github.com/dipendra-mule/microservice-with-grpc/proto/product"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// pricedOrder holds the items and totals of an order request priced in
+// the order's currency.
+type pricedOrder struct {
+	items        []*order.OrderItem
+	subtotal     money.Amount
+	discount     money.Amount
+	tax          money.Amount
+	total        money.Amount
+	taxInclusive bool
+	rates        []*order.ExchangeRate
+	adjustments  []*order.Adjustment
+}
+
+// price prices the requested items in the order currency. Each unit price
+// is converted once and then multiplied, so an item's line total never
+// depends on the quantities of other items. Every currency pair uses one
+// rate for the whole order, and that rate is recorded for audit. Coupon
+// codes are then applied to the line totals, and tax is computed per line
+// on the discounted amount from the shipping region and product category;
+// in tax-inclusive regions the line amount already contains it.
+func (s *Service) price(ctx context.Context, r *order.CreateOrderRequest, products map[string]*product.Product) (*pricedOrder, error) {
+	priced := &pricedOrder{items: make([]*order.OrderItem, len(r.Items))}
+	currency := r.Currency
+	applied := make(map[string]*exchange.Rate)
+	lines := make([]promotion.Line, len(r.Items))
+
+	for i, item := range r.Items {
+		p, ok := products[item.ProductId]
+		if !ok {
+			return nil, ErrProductNotFound.WithResource("product", item.ProductId)
+		}
+		original, err := ProductPrice(p)
+		if err != nil {
+			return nil, err
+		}
+		if currency == "" {
+			currency = original.Currency
+		}
+		if original.Currency != currency && r.Currency == "" {
+			// Without an explicit currency there is no single natural one
+			// to convert a mixed cart to.
+			return nil, ErrMixedCurrencies.WithField(itemField(r.Items, item.ProductId),
+				"priced in "+original.Currency+", order is in "+currency)
+		}
+
+		price := original
+		if rate, ok := applied[original.Currency]; ok {
+			price, err = original.Convert(currency, rate.Value)
+		} else {
+			var used *exchange.Rate
+			price, used, err = exchange.Convert(ctx, s.rates, original, currency)
+			if used != nil {
+				applied[original.Currency] = used
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		lineTotal, err := price.Mul(int64(item.Quantity))
+		if err != nil {
+			return nil, err
+		}
+		lines[i] = promotion.Line{
+			ProductID: item.ProductId,
+			Category:  p.Category,
+			Quantity:  int64(item.Quantity),
+			UnitPrice: price,
+			Amount:    lineTotal,
+		}
+
+		priced.items[i] = &order.OrderItem{
+			ProductId:   item.ProductId,
+			Quantity:    item.Quantity,
+			ProductName: p.Name,
+		}
+		setUnitPrice(priced.items[i], price)
+		if price.Currency != original.Currency {
+			priced.items[i].OriginalUnitPrice = original.Proto()
+		}
+	}
+
+	discounts, err := s.discount(ctx, r, lines, currency)
+	if err != nil {
+		return nil, err
+	}
+	priced.subtotal = money.Zero(currency)
+	priced.discount = money.Zero(currency)
+	priced.tax = money.Zero(currency)
+	for i, item := range priced.items {
+		lineDiscount := money.Zero(currency)
+		for _, d := range discounts {
+			if d.Line != i {
+				continue
+			}
+			if lineDiscount, err = lineDiscount.Add(d.Amount); err != nil {
+				return nil, err
+			}
+		}
+		amount, err := lines[i].Amount.Sub(lineDiscount)
+		if err != nil {
+			return nil, err
+		}
+
+		rates, err := s.taxes.Rates(ctx, r.ShippingRegion, lines[i].Category)
+		if err != nil {
+			return nil, err
+		}
+		taxed, err := tax.Apply(amount, rates)
+		if err != nil {
+			return nil, err
+		}
+		priced.taxInclusive = rates.Inclusive
+
+		if priced.subtotal, err = priced.subtotal.Add(taxed.Net); err != nil {
+			return nil, err
+		}
+		if priced.discount, err = priced.discount.Add(lineDiscount); err != nil {
+			return nil, err
+		}
+		if priced.tax, err = priced.tax.Add(taxed.Tax); err != nil {
+			return nil, err
+		}
+		item.Subtotal = taxed.Net.Proto()
+		item.Discount = lineDiscount.Proto()
+		item.Tax = taxed.Tax.Proto()
+		item.TaxLines = taxLines(taxed.Lines)
+	}
+
+	for _, d := range discounts {
+		amount, err := money.Zero(currency).Sub(d.Amount)
+		if err != nil {
+			return nil, err
+		}
+		priced.adjustments = append(priced.adjustments, &order.Adjustment{
+			Kind:        AdjustmentPromotion,
+			PromotionId: d.Promotion.Id,
+			Code:        d.Promotion.Code,
+			Description: d.Promotion.Description,
+			ProductId:   lines[d.Line].ProductID,
+			Amount:      amount.Proto(),
+		})
+	}
+
+	total, err := priced.subtotal.Add(priced.tax)
+	if err != nil {
+		return nil, err
+	}
+	priced.total = total
+
+	for _, rate := range applied {
+		priced.rates = append(priced.rates, &order.ExchangeRate{
+			FromCurrency: rate.From,
+			ToCurrency:   rate.To,
+			Rate:         rate.Decimal(),
+			AsOf:         timestamppb.New(rate.AsOf),
+			Source:       rate.Source,
+		})
+	}
+	sort.Slice(priced.rates, func(i, j int) bool {
+		return priced.rates[i].FromCurrency < priced.rates[j].FromCurrency
+	})
+	return priced, nil
+}
+
+// AdjustmentPromotion is the kind of adjustments made by coupon codes.
+const AdjustmentPromotion = "promotion"
+
+// discount evaluates the request's coupon codes against the priced lines.
+// min_order_value is compared with the order amount before discounts.
+func (s *Service) discount(ctx context.Context, r *order.CreateOrderRequest, lines []promotion.Line, currency string) ([]promotion.Discount, error) {
+	if len(r.CouponCodes) == 0 {
+		return nil, nil
+	}
+	orderValue := money.Zero(currency)
+	for _, l := range lines {
+		var err error
+		if orderValue, err = orderValue.Add(l.Amount); err != nil {
+			return nil, err
+		}
+	}
+	return s.promotions.Evaluate(ctx, r.UserId, r.CouponCodes, lines, orderValue)
+}
+
+// taxLines converts computed taxes for storage on an order item.
+func taxLines(lines []tax.Line) []*order.TaxLine {
+	out := make([]*order.TaxLine, len(lines))
+	for i, l := range lines {
+		out[i] = &order.TaxLine{
+			Name:   l.Name,
+			Rate:   l.Rate.FloatString(6),
+			Amount: l.Amount.Proto(),
+		}
+	}
+	return out
+}
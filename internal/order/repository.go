@@ -3,16 +3,26 @@ package order
 import (
 	"context"
 	"database/sql"
-	"errors"
+	"encoding/json"
 	"fmt"
 	"time"
 
+	"github.com/dipendra-mule/microservice-with-grpc/internal/promotion"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/database"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/errs"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/money"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/pagination"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/tracing"
+	moneypb "github.com/dipendra-mule/microservice-with-grpc/proto/money"
 	"github.com/dipendra-mule/microservice-with-grpc/proto/order"
+	"github.com/dipendra-mule/microservice-with-grpc/proto/product"
+	"github.com/dipendra-mule/microservice-with-grpc/proto/user"
 	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
 )
 
 var (
-	ErrOrderNotFound = errors.New("order not found")
+	ErrOrderNotFound = errs.New(errs.NotFound, "ORDER_NOT_FOUND", "order not found")
 )
 
 type Repository struct {
@@ -25,88 +35,366 @@ func NewRepository(db *sql.DB) *Repository {
 	}
 }
 
-func (r *Repository) CreateOrder(ctx context.Context, o *order.Order) (*order.Order, error) {
+// IdempotencyKey is a client-supplied key claimed by the order being
+// created.
+type IdempotencyKey struct {
+	Key         string
+	RequestHash []byte
+	ExpiresAt   time.Time
+}
+
+// errIdempotencyKeyTaken means another request holds an unexpired claim on
+// the key.
+var errIdempotencyKeyTaken = errs.New(errs.AlreadyExists, "IDEMPOTENCY_KEY_TAKEN", "idempotency key already used")
+
+// CreateOrder stores a new order and its created event. With a non-nil key
+// the order's response is recorded under it in the same transaction; if
+// the key is already claimed nothing is stored and errIdempotencyKeyTaken
+// is returned.
+func (r *Repository) CreateOrder(ctx context.Context, o *order.Order, key *IdempotencyKey, actor *order.Actor) (*order.Order, error) {
 	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	defer tx.Rollback()
 
+	// Claim the key first: a concurrent request with the same key blocks
+	// here until this transaction ends.
+	if key != nil {
+		if err := claimIdempotencyKey(ctx, tx, o.UserId, key); err != nil {
+			return nil, err
+		}
+	}
+
 	// Generate order ID if not set
 	if o.Id == "" {
 		o.Id = uuid.New().String()
 	}
 
+	total, err := money.FromProto(o.Total)
+	if err != nil {
+		return nil, err
+	}
+	subtotal, err := money.FromProto(o.Subtotal)
+	if err != nil {
+		return nil, err
+	}
+	taxTotal, err := money.FromProto(o.TaxTotal)
+	if err != nil {
+		return nil, err
+	}
+	discountTotal, err := money.FromProto(o.DiscountTotal)
+	if err != nil {
+		return nil, err
+	}
+	address, err := encodeAddress(o.ShippingAddress)
+	if err != nil {
+		return nil, err
+	}
+	var addressID sql.NullString
+	if o.ShippingAddressId != "" {
+		addressID = sql.NullString{String: o.ShippingAddressId, Valid: true}
+	}
 	now := time.Now()
 
 	// Insert order
 	orderQuery := `
-        INSERT INTO orders (id, user_id, total_amount, status, created_at, updated_at)
-        VALUES ($1, $2, $3, $4, $5, $6)
-        RETURNING id, user_id, total_amount, status, created_at, updated_at
-    `
+        INSERT INTO orders (id, user_id, total_amount, currency, status, created_at, updated_at,
+            subtotal, tax_total, shipping_region, tax_inclusive, discount_total,
+            shipping_address, shipping_address_id)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+        RETURNING ` + orderColumns
 
-	var createdOrder order.Order
-	err = tx.QueryRowContext(ctx, orderQuery,
-		o.Id, o.UserId, o.TotalAmount, o.Status, now, now,
-	).Scan(
-		&createdOrder.Id, &createdOrder.UserId, &createdOrder.TotalAmount,
-		&createdOrder.Status, &createdOrder.CreatedAt, &createdOrder.UpdatedAt,
-	)
+	qctx, end := tracing.Query(ctx, "INSERT", "orders")
+	createdOrder, err := scanOrder(tx.QueryRowContext(qctx, orderQuery,
+		o.Id, o.UserId, total.Decimal(), total.Currency, o.Status, now, now,
+		subtotal.Decimal(), taxTotal.Decimal(), o.ShippingRegion, o.TaxInclusive, discountTotal.Decimal(),
+		address, addressID,
+	))
+	end(err)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create order: %w", err)
 	}
 
 	// Insert order items
 	itemQuery := `
-        INSERT INTO order_items (id, order_id, product_id, quantity, price, product_name)
-        VALUES ($1, $2, $3, $4, $5, $6)
+        INSERT INTO order_items (id, order_id, product_id, quantity, price, product_name, original_price, original_currency,
+            subtotal, tax, tax_lines, discount)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
     `
 
 	for _, item := range o.Items {
+		price, err := money.FromProto(item.UnitPrice)
+		if err != nil {
+			return nil, err
+		}
+		var originalPrice, originalCurrency sql.NullString
+		if item.OriginalUnitPrice != nil {
+			original, err := money.FromProto(item.OriginalUnitPrice)
+			if err != nil {
+				return nil, err
+			}
+			originalPrice = sql.NullString{String: original.Decimal(), Valid: true}
+			originalCurrency = sql.NullString{String: original.Currency, Valid: true}
+		}
+		itemSubtotal, err := money.FromProto(item.Subtotal)
+		if err != nil {
+			return nil, err
+		}
+		itemTax, err := money.FromProto(item.Tax)
+		if err != nil {
+			return nil, err
+		}
+		taxLines, err := encodeTaxLines(item.TaxLines)
+		if err != nil {
+			return nil, err
+		}
+		itemDiscount, err := money.FromProto(item.Discount)
+		if err != nil {
+			return nil, err
+		}
 		itemID := uuid.New().String()
-		_, err = tx.ExecContext(ctx, itemQuery,
-			itemID, o.Id, item.ProductId, item.Quantity, item.Price, item.ProductName,
+		qctx, end := tracing.Query(ctx, "INSERT", "order_items")
+		_, err = tx.ExecContext(qctx, itemQuery,
+			itemID, o.Id, item.ProductId, item.Quantity, price.Decimal(), item.ProductName,
+			originalPrice, originalCurrency, itemSubtotal.Decimal(), itemTax.Decimal(), taxLines, itemDiscount.Decimal(),
 		)
+		end(err)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create order item: %w", err)
 		}
 	}
 
+	// Record the exchange rates the order was priced with
+	for _, rate := range o.ExchangeRates {
+		qctx, end := tracing.Query(ctx, "INSERT", "order_exchange_rates")
+		_, err = tx.ExecContext(qctx, `
+			INSERT INTO order_exchange_rates (order_id, from_currency, to_currency, rate, as_of, source)
+			VALUES ($1, $2, $3, $4, $5, $6)
+		`, o.Id, rate.FromCurrency, rate.ToCurrency, rate.Rate, rate.AsOf.AsTime(), rate.Source)
+		end(err)
+		if err != nil {
+			return nil, fmt.Errorf("failed to record exchange rate: %w", err)
+		}
+	}
+
+	// Record discounts and redeem each promotion once, enforcing its usage
+	// limits
+	redeemed := make(map[string]bool)
+	for i, adj := range o.Adjustments {
+		amount, err := money.FromProto(adj.Amount)
+		if err != nil {
+			return nil, err
+		}
+		var promotionID, productID sql.NullString
+		if adj.PromotionId != "" {
+			promotionID = sql.NullString{String: adj.PromotionId, Valid: true}
+		}
+		if adj.ProductId != "" {
+			productID = sql.NullString{String: adj.ProductId, Valid: true}
+		}
+		qctx, end := tracing.Query(ctx, "INSERT", "order_adjustments")
+		_, err = tx.ExecContext(qctx, `
+			INSERT INTO order_adjustments (order_id, position, kind, promotion_id, code, description, product_id, amount)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		`, o.Id, i, adj.Kind, promotionID, adj.Code, adj.Description, productID, amount.Decimal())
+		end(err)
+		if err != nil {
+			return nil, fmt.Errorf("failed to record order adjustment: %w", err)
+		}
+
+		if promotionID.Valid && !redeemed[adj.PromotionId] {
+			redeemed[adj.PromotionId] = true
+			if err := promotion.Redeem(ctx, tx, adj.PromotionId, o.Id, o.UserId); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := appendEvent(ctx, tx, orderEvent{
+		OrderID:   o.Id,
+		Type:      EventCreated,
+		NewStatus: createdOrder.Status,
+		Actor:     actor,
+	}); err != nil {
+		return nil, err
+	}
+
+	createdOrder.Items = o.Items
+	createdOrder.ExchangeRates = o.ExchangeRates
+	createdOrder.Adjustments = o.Adjustments
+	if key != nil {
+		if err := storeIdempotentResponse(ctx, tx, createdOrder, key); err != nil {
+			return nil, err
+		}
+	}
+
 	if err := tx.Commit(); err != nil {
 		return nil, fmt.Errorf("failed to commit transaction: %w", err)
 	}
+	return createdOrder, nil
+}
+
+func claimIdempotencyKey(ctx context.Context, tx *sql.Tx, userID string, key *IdempotencyKey) error {
+	qctx, end := tracing.Query(ctx, "INSERT", "idempotency_keys")
+	res, err := tx.ExecContext(qctx, `
+		INSERT INTO idempotency_keys (user_id, key, request_hash, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (user_id, key) DO UPDATE
+		SET request_hash = EXCLUDED.request_hash, order_id = NULL, response = NULL,
+			created_at = EXCLUDED.created_at, expires_at = EXCLUDED.expires_at
+		WHERE idempotency_keys.expires_at <= now()
+	`, userID, key.Key, key.RequestHash, time.Now(), key.ExpiresAt)
+	end(err)
+	if err != nil {
+		return fmt.Errorf("failed to claim idempotency key: %w", err)
+	}
+	if n, err := res.RowsAffected(); err != nil || n == 0 {
+		return errIdempotencyKeyTaken
+	}
+	return nil
+}
 
-	createdOrder.Items = o.Items
-	return &createdOrder, nil
+func storeIdempotentResponse(ctx context.Context, tx *sql.Tx, o *order.Order, key *IdempotencyKey) error {
+	response, err := proto.Marshal(o)
+	if err != nil {
+		return fmt.Errorf("failed to serialize order: %w", err)
+	}
+	qctx, end := tracing.Query(ctx, "UPDATE", "idempotency_keys")
+	_, err = tx.ExecContext(qctx, `
+		UPDATE idempotency_keys SET order_id = $3, response = $4
+		WHERE user_id = $1 AND key = $2
+	`, o.UserId, key.Key, o.Id, response)
+	end(err)
+	if err != nil {
+		return fmt.Errorf("failed to store idempotent response: %w", err)
+	}
+	return nil
+}
+
+// GetIdempotentResponse returns the request hash and order recorded under
+// an unexpired key, or ok false if there is none.
+func (r *Repository) GetIdempotentResponse(ctx context.Context, userID, key string) (hash []byte, o *order.Order, ok bool, err error) {
+	var response []byte
+	qctx, end := tracing.Query(ctx, "SELECT", "idempotency_keys")
+	err = r.db.QueryRowContext(qctx, `
+		SELECT request_hash, response
+		FROM idempotency_keys
+		WHERE user_id = $1 AND key = $2 AND expires_at > now()
+	`, userID, key).Scan(&hash, &response)
+	end(err)
+	if err == sql.ErrNoRows {
+		return nil, nil, false, nil
+	}
+	if err != nil {
+		return nil, nil, false, fmt.Errorf("failed to get idempotency key: %w", err)
+	}
+
+	o = &order.Order{}
+	if err := proto.Unmarshal(response, o); err != nil {
+		return nil, nil, false, fmt.Errorf("invalid idempotent response for key %s: %w", key, err)
+	}
+	return hash, o, true, nil
+}
+
+// DeleteExpiredIdempotencyKeys deletes expired keys and returns how many
+// there were.
+func (r *Repository) DeleteExpiredIdempotencyKeys(ctx context.Context) (int64, error) {
+	qctx, end := tracing.Query(ctx, "DELETE", "idempotency_keys")
+	res, err := r.db.ExecContext(qctx, "DELETE FROM idempotency_keys WHERE expires_at <= now()")
+	end(err)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired idempotency keys: %w", err)
+	}
+	return res.RowsAffected()
 }
 
 func (r *Repository) GetOrderByID(ctx context.Context, id string) (*order.Order, error) {
-	var o order.Order
+	return getOrder(ctx, r.db, id, false)
+}
+
+// LockOrder reads an order in tx and locks it until tx ends, for
+// subsystems such as shipments that change orders in their own
+// transactions.
+func LockOrder(ctx context.Context, tx *sql.Tx, id string) (*order.Order, error) {
+	return getOrder(ctx, tx, id, true)
+}
+
+// SetStatus changes the status of o, locked in tx by LockOrder, and
+// records the change in its history.
+func SetStatus(ctx context.Context, tx *sql.Tx, o *order.Order, status, reason string, actor *order.Actor) error {
+	qctx, end := tracing.Query(ctx, "UPDATE", "orders")
+	_, err := tx.ExecContext(qctx, "UPDATE orders SET status = $2, updated_at = $3 WHERE id = $1", o.Id, status, time.Now())
+	end(err)
+	if err != nil {
+		return fmt.Errorf("failed to update order status: %w", err)
+	}
+	if err := appendEvent(ctx, tx, orderEvent{
+		OrderID:        o.Id,
+		Type:           EventStatusChanged,
+		PreviousStatus: o.Status,
+		NewStatus:      status,
+		Reason:         reason,
+		Actor:          actor,
+	}); err != nil {
+		return err
+	}
+	orderStatusUpdates.WithLabelValues(status).Inc()
+	o.Status = status
+	return nil
+}
 
+// querier is implemented by *sql.DB and *sql.Tx.
+type querier interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// getOrder reads a complete order. With lock set the order row stays
+// locked until the transaction q ends.
+func getOrder(ctx context.Context, q querier, id string, lock bool) (*order.Order, error) {
 	orderQuery := `
-        SELECT id, user_id, total_amount, status, created_at, updated_at
+        SELECT ` + orderColumns + `
         FROM orders
         WHERE id = $1
 		`
-	err := r.db.QueryRowContext(ctx, orderQuery, id).Scan(
-		&o.Id, &o.UserId, &o.TotalAmount, &o.Status, &o.CreatedAt, &o.UpdatedAt,
-	)
+	if lock {
+		orderQuery += " FOR UPDATE"
+	}
+	qctx, end := tracing.Query(ctx, "SELECT", "orders")
+	o, err := scanOrder(q.QueryRowContext(qctx, orderQuery, id))
+	end(err)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, ErrOrderNotFound
+			return nil, ErrOrderNotFound.WithResource("order", id)
 		}
 		return nil, fmt.Errorf("failed to get order: %w", err)
 	}
 
-	// Get order items
+	if o.Items, err = getOrderItems(ctx, q, o); err != nil {
+		return nil, err
+	}
+	if o.ExchangeRates, err = getExchangeRates(ctx, q, o.Id); err != nil {
+		return nil, err
+	}
+	if o.Adjustments, err = getAdjustments(ctx, q, o); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+func getOrderItems(ctx context.Context, q querier, o *order.Order) ([]*order.OrderItem, error) {
 	itemsQuery := `
-		SELECT product_id, quantity, price, product_name
+		SELECT product_id, quantity, price, product_name, original_price, original_currency,
+			subtotal, tax, tax_lines, discount, refunded_quantity, refunded_amount
 		FROM order_items
 		WHERE order_id = $1
 	`
 
-	rows, err := r.db.QueryContext(ctx, itemsQuery, id)
+	qctx, end := tracing.Query(ctx, "SELECT", "order_items")
+	rows, err := q.QueryContext(qctx, itemsQuery, o.Id)
+	end(err)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get order items: %w", err)
 	}
@@ -116,72 +404,722 @@ func (r *Repository) GetOrderByID(ctx context.Context, id string) (*order.Order,
 	var items []*order.OrderItem
 	for rows.Next() {
 		var item order.OrderItem
-		if err := rows.Scan(&item.ProductId, &item.Quantity, &item.Price, &item.ProductName); err != nil {
+		var price string
+		var originalPrice, originalCurrency sql.NullString
+		var subtotal, tax, discount, refundedAmount string
+		var taxLines []byte
+		if err := rows.Scan(&item.ProductId, &item.Quantity, &price, &item.ProductName, &originalPrice, &originalCurrency,
+			&subtotal, &tax, &taxLines, &discount, &item.RefundedQuantity, &refundedAmount); err != nil {
 			return nil, fmt.Errorf("failed to scan order item: %w", err)
 		}
+		// Items are priced in the order's currency.
+		currency := o.Total.CurrencyCode
+		unitPrice, err := money.Parse(currency, price)
+		if err != nil {
+			return nil, fmt.Errorf("invalid price in order %s: %w", o.Id, err)
+		}
+		setUnitPrice(&item, unitPrice)
+		if item.Subtotal, err = parseProto(currency, subtotal); err != nil {
+			return nil, fmt.Errorf("invalid item subtotal in order %s: %w", o.Id, err)
+		}
+		if item.Tax, err = parseProto(currency, tax); err != nil {
+			return nil, fmt.Errorf("invalid item tax in order %s: %w", o.Id, err)
+		}
+		if item.TaxLines, err = decodeTaxLines(currency, taxLines); err != nil {
+			return nil, fmt.Errorf("invalid tax lines in order %s: %w", o.Id, err)
+		}
+		if item.Discount, err = parseProto(currency, discount); err != nil {
+			return nil, fmt.Errorf("invalid item discount in order %s: %w", o.Id, err)
+		}
+		if item.RefundedAmount, err = parseProto(currency, refundedAmount); err != nil {
+			return nil, fmt.Errorf("invalid item refund in order %s: %w", o.Id, err)
+		}
+		if originalPrice.Valid {
+			original, err := money.Parse(originalCurrency.String, originalPrice.String)
+			if err != nil {
+				return nil, fmt.Errorf("invalid original price in order %s: %w", o.Id, err)
+			}
+			item.OriginalUnitPrice = original.Proto()
+		}
 		items = append(items, &item) // Append item to list
 	}
-	o.Items = items // Set items to order
-	return &o, nil
+	return items, rows.Err()
+}
+
+func getExchangeRates(ctx context.Context, q querier, orderID string) ([]*order.ExchangeRate, error) {
+	qctx, end := tracing.Query(ctx, "SELECT", "order_exchange_rates")
+	rows, err := q.QueryContext(qctx, `
+		SELECT from_currency, to_currency, rate, as_of, source
+		FROM order_exchange_rates
+		WHERE order_id = $1
+		ORDER BY from_currency
+	`, orderID)
+	end(err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get exchange rates: %w", err)
+	}
+	defer rows.Close()
+
+	var rates []*order.ExchangeRate
+	for rows.Next() {
+		var rate order.ExchangeRate
+		if err := rows.Scan(&rate.FromCurrency, &rate.ToCurrency, &rate.Rate, database.Timestamp(&rate.AsOf), &rate.Source); err != nil {
+			return nil, fmt.Errorf("failed to scan exchange rate: %w", err)
+		}
+		rates = append(rates, &rate)
+	}
+	return rates, rows.Err()
+}
+
+func getAdjustments(ctx context.Context, q querier, o *order.Order) ([]*order.Adjustment, error) {
+	qctx, end := tracing.Query(ctx, "SELECT", "order_adjustments")
+	rows, err := q.QueryContext(qctx, `
+		SELECT kind, COALESCE(promotion_id::text, ''), code, description, COALESCE(product_id::text, ''), amount
+		FROM order_adjustments
+		WHERE order_id = $1
+		ORDER BY position
+	`, o.Id)
+	end(err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order adjustments: %w", err)
+	}
+	defer rows.Close()
+
+	var adjustments []*order.Adjustment
+	for rows.Next() {
+		var adj order.Adjustment
+		var amount string
+		if err := rows.Scan(&adj.Kind, &adj.PromotionId, &adj.Code, &adj.Description, &adj.ProductId, &amount); err != nil {
+			return nil, fmt.Errorf("failed to scan order adjustment: %w", err)
+		}
+		if adj.Amount, err = parseProto(o.Total.CurrencyCode, amount); err != nil {
+			return nil, fmt.Errorf("invalid adjustment in order %s: %w", o.Id, err)
+		}
+		adjustments = append(adjustments, &adj)
+	}
+	return adjustments, rows.Err()
 }
 
-func (r *Repository) ListOrders(ctx context.Context, userID string, pag, limit int32) ([]*order.Order, int32, error) {
-	offset := (pag - 1) * limit
+// ListOrders returns a user's orders, newest first, for the given page.
+// It returns up to page.Limit+1 rows; see pagination.Trim.
+func (r *Repository) ListOrders(ctx context.Context, userID string, page pagination.Request) ([]*order.Order, error) {
+	after, afterArgs := page.Where(2)
+	limitOffset, limitArgs := page.LimitOffset(2 + len(afterArgs))
 
 	listQuery := `
-		SELECT id, user_id, total_amount, status, created_at, updated_at
+		SELECT ` + orderColumns + `
 		FROM orders
-		WHERE user_id = $1
-		ORDER BY created_at DESC
-		LIMIT $2 OFFSET $3
-	`
-	rows, err := r.db.QueryContext(ctx, listQuery, userID, limit, offset)
+		WHERE user_id = $1 AND ` + after + `
+		` + pagination.OrderBy + `
+		` + limitOffset
+
+	args := append(append([]interface{}{userID}, afterArgs...), limitArgs...)
+	qctx, end := tracing.Query(ctx, "SELECT", "orders")
+	rows, err := r.db.QueryContext(qctx, listQuery, args...)
+	end(err)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to list orders: %w", err)
+		return nil, fmt.Errorf("failed to list orders: %w", err)
 	}
 	defer rows.Close()
 
 	// Create orders list
 	var orders []*order.Order
 	for rows.Next() {
-		var o order.Order
-		if err := rows.Scan(&o.Id, &o.UserId, &o.TotalAmount, &o.Status, &o.CreatedAt, &o.UpdatedAt); err != nil {
-			return nil, 0, fmt.Errorf("failed to scan order: %w", err)
+		o, err := scanOrder(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan order: %w", err)
 		}
-		orders = append(orders, &o)
+		orders = append(orders, o)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list orders: %w", err)
 	}
+	return orders, nil
+}
 
-	// Get total count
+// CountOrders returns the number of orders placed by a user.
+func (r *Repository) CountOrders(ctx context.Context, userID string) (int32, error) {
 	var total int32
 	countQuery := `
 		SELECT COUNT(*) FROM orders WHERE user_id = $1
 		`
-	err = r.db.QueryRowContext(ctx, countQuery, userID).Scan(&total)
+	qctx, end := tracing.Query(ctx, "SELECT COUNT", "orders")
+	err := r.db.QueryRowContext(qctx, countQuery, userID).Scan(&total)
+	end(err)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to get order count: %w", err)
+		return 0, fmt.Errorf("failed to get order count: %w", err)
 	}
-
-	return orders, total, nil
+	return total, nil
 }
 
-// UpdateOrderStatus updates the status of an order
-// and returns the updated order and error
-func (r *Repository) UpdateOrderStatus(ctx context.Context, id, status string) (*order.Order, error) {
+// UpdateOrderStatus updates the status of an order, recording the change
+// in its history, and returns the updated order
+func (r *Repository) UpdateOrderStatus(ctx context.Context, id, status, reason string, actor *order.Actor) (*order.Order, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var previous string
+	qctx, end := tracing.Query(ctx, "SELECT", "orders")
+	err = tx.QueryRowContext(qctx, "SELECT status FROM orders WHERE id = $1 FOR UPDATE", id).Scan(&previous)
+	end(err)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrOrderNotFound.WithResource("order", id)
+		}
+		return nil, fmt.Errorf("failed to get order status: %w", err)
+	}
+	if previous != status && !canTransition(previous, status) {
+		return nil, ErrInvalidStatusTransition.WithResource("order", id).
+			WithMetadata("from", previous).WithMetadata("to", status)
+	}
+
 	updateStatusQuery := `
 		UPDATE orders
 		SET status = $1, updated_at = $2
 		WHERE id = $3
-		RETURNING id, user_id, total_amount, status, created_at, updated_at
-	`
-	var o order.Order
-	err := r.db.QueryRowContext(ctx, updateStatusQuery, status, time.Now(), id).Scan(
-		&o.Id, &o.UserId, &o.TotalAmount, &o.Status, &o.CreatedAt, &o.UpdatedAt,
-	)
+		RETURNING ` + orderColumns
+	qctx, end = tracing.Query(ctx, "UPDATE", "orders")
+	o, err := scanOrder(tx.QueryRowContext(qctx, updateStatusQuery, status, time.Now(), id))
+	end(err)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, ErrOrderNotFound
-		}
 		return nil, fmt.Errorf("failed to update order status: %w", err)
 	}
+
+	if previous != status {
+		if err := appendEvent(ctx, tx, orderEvent{
+			OrderID:        id,
+			Type:           EventStatusChanged,
+			PreviousStatus: previous,
+			NewStatus:      status,
+			Reason:         reason,
+			Actor:          actor,
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return o, nil
+}
+
+// CancelOrder cancels an order whose current status allows it, records the
+// event, queues its items' stock for release and releases its promotion
+// redemptions, in one transaction.
+// cancelledBy says whether the customer or an admin cancelled it.
+func (r *Repository) CancelOrder(ctx context.Context, id, reason, cancelledBy string, actor *order.Actor) (*order.Order, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	o, err := getOrder(ctx, tx, id, true)
+	if err != nil {
+		return nil, err
+	}
+	if !cancellable(o.Status) {
+		return nil, ErrOrderNotCancellable.WithResource("order", id).WithMetadata("status", o.Status)
+	}
+	// Part of a confirmed order may already be on its way.
+	var hasShipments bool
+	qctx, end := tracing.Query(ctx, "SELECT", "shipments")
+	err = tx.QueryRowContext(qctx, "SELECT EXISTS (SELECT 1 FROM shipments WHERE order_id = $1)", id).Scan(&hasShipments)
+	end(err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check shipments: %w", err)
+	}
+	if hasShipments {
+		return nil, ErrOrderNotCancellable.WithResource("order", id).
+			WithMetadata("status", o.Status).WithMetadata("shipped", "partially")
+	}
+
+	now := time.Now()
+	qctx, end = tracing.Query(ctx, "UPDATE", "orders")
+	_, err = tx.ExecContext(qctx, `
+		UPDATE orders
+		SET status = $2, cancellation_reason = $3, cancelled_at = $4, updated_at = $4
+		WHERE id = $1
+	`, id, StatusCancelled, reason, now)
+	end(err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to cancel order: %w", err)
+	}
+
+	if err := appendEvent(ctx, tx, orderEvent{
+		OrderID:        id,
+		Type:           EventCancelled,
+		PreviousStatus: o.Status,
+		NewStatus:      StatusCancelled,
+		Reason:         reason,
+		Actor:          actor,
+		Data:           map[string]string{"cancelled_by": cancelledBy},
+	}); err != nil {
+		return nil, err
+	}
+
+	release := make(map[string]int32, len(o.Items))
+	for _, item := range o.Items {
+		if n := item.Quantity - item.RefundedQuantity; n > 0 {
+			release[item.ProductId] += n
+		}
+	}
+	if err := queueStockRelease(ctx, tx, id, "cancel:"+id, release); err != nil {
+		return nil, err
+	}
+	// A cancelled order did not use its codes: give back the redemptions so
+	// they do not count against the promotion's or the customer's limits.
+	if err := promotion.Release(ctx, tx, id); err != nil {
+		return nil, err
+	}
+
+	cancelled, err := getOrder(ctx, tx, id, false)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return cancelled, nil
+}
+
+// RefundOrder records a refund planned by plan against the locked order:
+// the refund and its lines, the refunded quantities and amounts, and the
+// event. Refunded units that were never shipped are queued for release to
+// stock, an order whose remaining units have all shipped becomes shipped,
+// and a confirmed order refunded in full with nothing shipped is cancelled.
+func (r *Repository) RefundOrder(ctx context.Context, id string, actor *order.Actor, plan func(*order.Order) (*order.Refund, error)) (*order.Order, *order.Refund, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	o, err := getOrder(ctx, tx, id, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	refund, err := plan(o)
+	if err != nil {
+		return nil, nil, err
+	}
+	shipped, err := ShippedQuantities(ctx, tx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	amount, err := money.FromProto(refund.Amount)
+	if err != nil {
+		return nil, nil, err
+	}
+	now := time.Now()
+	refund.Id = uuid.New().String()
+	refund.OrderId = id
+
+	qctx, end := tracing.Query(ctx, "INSERT", "refunds")
+	err = tx.QueryRowContext(qctx, `
+		INSERT INTO refunds (id, order_id, amount, reason, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING created_at
+	`, refund.Id, id, amount.Decimal(), refund.Reason, now).Scan(database.Timestamp(&refund.CreatedAt))
+	end(err)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create refund: %w", err)
+	}
+
+	items := make(map[string]*order.OrderItem, len(o.Items))
+	for _, item := range o.Items {
+		items[item.ProductId] = item
+	}
+	release := make(map[string]int32, len(refund.Items))
+	for _, line := range refund.Items {
+		lineAmount, err := money.FromProto(line.Amount)
+		if err != nil {
+			return nil, nil, err
+		}
+		qctx, end := tracing.Query(ctx, "INSERT", "refund_items")
+		_, err = tx.ExecContext(qctx, `
+			INSERT INTO refund_items (refund_id, product_id, quantity, amount)
+			VALUES ($1, $2, $3, $4)
+		`, refund.Id, line.ProductId, line.Quantity, lineAmount.Decimal())
+		end(err)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create refund item: %w", err)
+		}
+
+		qctx, end = tracing.Query(ctx, "UPDATE", "order_items")
+		_, err = tx.ExecContext(qctx, `
+			UPDATE order_items
+			SET refunded_quantity = refunded_quantity + $3, refunded_amount = refunded_amount + $4
+			WHERE order_id = $1 AND product_id = $2
+		`, id, line.ProductId, line.Quantity, lineAmount.Decimal())
+		end(err)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to update refunded item: %w", err)
+		}
+		// Units still in the warehouse go back to stock; the rest are
+		// with the customer.
+		if n := min(line.Quantity, unshipped(items[line.ProductId], shipped)); n > 0 {
+			release[line.ProductId] += n
+		}
+	}
+
+	qctx, end = tracing.Query(ctx, "UPDATE", "orders")
+	_, err = tx.ExecContext(qctx, `
+		UPDATE orders
+		SET refunded_total = refunded_total + $2, updated_at = $3
+		WHERE id = $1
+	`, id, amount.Decimal(), now)
+	end(err)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to update refunded total: %w", err)
+	}
+
+	if err := appendEvent(ctx, tx, orderEvent{
+		OrderID:        id,
+		Type:           EventRefunded,
+		PreviousStatus: o.Status,
+		NewStatus:      o.Status,
+		Reason:         refund.Reason,
+		Actor:          actor,
+		Data:           map[string]string{"refund_id": refund.Id, "amount": amount.Decimal()},
+	}); err != nil {
+		return nil, nil, err
+	}
+
+	if len(release) > 0 {
+		if err := queueStockRelease(ctx, tx, id, "refund:"+refund.Id, release); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// Refunding the last unshipped units completes the shipping, and an
+	// order refunded in full before anything shipped is over: it is
+	// cancelled, and the codes it redeemed are released as on cancellation.
+	refunded, err := getOrder(ctx, tx, id, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	if refunded.Status == StatusConfirmed && len(shipped) == 0 && fullyRefunded(refunded) {
+		if err := SetStatus(ctx, tx, refunded, StatusCancelled, "refunded in full before shipping", actor); err != nil {
+			return nil, nil, err
+		}
+		if err := promotion.Release(ctx, tx, id); err != nil {
+			return nil, nil, err
+		}
+	} else if err := AdvanceStatus(ctx, tx, refunded, actor); err != nil {
+		return nil, nil, err
+	}
+	if refunded.Status != o.Status {
+		if refunded, err = getOrder(ctx, tx, id, false); err != nil {
+			return nil, nil, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return refunded, refund, nil
+}
+
+// orderEvent is a row of an order's history in order_events.
+type orderEvent struct {
+	OrderID        string
+	Type           string
+	PreviousStatus string
+	NewStatus      string
+	Reason         string
+	Actor          *order.Actor
+	Data           map[string]string
+}
+
+// appendEvent records a change in the transaction that makes it, so the
+// history never misses or invents one.
+func appendEvent(ctx context.Context, tx *sql.Tx, e orderEvent) error {
+	data := []byte("{}")
+	if len(e.Data) > 0 {
+		var err error
+		if data, err = json.Marshal(e.Data); err != nil {
+			return fmt.Errorf("failed to encode order event: %w", err)
+		}
+	}
+	qctx, end := tracing.Query(ctx, "INSERT", "order_events")
+	_, err := tx.ExecContext(qctx, `
+		INSERT INTO order_events (order_id, type, previous_status, new_status, reason,
+			actor, actor_email, actor_role, data, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`, e.OrderID, e.Type, e.PreviousStatus, e.NewStatus, e.Reason,
+		e.Actor.GetUserId(), e.Actor.GetEmail(), e.Actor.GetRole(), data, time.Now())
+	end(err)
+	if err != nil {
+		return fmt.Errorf("failed to record order event: %w", err)
+	}
+	return nil
+}
+
+// GetOrderHistory returns the events of an order, oldest first.
+func (r *Repository) GetOrderHistory(ctx context.Context, orderID string) ([]*order.OrderEvent, error) {
+	var exists bool
+	qctx, end := tracing.Query(ctx, "SELECT", "orders")
+	err := r.db.QueryRowContext(qctx, "SELECT EXISTS (SELECT 1 FROM orders WHERE id = $1)", orderID).Scan(&exists)
+	end(err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+	if !exists {
+		return nil, ErrOrderNotFound.WithResource("order", orderID)
+	}
+
+	qctx, end = tracing.Query(ctx, "SELECT", "order_events")
+	rows, err := r.db.QueryContext(qctx, `
+		SELECT id, type, previous_status, new_status, reason, actor, actor_email, actor_role, data, created_at
+		FROM order_events
+		WHERE order_id = $1
+		ORDER BY id
+	`, orderID)
+	end(err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order history: %w", err)
+	}
+	defer rows.Close()
+
+	var events []*order.OrderEvent
+	for rows.Next() {
+		e := &order.OrderEvent{Actor: &order.Actor{}}
+		var data []byte
+		if err := rows.Scan(&e.Id, &e.Type, &e.PreviousStatus, &e.NewStatus, &e.Reason,
+			&e.Actor.UserId, &e.Actor.Email, &e.Actor.Role, &data, database.Timestamp(&e.CreatedAt)); err != nil {
+			return nil, fmt.Errorf("failed to scan order event: %w", err)
+		}
+		if err := json.Unmarshal(data, &e.Data); err != nil {
+			return nil, fmt.Errorf("invalid data in order event %d: %w", e.Id, err)
+		}
+		events = append(events, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating order events: %w", err)
+	}
+	return events, nil
+}
+
+// StockRelease is stock queued for return to the product service under a
+// reference it applies once.
+type StockRelease struct {
+	Reference string
+	OrderID   string
+	Items     []*product.StockQuantity
+}
+
+func queueStockRelease(ctx context.Context, tx *sql.Tx, orderID, reference string, quantities map[string]int32) error {
+	for productID, quantity := range quantities {
+		qctx, end := tracing.Query(ctx, "INSERT", "stock_releases")
+		_, err := tx.ExecContext(qctx, `
+			INSERT INTO stock_releases (reference, product_id, order_id, quantity, created_at)
+			VALUES ($1, $2, $3, $4, $5)
+		`, reference, productID, orderID, quantity, time.Now())
+		end(err)
+		if err != nil {
+			return fmt.Errorf("failed to queue stock release: %w", err)
+		}
+	}
+	return nil
+}
+
+// PendingStockReleases returns up to limit unacknowledged releases, oldest
+// first. With orderID set only that order's releases are returned.
+func (r *Repository) PendingStockReleases(ctx context.Context, orderID string, limit int) ([]*StockRelease, error) {
+	qctx, end := tracing.Query(ctx, "SELECT", "stock_releases")
+	rows, err := r.db.QueryContext(qctx, `
+		SELECT reference, order_id, product_id, quantity
+		FROM stock_releases
+		WHERE released_at IS NULL AND ($1 = '' OR order_id::text = $1)
+		  AND reference IN (
+			SELECT reference FROM stock_releases
+			WHERE released_at IS NULL AND ($1 = '' OR order_id::text = $1)
+			GROUP BY reference
+			ORDER BY min(created_at)
+			LIMIT $2
+		  )
+		ORDER BY created_at, reference, product_id
+	`, orderID, limit)
+	end(err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending stock releases: %w", err)
+	}
+	defer rows.Close()
+
+	var releases []*StockRelease
+	byReference := make(map[string]*StockRelease)
+	for rows.Next() {
+		var reference, releaseOrderID string
+		item := &product.StockQuantity{}
+		if err := rows.Scan(&reference, &releaseOrderID, &item.ProductId, &item.Quantity); err != nil {
+			return nil, fmt.Errorf("failed to scan stock release: %w", err)
+		}
+		release, ok := byReference[reference]
+		if !ok {
+			release = &StockRelease{Reference: reference, OrderID: releaseOrderID}
+			byReference[reference] = release
+			releases = append(releases, release)
+		}
+		release.Items = append(release.Items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating stock releases: %w", err)
+	}
+	return releases, nil
+}
+
+// MarkStockReleased records that the product service applied reference.
+func (r *Repository) MarkStockReleased(ctx context.Context, reference string) error {
+	qctx, end := tracing.Query(ctx, "UPDATE", "stock_releases")
+	_, err := r.db.ExecContext(qctx, `
+		UPDATE stock_releases SET released_at = $2
+		WHERE reference = $1 AND released_at IS NULL
+	`, reference, time.Now())
+	end(err)
+	if err != nil {
+		return fmt.Errorf("failed to mark stock released: %w", err)
+	}
+	return nil
+}
+
+// orderColumns are the orders columns read by scanOrder, in order.
+const orderColumns = "id, user_id, total_amount, currency, status, created_at, updated_at, " +
+	"subtotal, tax_total, shipping_region, tax_inclusive, discount_total, refunded_total, cancellation_reason, cancelled_at, " +
+	"shipping_address, shipping_address_id"
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanOrder(row rowScanner) (*order.Order, error) {
+	var o order.Order
+	var total, currency, subtotal, taxTotal, discountTotal, refundedTotal string
+	var address []byte
+	var addressID sql.NullString
+	if err := row.Scan(&o.Id, &o.UserId, &total, &currency, &o.Status,
+		database.Timestamp(&o.CreatedAt), database.Timestamp(&o.UpdatedAt),
+		&subtotal, &taxTotal, &o.ShippingRegion, &o.TaxInclusive, &discountTotal,
+		&refundedTotal, &o.CancellationReason, database.Timestamp(&o.CancelledAt),
+		&address, &addressID); err != nil {
+		return nil, err
+	}
+	o.ShippingAddressId = addressID.String
+	amount, err := money.Parse(currency, total)
+	if err != nil {
+		return nil, fmt.Errorf("invalid total in order %s: %w", o.Id, err)
+	}
+	setTotal(&o, amount)
+	if o.Subtotal, err = parseProto(currency, subtotal); err != nil {
+		return nil, fmt.Errorf("invalid subtotal in order %s: %w", o.Id, err)
+	}
+	if o.TaxTotal, err = parseProto(currency, taxTotal); err != nil {
+		return nil, fmt.Errorf("invalid tax total in order %s: %w", o.Id, err)
+	}
+	if o.DiscountTotal, err = parseProto(currency, discountTotal); err != nil {
+		return nil, fmt.Errorf("invalid discount total in order %s: %w", o.Id, err)
+	}
+	if o.RefundedTotal, err = parseProto(currency, refundedTotal); err != nil {
+		return nil, fmt.Errorf("invalid refunded total in order %s: %w", o.Id, err)
+	}
+	if o.ShippingAddress, err = decodeAddress(address); err != nil {
+		return nil, fmt.Errorf("invalid shipping address in order %s: %w", o.Id, err)
+	}
 	return &o, nil
 }
+
+func parseProto(currency, s string) (*moneypb.Money, error) {
+	a, err := money.Parse(currency, s)
+	if err != nil {
+		return nil, err
+	}
+	return a.Proto(), nil
+}
+
+// storedTaxLine is the JSON form of an order.TaxLine in order_items.tax_lines.
+type storedTaxLine struct {
+	Name   string `json:"name"`
+	Rate   string `json:"rate"`
+	Amount string `json:"amount"`
+}
+
+func encodeTaxLines(lines []*order.TaxLine) ([]byte, error) {
+	stored := make([]storedTaxLine, len(lines))
+	for i, l := range lines {
+		amount, err := money.FromProto(l.Amount)
+		if err != nil {
+			return nil, err
+		}
+		stored[i] = storedTaxLine{Name: l.Name, Rate: l.Rate, Amount: amount.Decimal()}
+	}
+	return json.Marshal(stored)
+}
+
+func decodeTaxLines(currency string, data []byte) ([]*order.TaxLine, error) {
+	var stored []storedTaxLine
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return nil, err
+	}
+	lines := make([]*order.TaxLine, len(stored))
+	for i, l := range stored {
+		amount, err := parseProto(currency, l.Amount)
+		if err != nil {
+			return nil, err
+		}
+		lines[i] = &order.TaxLine{Name: l.Name, Rate: l.Rate, Amount: amount}
+	}
+	return lines, nil
+}
+
+// storedAddress is the JSON form of a user.PostalAddress in
+// orders.shipping_address.
+type storedAddress struct {
+	RecipientName string `json:"recipient_name"`
+	Line1         string `json:"line1"`
+	Line2         string `json:"line2,omitempty"`
+	City          string `json:"city"`
+	Region        string `json:"region,omitempty"`
+	PostalCode    string `json:"postal_code,omitempty"`
+	CountryCode   string `json:"country_code"`
+	Phone         string `json:"phone,omitempty"`
+}
+
+// encodeAddress returns a nil value, stored as NULL, for a nil address.
+func encodeAddress(a *user.PostalAddress) (interface{}, error) {
+	if a == nil {
+		return nil, nil
+	}
+	return json.Marshal(storedAddress{
+		RecipientName: a.RecipientName,
+		Line1:         a.Line1,
+		Line2:         a.Line2,
+		City:          a.City,
+		Region:        a.Region,
+		PostalCode:    a.PostalCode,
+		CountryCode:   a.CountryCode,
+		Phone:         a.Phone,
+	})
+}
+
+func decodeAddress(data []byte) (*user.PostalAddress, error) {
+	if data == nil {
+		return nil, nil
+	}
+	var a storedAddress
+	if err := json.Unmarshal(data, &a); err != nil {
+		return nil, err
+	}
+	return &user.PostalAddress{
+		RecipientName: a.RecipientName,
+		Line1:         a.Line1,
+		Line2:         a.Line2,
+		City:          a.City,
+		Region:        a.Region,
+		PostalCode:    a.PostalCode,
+		CountryCode:   a.CountryCode,
+		Phone:         a.Phone,
+	}, nil
+}
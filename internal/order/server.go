@@ -4,8 +4,6 @@ import (
 	"context"
 
 	"github.com/dipendra-mule/microservice-with-grpc/proto/order"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 )
 
 type Server struct {
@@ -19,31 +17,52 @@ func NewServer(s *Service) *Server {
 
 func (s *Server) CreateOrder(ctx context.Context, r *order.CreateOrderRequest) (*order.OrderResponse, error) {
 	createdOrder, err := s.service.CreateOrder(ctx, r)
-
 	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, err
 	}
 	return &order.OrderResponse{Order: createdOrder}, nil
 }
 
 func (s *Server) GetOrder(ctx context.Context, r *order.GetOrderRequest) (*order.OrderResponse, error) {
-	fetchedOrder, err := s.service.GetOrder(ctx, r)
+	fetchedOrder, warnings, err := s.service.GetOrder(ctx, r)
 	if err != nil {
-		if err == ErrOrderNotFound {
-			return nil, status.Error(codes.NotFound, "order not found")
-		}
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, err
 	}
-	return &order.OrderResponse{Order: fetchedOrder}, nil
+	return &order.OrderResponse{Order: fetchedOrder, Warnings: warnings}, nil
+}
+
+func (s *Server) ListOrders(ctx context.Context, r *order.ListOrdersRequest) (*order.ListOrdersResponse, error) {
+	return s.service.ListOrders(ctx, r)
 }
 
 func (s *Server) UpdateOrderStatus(ctx context.Context, r *order.UpdateOrderStatusRequest) (*order.OrderResponse, error) {
 	updatedOrderStatus, err := s.service.UpdateOrderStatus(ctx, r)
 	if err != nil {
-		if err == ErrOrderNotFound {
-			return nil, status.Error(codes.NotFound, "order not found")
-		}
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, err
 	}
 	return &order.OrderResponse{Order: updatedOrderStatus}, nil
 }
+
+func (s *Server) CancelOrder(ctx context.Context, r *order.CancelOrderRequest) (*order.OrderResponse, error) {
+	cancelledOrder, err := s.service.CancelOrder(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	return &order.OrderResponse{Order: cancelledOrder}, nil
+}
+
+func (s *Server) RefundOrder(ctx context.Context, r *order.RefundOrderRequest) (*order.RefundOrderResponse, error) {
+	refundedOrder, refund, err := s.service.RefundOrder(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	return &order.RefundOrderResponse{Order: refundedOrder, Refund: refund}, nil
+}
+
+func (s *Server) GetOrderHistory(ctx context.Context, r *order.GetOrderHistoryRequest) (*order.GetOrderHistoryResponse, error) {
+	events, err := s.service.GetOrderHistory(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	return &order.GetOrderHistoryResponse{Events: events}, nil
+}
@@ -2,28 +2,87 @@ package order
 
 import (
 	"context"
+	"errors"
 	"fmt"
-
+	"time"
+
+	"github.com/dipendra-mule/microservice-with-grpc/internal/promotion"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/auth"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/errs"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/exchange"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/pagination"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/tax"
 	"github.com/dipendra-mule/microservice-with-grpc/proto/order"
 	"github.com/dipendra-mule/microservice-with-grpc/proto/product"
 	"github.com/dipendra-mule/microservice-with-grpc/proto/user"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	ErrInvalidProducts = errs.New(errs.FailedPrecondition, "INVALID_PRODUCTS", "invalid products in order")
+	ErrProductNotFound = errs.New(errs.NotFound, "PRODUCT_NOT_FOUND", "product not found")
+	ErrDuplicateItem   = errs.New(errs.InvalidArgument, "DUPLICATE_ORDER_ITEM", "product listed more than once; combine the quantities")
 )
 
 type Service struct {
 	repo          *Repository
 	productClient product.ProductServiceClient
 	userClient    user.UserServiceClient
+	rates         exchange.Provider
+	taxes         tax.Calculator
+	promotions    *promotion.Service
+	keyTTL        time.Duration
 }
 
-func NewService(r *Repository, pc product.ProductServiceClient, uc user.UserServiceClient) *Service {
+// NewService returns an order service. Idempotency keys are remembered for
+// keyTTL.
+func NewService(r *Repository, pc product.ProductServiceClient, uc user.UserServiceClient, rates exchange.Provider, taxes tax.Calculator, promotions *promotion.Service, keyTTL time.Duration) *Service {
 	return &Service{
 		repo:          r,
 		productClient: pc,
 		userClient:    uc,
+		rates:         rates,
+		taxes:         taxes,
+		promotions:    promotions,
+		keyTTL:        keyTTL,
 	}
 }
 
+// CreateOrder places an order for r.UserId, who must be the caller unless
+// an admin places it. The user's address book is read on their behalf.
 func (s *Service) CreateOrder(ctx context.Context, r *order.CreateOrderRequest) (*order.Order, error) {
+	if _, err := auth.RequireUser(ctx, r.UserId); err != nil {
+		return nil, err
+	}
+	if err := checkDuplicateItems(r.Items); err != nil {
+		return nil, err
+	}
+
+	// A retry of a request that already succeeded gets the original order.
+	var key *IdempotencyKey
+	if k, err := idempotencyKey(ctx, r); err != nil {
+		return nil, err
+	} else if k != "" {
+		hash, err := requestHash(r)
+		if err != nil {
+			return nil, err
+		}
+		if o, err := s.replay(ctx, r.UserId, k, hash); o != nil || err != nil {
+			return o, err
+		}
+		key = &IdempotencyKey{Key: k, RequestHash: hash, ExpiresAt: time.Now().Add(s.keyTTL)}
+	}
+
+	address, addressID, err := s.shippingAddress(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	if address != nil && r.ShippingRegion == "" {
+		// Tax by where the order ships.
+		r = proto.Clone(r).(*order.CreateOrderRequest)
+		r.ShippingRegion = taxRegion(address)
+	}
+
 	// Validate products and get product details
 	productReqs := make([]*product.ProductValidation, len(r.Items))
 	for i, item := range r.Items {
@@ -37,85 +96,159 @@ func (s *Service) CreateOrder(ctx context.Context, r *order.CreateOrderRequest)
 		Items: productReqs,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to validate products: %w", err)
+		return nil, errs.FromRemote(fmt.Errorf("failed to validate products: %w", err), "product-service")
 	}
 
 	if !validationReq.Valid {
-		return nil, fmt.Errorf("invalid products in order")
+		invalid := ErrInvalidProducts
+		for _, ve := range validationReq.Errors {
+			invalid = invalid.WithField(itemField(r.Items, ve.ProductId), ve.Message)
+		}
+		return nil, invalid
 	}
 
 	// Calculate total and prepare order items
-	var total float32
-	orderItems := make([]*order.OrderItem, len(r.Items))
 	productMap := make(map[string]*product.Product)
 	for _, p := range validationReq.Products {
 		productMap[p.Id] = p
 	}
-
-	for i, item := range r.Items {
-		p := productMap[item.ProductId]
-		orderItems[i] = &order.OrderItem{
-			ProductId:   item.ProductId,
-			Quantity:    item.Quantity,
-			Price:       p.Price,
-			ProductName: p.Name,
-		}
-		total += p.Price * float32(item.Quantity)
+	priced, err := s.price(ctx, r, productMap)
+	if err != nil {
+		return nil, err
 	}
 
 	// create order
 	o := &order.Order{
-		UserId:      r.UserId,
-		Items:       orderItems,
-		TotalAmount: total,
-		Status:      "pending",
+		UserId:            r.UserId,
+		Items:             priced.items,
+		Status:            StatusPending,
+		ExchangeRates:     priced.rates,
+		Subtotal:          priced.subtotal.Proto(),
+		TaxTotal:          priced.tax.Proto(),
+		ShippingRegion:    r.ShippingRegion,
+		TaxInclusive:      priced.taxInclusive,
+		Adjustments:       priced.adjustments,
+		DiscountTotal:     priced.discount.Proto(),
+		ShippingAddress:   address,
+		ShippingAddressId: addressID,
+	}
+	setTotal(o, priced.total)
+	createdOrder, err := s.repo.CreateOrder(ctx, o, key, ActorFromContext(ctx, ActorCustomer))
+	if errors.Is(err, errIdempotencyKeyTaken) {
+		// A concurrent request with the same key won.
+		if o, err := s.replay(ctx, r.UserId, key.Key, key.RequestHash); o != nil || err != nil {
+			return o, err
+		}
+	}
+	if err != nil {
+		return nil, err
 	}
-	return s.repo.CreateOrder(ctx, o)
-}
 
-// GetOrder returns an order by ID
-// and also returns the user associated with the order
+	ordersCreated.WithLabelValues(createdOrder.Status).Inc()
+	orderValue.WithLabelValues(priced.total.Currency).Observe(float64(priced.total.Float()))
+	return createdOrder, nil
+}
 
-func (s *Service) GetOrder(ctx context.Context, r *order.GetOrderRequest) (*order.Order, error) {
+// GetOrder returns an order by ID and, if requested, the user who placed
+// it. Warnings list the data that could not be included.
+func (s *Service) GetOrder(ctx context.Context, r *order.GetOrderRequest) (*order.Order, []*order.Warning, error) {
 	o, err := s.repo.GetOrderByID(ctx, r.Id)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	// Get user information
-	userResp, err := s.userClient.GetUser(ctx, &user.GetUserRequest{Id: o.UserId})
-	if err != nil && userResp != nil {
-		o.User = userResp.User
+	var warnings []*order.Warning
+	if r.IncludeUser {
+		warnings = s.enrichUsers(ctx, []*order.Order{o})
 	}
-
-	return o, nil
+	return o, warnings, nil
 }
 
+// ListOrders returns one page of a user's orders, newest first. Pages are
+// addressed by page_token; the legacy page number still works and also
+// returns the total.
 func (s *Service) ListOrders(ctx context.Context, r *order.ListOrdersRequest) (*order.ListOrdersResponse, error) {
-	orders, total, err := s.repo.ListOrders(ctx, r.UserId, r.Page, r.Limit)
+	page, err := pagination.NewRequest(r.PageToken, r.Page, r.Limit, r.UserId)
+	if err != nil {
+		return nil, err
+	}
+	orders, err := s.repo.ListOrders(ctx, r.UserId, page)
 	if err != nil {
 		return nil, err
 	}
+	orders, next := pagination.Trim(orders, page, r.UserId, orderCursor)
 
+	var total int32
+	if r.IncludeTotal || r.Page > 0 {
+		if total, err = s.repo.CountOrders(ctx, r.UserId); err != nil {
+			return nil, err
+		}
+	}
+
+	var warnings []*order.Warning
+	if r.IncludeUser {
+		warnings = s.enrichUsers(ctx, orders)
+	}
 	return &order.ListOrdersResponse{
-		Orders: orders,
-		Total:  total,
-		Page:   r.Page,
-		Limit:  r.Limit,
+		Orders:        orders,
+		Total:         total,
+		Page:          r.Page,
+		Limit:         r.Limit,
+		Warnings:      warnings,
+		NextPageToken: next,
 	}, nil
 }
 
+func orderCursor(o *order.Order) pagination.Cursor {
+	return pagination.Cursor{CreatedAt: o.CreatedAt.AsTime(), ID: o.Id}
+}
+
 func (s *Service) UpdateOrderStatus(ctx context.Context, r *order.UpdateOrderStatusRequest) (*order.Order, error) {
-	o, err := s.repo.GetOrderByID(ctx, r.OrderId)
+	// Cancelling has side effects; keep the old way of doing it working.
+	if r.Status == StatusCancelled {
+		reason := r.Reason
+		if reason == "" {
+			reason = "cancelled via UpdateOrderStatus"
+		}
+		return s.CancelOrder(ctx, &order.CancelOrderRequest{
+			OrderId: r.OrderId,
+			Reason:  reason,
+		})
+	}
+
+	actor, err := callerActor(ctx)
 	if err != nil {
 		return nil, err
 	}
-
-	// Update order status
-	o.Status = r.Status
-	updatedOrder, err := s.repo.UpdateOrderStatus(ctx, o.Id, o.Status)
+	updatedOrder, err := s.repo.UpdateOrderStatus(ctx, r.OrderId, r.Status, r.Reason, actor)
 	if err != nil {
 		return nil, err
 	}
+	orderStatusUpdates.WithLabelValues(updatedOrder.Status).Inc()
 	return updatedOrder, nil
 }
+
+// checkDuplicateItems rejects items listing a product more than once. An
+// order has a single line per product, which refunds and shipments rely
+// on.
+func checkDuplicateItems(items []*order.OrderItemRequest) error {
+	seen := make(map[string]bool, len(items))
+	for i, item := range items {
+		if seen[item.ProductId] {
+			return ErrDuplicateItem.WithField(fmt.Sprintf("items[%d].product_id", i), "product "+item.ProductId+" is already listed")
+		}
+		seen[item.ProductId] = true
+	}
+	return nil
+}
+
+// itemField returns the request field path of the item for productID, for
+// use in field violations.
+func itemField(items []*order.OrderItemRequest, productID string) string {
+	for i, item := range items {
+		if item.ProductId == productID {
+			return fmt.Sprintf("items[%d].product_id", i)
+		}
+	}
+	return "items"
+}
@@ -0,0 +1,42 @@
+package order
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/errs"
+	"github.com/dipendra-mule/microservice-with-grpc/proto/order"
+)
+
+func TestCheckDuplicateItems(t *testing.T) {
+	tests := []struct {
+		name      string
+		items     []*order.OrderItemRequest
+		wantField string // empty if the items are valid
+	}{
+		{"distinct", []*order.OrderItemRequest{{ProductId: "p1", Quantity: 1}, {ProductId: "p2", Quantity: 2}}, ""},
+		{
+			"product on two lines",
+			[]*order.OrderItemRequest{{ProductId: "p1", Quantity: 1}, {ProductId: "p2", Quantity: 1}, {ProductId: "p1", Quantity: 2}},
+			"items[2].product_id",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkDuplicateItems(tt.items)
+			if tt.wantField == "" {
+				if err != nil {
+					t.Fatalf("checkDuplicateItems() error = %v", err)
+				}
+				return
+			}
+			var e *errs.Error
+			if !errors.Is(err, ErrDuplicateItem) || !errors.As(err, &e) {
+				t.Fatalf("checkDuplicateItems() error = %v, want ErrDuplicateItem", err)
+			}
+			if len(e.Fields) != 1 || e.Fields[0].Field != tt.wantField {
+				t.Errorf("fields = %v, want %s", e.Fields, tt.wantField)
+			}
+		})
+	}
+}
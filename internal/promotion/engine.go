@@ -0,0 +1,150 @@
+package promotion
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/errs"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/money"
+	"github.com/dipendra-mule/microservice-with-grpc/proto/promotion"
+)
+
+// Reasons a promotion does not apply, reported in the "reason" metadata of
+// ErrNotApplicable.
+const (
+	ReasonInactive      = "inactive"
+	ReasonNotStarted    = "not_started"
+	ReasonExpired       = "expired"
+	ReasonCurrency      = "currency"
+	ReasonMinOrderValue = "min_order_value"
+	ReasonNoEligible    = "no_eligible_items"
+	ReasonUsageLimit    = "usage_limit"
+	ReasonUserLimit     = "user_limit"
+)
+
+var ErrNotApplicable = errs.New(errs.FailedPrecondition, "PROMOTION_NOT_APPLICABLE", "promotion does not apply to this order")
+
+func notApplicable(reason string) *errs.Error {
+	return ErrNotApplicable.WithMetadata("reason", reason)
+}
+
+// Line is an order line a promotion may discount, priced in the order's
+// currency.
+type Line struct {
+	ProductID string
+	Category  string
+	Quantity  int64
+	UnitPrice money.Amount
+	Amount    money.Amount // Line amount left after earlier promotions
+}
+
+// Discount is the part of a promotion taken off one line.
+type Discount struct {
+	Promotion *promotion.Promotion
+	Line      int          // Index into the lines passed to Apply
+	Amount    money.Amount // Positive
+}
+
+// Apply computes the discounts p gives on lines. orderValue is the order
+// amount before any discounts, which min_order_value is compared against.
+// Apply checks the validity window but not usage limits, which need the
+// redemption history.
+func Apply(p *promotion.Promotion, lines []Line, orderValue money.Amount, now time.Time) ([]Discount, error) {
+	switch {
+	case !p.Active:
+		return nil, notApplicable(ReasonInactive)
+	case p.StartsAt != nil && now.Before(p.StartsAt.AsTime()):
+		return nil, notApplicable(ReasonNotStarted)
+	case p.EndsAt != nil && !now.Before(p.EndsAt.AsTime()):
+		return nil, notApplicable(ReasonExpired)
+	}
+
+	if p.MinOrderValue != nil {
+		if p.MinOrderValue.CurrencyCode != orderValue.Currency {
+			return nil, notApplicable(ReasonCurrency)
+		}
+		if orderValue.Minor < p.MinOrderValue.MinorUnits {
+			return nil, notApplicable(ReasonMinOrderValue)
+		}
+	}
+
+	var eligible []int
+	for i, l := range lines {
+		if (p.Category == "" || l.Category == p.Category) && l.Amount.Minor > 0 {
+			eligible = append(eligible, i)
+		}
+	}
+
+	var discounts []Discount
+	add := func(i int, d money.Amount) {
+		if d.Minor > lines[i].Amount.Minor {
+			d = lines[i].Amount
+		}
+		if d.Minor > 0 {
+			discounts = append(discounts, Discount{Promotion: p, Line: i, Amount: d})
+		}
+	}
+
+	switch d := p.Discount.(type) {
+	case *promotion.Promotion_PercentOff:
+		rate, ok := new(big.Rat).SetString(d.PercentOff)
+		if !ok {
+			return nil, fmt.Errorf("invalid percent_off %q on promotion %s", d.PercentOff, p.Code)
+		}
+		rate.Quo(rate, big.NewRat(100, 1))
+		for _, i := range eligible {
+			off, err := lines[i].Amount.MulRat(rate)
+			if err != nil {
+				return nil, err
+			}
+			add(i, off)
+		}
+
+	case *promotion.Promotion_AmountOff:
+		if d.AmountOff.CurrencyCode != orderValue.Currency {
+			return nil, notApplicable(ReasonCurrency)
+		}
+		var base int64
+		for _, i := range eligible {
+			base += lines[i].Amount.Minor
+		}
+		off := d.AmountOff.MinorUnits
+		if off > base {
+			off = base
+		}
+		// Split the amount across the eligible lines in proportion to their
+		// amounts so that tax is computed on what each line really costs.
+		// The last line takes the rounding remainder.
+		remaining := off
+		for n, i := range eligible {
+			share := money.New(orderValue.Currency, remaining)
+			if n < len(eligible)-1 {
+				var err error
+				share, err = money.New(orderValue.Currency, off).MulRat(big.NewRat(lines[i].Amount.Minor, base))
+				if err != nil {
+					return nil, err
+				}
+			}
+			add(i, share)
+			remaining -= share.Minor
+		}
+
+	case *promotion.Promotion_BuyXGetY:
+		// Free units are counted per product, not across lines.
+		set := int64(d.BuyXGetY.BuyQuantity + d.BuyXGetY.GetQuantity)
+		for _, i := range eligible {
+			free := lines[i].Quantity / set * int64(d.BuyXGetY.GetQuantity)
+			off, err := lines[i].UnitPrice.Mul(free)
+			if err != nil {
+				return nil, err
+			}
+			add(i, off)
+		}
+	}
+
+	if len(discounts) == 0 {
+		return nil, notApplicable(ReasonNoEligible)
+	}
+	return discounts, nil
+}
@@ -0,0 +1,174 @@
+package promotion
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/errs"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/money"
+	moneypb "github.com/dipendra-mule/microservice-with-grpc/proto/money"
+	"github.com/dipendra-mule/microservice-with-grpc/proto/promotion"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var now = time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func line(category string, quantity, unit int64) Line {
+	return Line{
+		ProductID: category,
+		Category:  category,
+		Quantity:  quantity,
+		UnitPrice: money.New("USD", unit),
+		Amount:    money.New("USD", quantity*unit),
+	}
+}
+
+func percentOff(pct string) *promotion.Promotion {
+	return &promotion.Promotion{Code: "PCT", Active: true, Discount: &promotion.Promotion_PercentOff{PercentOff: pct}}
+}
+
+func amountOff(minor int64) *promotion.Promotion {
+	return &promotion.Promotion{Code: "AMT", Active: true, Discount: &promotion.Promotion_AmountOff{
+		AmountOff: &moneypb.Money{CurrencyCode: "USD", MinorUnits: minor},
+	}}
+}
+
+func buyXGetY(buy, get int32) *promotion.Promotion {
+	return &promotion.Promotion{Code: "BXGY", Active: true, Discount: &promotion.Promotion_BuyXGetY{
+		BuyXGetY: &promotion.BuyXGetY{BuyQuantity: buy, GetQuantity: get},
+	}}
+}
+
+func orderValue(lines []Line) money.Amount {
+	total := money.Zero("USD")
+	for _, l := range lines {
+		total, _ = total.Add(l.Amount)
+	}
+	return total
+}
+
+func TestApply(t *testing.T) {
+	inCategory := percentOff("50")
+	inCategory.Category = "books"
+
+	spent := line("toys", 3, 300)
+	spent.Amount = money.New("USD", 100)
+
+	tests := []struct {
+		name  string
+		p     *promotion.Promotion
+		lines []Line
+		want  map[int]int64 // line index: discount
+	}{
+		{
+			name:  "percent off rounds each line",
+			p:     percentOff("10"),
+			lines: []Line{line("books", 1, 1999), line("toys", 2, 250)},
+			want:  map[int]int64{0: 200, 1: 50},
+		},
+		{
+			name:  "percent off in category",
+			p:     inCategory,
+			lines: []Line{line("books", 1, 1000), line("toys", 1, 1000)},
+			want:  map[int]int64{0: 500},
+		},
+		{
+			name:  "amount off split by line amount",
+			p:     amountOff(1000),
+			lines: []Line{line("a", 1, 3000), line("b", 1, 1000)},
+			want:  map[int]int64{0: 750, 1: 250},
+		},
+		{
+			name:  "amount off remainder goes to the last line",
+			p:     amountOff(1000),
+			lines: []Line{line("a", 1, 1000), line("b", 1, 1000), line("c", 1, 1000)},
+			want:  map[int]int64{0: 333, 1: 333, 2: 334},
+		},
+		{
+			name:  "amount off capped at the order",
+			p:     amountOff(5000),
+			lines: []Line{line("a", 1, 1000), line("b", 1, 500)},
+			want:  map[int]int64{0: 1000, 1: 500},
+		},
+		{
+			name:  "buy two get one",
+			p:     buyXGetY(2, 1),
+			lines: []Line{line("a", 7, 300), line("b", 2, 500)},
+			want:  map[int]int64{0: 600},
+		},
+		{
+			name:  "discount capped at what earlier promotions left",
+			p:     buyXGetY(2, 1),
+			lines: []Line{spent},
+			want:  map[int]int64{0: 100},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			discounts, err := Apply(tt.p, tt.lines, orderValue(tt.lines), now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := make(map[int]int64)
+			for _, d := range discounts {
+				if d.Promotion != tt.p || d.Amount.Currency != "USD" {
+					t.Errorf("discount %+v does not belong to the promotion", d)
+				}
+				got[d.Line] += d.Amount.Minor
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("discounts = %v, want %v", got, tt.want)
+			}
+			for i, want := range tt.want {
+				if got[i] != want {
+					t.Errorf("discounts = %v, want %v", got, tt.want)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestApplyNotApplicable(t *testing.T) {
+	lines := []Line{line("books", 2, 1000)}
+
+	inactive := percentOff("10")
+	inactive.Active = false
+	notStarted := percentOff("10")
+	notStarted.StartsAt = timestamppb.New(now.Add(time.Hour))
+	expired := percentOff("10")
+	expired.EndsAt = timestamppb.New(now)
+	minOrder := percentOff("10")
+	minOrder.MinOrderValue = &moneypb.Money{CurrencyCode: "USD", MinorUnits: 2001}
+	otherCurrency := amountOff(100)
+	otherCurrency.GetAmountOff().CurrencyCode = "EUR"
+	otherCategory := percentOff("10")
+	otherCategory.Category = "toys"
+
+	tests := []struct {
+		name   string
+		p      *promotion.Promotion
+		reason string
+	}{
+		{"inactive", inactive, ReasonInactive},
+		{"not started", notStarted, ReasonNotStarted},
+		{"expired", expired, ReasonExpired},
+		{"min order value", minOrder, ReasonMinOrderValue},
+		{"currency", otherCurrency, ReasonCurrency},
+		{"no eligible items", otherCategory, ReasonNoEligible},
+		{"nothing free yet", buyXGetY(2, 1), ReasonNoEligible},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Apply(tt.p, lines, orderValue(lines), now)
+			var e *errs.Error
+			if !errors.Is(err, ErrNotApplicable) || !errors.As(err, &e) {
+				t.Fatalf("Apply() error = %v, want ErrNotApplicable", err)
+			}
+			if e.Metadata["reason"] != tt.reason {
+				t.Errorf("reason = %q, want %q", e.Metadata["reason"], tt.reason)
+			}
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package promotion
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	promotionsCreated = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "promotions_created_total",
+		Help: "Total number of promotions created.",
+	})
+
+	promotionRejections = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "promotion_rejections_total",
+		Help: "Total number of coupon codes rejected at checkout, by reason.",
+	}, []string{"reason"})
+)
@@ -0,0 +1,263 @@
+package promotion
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/database"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/errs"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/money"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/tracing"
+	"github.com/dipendra-mule/microservice-with-grpc/proto/promotion"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+var (
+	ErrPromotionNotFound = errs.New(errs.NotFound, "PROMOTION_NOT_FOUND", "promotion not found")
+	ErrCodeExists        = errs.New(errs.AlreadyExists, "PROMOTION_CODE_EXISTS", "promotion code already exists")
+)
+
+type Repository struct {
+	db *sql.DB
+}
+
+func NewRepository(db *sql.DB) *Repository {
+	return &Repository{
+		db: db,
+	}
+}
+
+func (r *Repository) CreatePromotion(ctx context.Context, p *promotion.Promotion) (*promotion.Promotion, error) {
+	var percentOff, amountOff, minOrderValue, currency sql.NullString
+	var buyQuantity, getQuantity sql.NullInt32
+	switch d := p.Discount.(type) {
+	case *promotion.Promotion_PercentOff:
+		percentOff = sql.NullString{String: d.PercentOff, Valid: true}
+	case *promotion.Promotion_AmountOff:
+		amount, err := money.FromProto(d.AmountOff)
+		if err != nil {
+			return nil, err
+		}
+		amountOff = sql.NullString{String: amount.Decimal(), Valid: true}
+		currency = sql.NullString{String: amount.Currency, Valid: true}
+	case *promotion.Promotion_BuyXGetY:
+		buyQuantity = sql.NullInt32{Int32: d.BuyXGetY.BuyQuantity, Valid: true}
+		getQuantity = sql.NullInt32{Int32: d.BuyXGetY.GetQuantity, Valid: true}
+	}
+	if p.MinOrderValue != nil {
+		amount, err := money.FromProto(p.MinOrderValue)
+		if err != nil {
+			return nil, err
+		}
+		minOrderValue = sql.NullString{String: amount.Decimal(), Valid: true}
+		currency = sql.NullString{String: amount.Currency, Valid: true}
+	}
+	var endsAt sql.NullTime
+	if p.EndsAt != nil {
+		endsAt = sql.NullTime{Time: p.EndsAt.AsTime(), Valid: true}
+	}
+
+	query := `
+		INSERT INTO promotions (id, code, description, percent_off, amount_off, buy_quantity, get_quantity,
+			currency, category, min_order_value, max_uses, max_uses_per_user, starts_at, ends_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+		RETURNING ` + promotionColumns
+	qctx, end := tracing.Query(ctx, "INSERT", "promotions")
+	created, err := scanPromotion(r.db.QueryRowContext(qctx, query,
+		uuid.New().String(), p.Code, p.Description, percentOff, amountOff, buyQuantity, getQuantity,
+		currency, p.Category, minOrderValue, p.MaxUses, p.MaxUsesPerUser, p.StartsAt.AsTime(), endsAt, time.Now(),
+	))
+	end(err)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return nil, ErrCodeExists.WithResource("promotion", p.Code)
+		}
+		return nil, fmt.Errorf("failed to create promotion: %w", err)
+	}
+	return created, nil
+}
+
+func (r *Repository) GetPromotionByCode(ctx context.Context, code string) (*promotion.Promotion, error) {
+	query := `
+		SELECT ` + promotionColumns + `
+		FROM promotions
+		WHERE code = $1
+	`
+	qctx, end := tracing.Query(ctx, "SELECT", "promotions")
+	p, err := scanPromotion(r.db.QueryRowContext(qctx, query, code))
+	end(err)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrPromotionNotFound.WithResource("promotion", code)
+		}
+		return nil, fmt.Errorf("failed to get promotion: %w", err)
+	}
+	return p, nil
+}
+
+// DeactivatePromotion stops a promotion from applying to new orders.
+func (r *Repository) DeactivatePromotion(ctx context.Context, code string) (*promotion.Promotion, error) {
+	query := `
+		UPDATE promotions
+		SET active = FALSE
+		WHERE code = $1
+		RETURNING ` + promotionColumns
+	qctx, end := tracing.Query(ctx, "UPDATE", "promotions")
+	p, err := scanPromotion(r.db.QueryRowContext(qctx, query, code))
+	end(err)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrPromotionNotFound.WithResource("promotion", code)
+		}
+		return nil, fmt.Errorf("failed to deactivate promotion: %w", err)
+	}
+	return p, nil
+}
+
+// CountUserRedemptions returns how often a user has redeemed a promotion.
+func (r *Repository) CountUserRedemptions(ctx context.Context, promotionID, userID string) (int32, error) {
+	var count int32
+	qctx, end := tracing.Query(ctx, "SELECT COUNT", "promotion_redemptions")
+	err := r.db.QueryRowContext(qctx,
+		"SELECT COUNT(*) FROM promotion_redemptions WHERE promotion_id = $1 AND user_id = $2",
+		promotionID, userID).Scan(&count)
+	end(err)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count promotion redemptions: %w", err)
+	}
+	return count, nil
+}
+
+// Redeem records that an order used a promotion, within the transaction
+// that creates the order. It enforces the usage limits: the promotion row
+// stays locked until tx ends, so concurrent orders redeeming the same
+// promotion are counted one after another.
+func Redeem(ctx context.Context, tx *sql.Tx, promotionID, orderID, userID string) error {
+	var maxPerUser int32
+	qctx, end := tracing.Query(ctx, "UPDATE", "promotions")
+	err := tx.QueryRowContext(qctx, `
+		UPDATE promotions
+		SET times_used = times_used + 1
+		WHERE id = $1 AND active AND (max_uses = 0 OR times_used < max_uses)
+		RETURNING max_uses_per_user
+	`, promotionID).Scan(&maxPerUser)
+	end(err)
+	if err == sql.ErrNoRows {
+		return notApplicable(ReasonUsageLimit).WithResource("promotion", promotionID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to redeem promotion: %w", err)
+	}
+
+	if maxPerUser > 0 {
+		var used int32
+		qctx, end := tracing.Query(ctx, "SELECT COUNT", "promotion_redemptions")
+		err := tx.QueryRowContext(qctx,
+			"SELECT COUNT(*) FROM promotion_redemptions WHERE promotion_id = $1 AND user_id = $2",
+			promotionID, userID).Scan(&used)
+		end(err)
+		if err != nil {
+			return fmt.Errorf("failed to count promotion redemptions: %w", err)
+		}
+		if used >= maxPerUser {
+			return notApplicable(ReasonUserLimit).WithResource("promotion", promotionID)
+		}
+	}
+
+	qctx, end = tracing.Query(ctx, "INSERT", "promotion_redemptions")
+	_, err = tx.ExecContext(qctx, `
+		INSERT INTO promotion_redemptions (promotion_id, order_id, user_id, created_at)
+		VALUES ($1, $2, $3, $4)
+	`, promotionID, orderID, userID, time.Now())
+	end(err)
+	if err != nil {
+		return fmt.Errorf("failed to record promotion redemption: %w", err)
+	}
+	return nil
+}
+
+// Release undoes the redemptions of an order, within the transaction that
+// cancels it, so the codes it used count towards no limits.
+func Release(ctx context.Context, tx *sql.Tx, orderID string) error {
+	qctx, end := tracing.Query(ctx, "DELETE", "promotion_redemptions")
+	rows, err := tx.QueryContext(qctx,
+		"DELETE FROM promotion_redemptions WHERE order_id = $1 RETURNING promotion_id", orderID)
+	end(err)
+	if err != nil {
+		return fmt.Errorf("failed to release promotion redemptions: %w", err)
+	}
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan promotion redemption: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to release promotion redemptions: %w", err)
+	}
+
+	for _, id := range ids {
+		qctx, end := tracing.Query(ctx, "UPDATE", "promotions")
+		_, err := tx.ExecContext(qctx,
+			"UPDATE promotions SET times_used = GREATEST(times_used - 1, 0) WHERE id = $1", id)
+		end(err)
+		if err != nil {
+			return fmt.Errorf("failed to release promotion: %w", err)
+		}
+	}
+	return nil
+}
+
+// promotionColumns are the promotions columns read by scanPromotion, in order.
+const promotionColumns = "id, code, description, percent_off, amount_off, buy_quantity, get_quantity, currency, " +
+	"category, min_order_value, max_uses, max_uses_per_user, times_used, starts_at, ends_at, active, created_at"
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanPromotion(row rowScanner) (*promotion.Promotion, error) {
+	var p promotion.Promotion
+	var percentOff, amountOff, minOrderValue, currency sql.NullString
+	var buyQuantity, getQuantity sql.NullInt32
+	if err := row.Scan(&p.Id, &p.Code, &p.Description, &percentOff, &amountOff, &buyQuantity, &getQuantity, &currency,
+		&p.Category, &minOrderValue, &p.MaxUses, &p.MaxUsesPerUser, &p.TimesUsed,
+		database.Timestamp(&p.StartsAt), database.Timestamp(&p.EndsAt), &p.Active, database.Timestamp(&p.CreatedAt)); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case percentOff.Valid:
+		// NUMERIC keeps trailing zeros, e.g. "15.0000".
+		pct, _ := new(big.Rat).SetString(percentOff.String)
+		p.Discount = &promotion.Promotion_PercentOff{PercentOff: strings.TrimRight(strings.TrimRight(pct.FloatString(4), "0"), ".")}
+	case amountOff.Valid:
+		amount, err := money.Parse(currency.String, amountOff.String)
+		if err != nil {
+			return nil, fmt.Errorf("invalid amount_off on promotion %s: %w", p.Code, err)
+		}
+		p.Discount = &promotion.Promotion_AmountOff{AmountOff: amount.Proto()}
+	case buyQuantity.Valid:
+		p.Discount = &promotion.Promotion_BuyXGetY{BuyXGetY: &promotion.BuyXGetY{
+			BuyQuantity: buyQuantity.Int32,
+			GetQuantity: getQuantity.Int32,
+		}}
+	}
+	if minOrderValue.Valid {
+		amount, err := money.Parse(currency.String, minOrderValue.String)
+		if err != nil {
+			return nil, fmt.Errorf("invalid min_order_value on promotion %s: %w", p.Code, err)
+		}
+		p.MinOrderValue = amount.Proto()
+	}
+	return &p, nil
+}
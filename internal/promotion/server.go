@@ -0,0 +1,40 @@
+package promotion
+
+import (
+	"context"
+
+	"github.com/dipendra-mule/microservice-with-grpc/proto/promotion"
+)
+
+type Server struct {
+	promotion.UnimplementedPromotionServiceServer
+	service *Service
+}
+
+func NewServer(s *Service) *Server {
+	return &Server{service: s}
+}
+
+func (s *Server) CreatePromotion(ctx context.Context, r *promotion.CreatePromotionRequest) (*promotion.PromotionResponse, error) {
+	p, err := s.service.CreatePromotion(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	return &promotion.PromotionResponse{Promotion: p}, nil
+}
+
+func (s *Server) GetPromotion(ctx context.Context, r *promotion.GetPromotionRequest) (*promotion.PromotionResponse, error) {
+	p, err := s.service.GetPromotion(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	return &promotion.PromotionResponse{Promotion: p}, nil
+}
+
+func (s *Server) DeactivatePromotion(ctx context.Context, r *promotion.DeactivatePromotionRequest) (*promotion.PromotionResponse, error) {
+	p, err := s.service.DeactivatePromotion(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	return &promotion.PromotionResponse{Promotion: p}, nil
+}
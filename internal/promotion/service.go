@@ -0,0 +1,175 @@
+package promotion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/errs"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/money"
+	"github.com/dipendra-mule/microservice-with-grpc/proto/promotion"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var (
+	ErrInvalidPromotion = errs.New(errs.InvalidArgument, "INVALID_PROMOTION", "invalid promotion")
+	ErrDuplicateCode    = errs.New(errs.InvalidArgument, "DUPLICATE_COUPON_CODE", "coupon code given more than once")
+)
+
+type Service struct {
+	repo *Repository
+}
+
+func NewService(repo *Repository) *Service {
+	return &Service{
+		repo: repo,
+	}
+}
+
+func (s *Service) CreatePromotion(ctx context.Context, req *promotion.CreatePromotionRequest) (*promotion.Promotion, error) {
+	p := &promotion.Promotion{
+		Code:           normalizeCode(req.Code),
+		Description:    req.Description,
+		Category:       req.Category,
+		MinOrderValue:  req.MinOrderValue,
+		MaxUses:        req.MaxUses,
+		MaxUsesPerUser: req.MaxUsesPerUser,
+		StartsAt:       req.StartsAt,
+		EndsAt:         req.EndsAt,
+		Active:         true,
+	}
+	if p.StartsAt == nil {
+		p.StartsAt = timestamppb.Now()
+	}
+
+	invalid := ErrInvalidPromotion
+	valid := true
+	switch d := req.Discount.(type) {
+	case *promotion.CreatePromotionRequest_PercentOff:
+		pct, _ := new(big.Rat).SetString(d.PercentOff)
+		if pct == nil || pct.Sign() <= 0 || pct.Cmp(big.NewRat(100, 1)) > 0 {
+			invalid, valid = invalid.WithField("percent_off", "must be above 0 and at most 100"), false
+		}
+		p.Discount = &promotion.Promotion_PercentOff{PercentOff: d.PercentOff}
+	case *promotion.CreatePromotionRequest_AmountOff:
+		if amount, err := money.FromProto(d.AmountOff); err != nil || amount.Minor <= 0 {
+			invalid, valid = invalid.WithField("amount_off", "must be a positive amount"), false
+		}
+		p.Discount = &promotion.Promotion_AmountOff{AmountOff: d.AmountOff}
+	case *promotion.CreatePromotionRequest_BuyXGetY:
+		p.Discount = &promotion.Promotion_BuyXGetY{BuyXGetY: d.BuyXGetY}
+	}
+	if req.MinOrderValue != nil {
+		if amount, err := money.FromProto(req.MinOrderValue); err != nil || amount.IsNegative() {
+			invalid, valid = invalid.WithField("min_order_value", "must be a valid amount"), false
+		}
+		if off := req.GetAmountOff(); off != nil && off.CurrencyCode != req.MinOrderValue.CurrencyCode {
+			invalid, valid = invalid.WithField("min_order_value", "must be in the currency of amount_off"), false
+		}
+	}
+	if p.EndsAt != nil && !p.EndsAt.AsTime().After(p.StartsAt.AsTime()) {
+		invalid, valid = invalid.WithField("ends_at", "must be after starts_at"), false
+	}
+	if !valid {
+		return nil, invalid
+	}
+
+	created, err := s.repo.CreatePromotion(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+	promotionsCreated.Inc()
+	return created, nil
+}
+
+func (s *Service) GetPromotion(ctx context.Context, req *promotion.GetPromotionRequest) (*promotion.Promotion, error) {
+	return s.repo.GetPromotionByCode(ctx, normalizeCode(req.Code))
+}
+
+func (s *Service) DeactivatePromotion(ctx context.Context, req *promotion.DeactivatePromotionRequest) (*promotion.Promotion, error) {
+	return s.repo.DeactivatePromotion(ctx, normalizeCode(req.Code))
+}
+
+// Evaluate applies the promotions behind codes, in order, to an order a
+// user is placing. Each promotion discounts what earlier ones left of the
+// line amounts. Errors name the offending code as field
+// "coupon_codes[i]". Usage limits checked here are checked again when the
+// order redeems the promotions; see Redeem.
+func (s *Service) Evaluate(ctx context.Context, userID string, codes []string, lines []Line, orderValue money.Amount) ([]Discount, error) {
+	// Codes are case-insensitive, so the request's own uniqueness check
+	// does not catch "save10" next to "SAVE10".
+	seen := make(map[string]bool, len(codes))
+	for i, code := range codes {
+		code = normalizeCode(code)
+		if seen[code] {
+			return nil, ErrDuplicateCode.WithField(fmt.Sprintf("coupon_codes[%d]", i), "same code as an earlier one")
+		}
+		seen[code] = true
+	}
+
+	remaining := make([]Line, len(lines))
+	copy(remaining, lines)
+	now := time.Now()
+
+	var discounts []Discount
+	for i, code := range codes {
+		field := fmt.Sprintf("coupon_codes[%d]", i)
+		p, err := s.repo.GetPromotionByCode(ctx, normalizeCode(code))
+		if err != nil {
+			if errors.Is(err, ErrPromotionNotFound) {
+				return nil, ErrPromotionNotFound.WithField(field, "unknown code")
+			}
+			return nil, err
+		}
+
+		if err := s.checkLimits(ctx, p, userID); err != nil {
+			return nil, rejected(err, field)
+		}
+		applied, err := Apply(p, remaining, orderValue, now)
+		if err != nil {
+			return nil, rejected(err, field)
+		}
+		for _, d := range applied {
+			if remaining[d.Line].Amount, err = remaining[d.Line].Amount.Sub(d.Amount); err != nil {
+				return nil, err
+			}
+		}
+		discounts = append(discounts, applied...)
+	}
+	return discounts, nil
+}
+
+func (s *Service) checkLimits(ctx context.Context, p *promotion.Promotion, userID string) error {
+	if p.MaxUses > 0 && p.TimesUsed >= p.MaxUses {
+		return notApplicable(ReasonUsageLimit)
+	}
+	if p.MaxUsesPerUser > 0 {
+		used, err := s.repo.CountUserRedemptions(ctx, p.Id, userID)
+		if err != nil {
+			return err
+		}
+		if used >= p.MaxUsesPerUser {
+			return notApplicable(ReasonUserLimit)
+		}
+	}
+	return nil
+}
+
+// rejected attributes a not-applicable error to the coupon code field and
+// counts it.
+func rejected(err error, field string) error {
+	var e *errs.Error
+	if !errors.As(err, &e) || !errors.Is(err, ErrNotApplicable) {
+		return err
+	}
+	reason := e.Metadata["reason"]
+	promotionRejections.WithLabelValues(reason).Inc()
+	return e.WithField(field, strings.ReplaceAll(reason, "_", " "))
+}
+
+func normalizeCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
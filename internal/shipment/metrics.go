@@ -0,0 +1,18 @@
+package shipment
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	shipmentsCreated = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "shipments_created_total",
+		Help: "Total number of shipments created, by carrier.",
+	}, []string{"carrier"})
+
+	trackingEvents = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "shipment_tracking_events_total",
+		Help: "Total number of tracking events received, by carrier and status.",
+	}, []string{"carrier", "status"})
+)
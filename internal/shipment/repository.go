@@ -0,0 +1,326 @@
+package shipment
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dipendra-mule/microservice-with-grpc/internal/order"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/database"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/errs"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/tracing"
+	orderv1 "github.com/dipendra-mule/microservice-with-grpc/proto/order"
+	"github.com/dipendra-mule/microservice-with-grpc/proto/shipment"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// Shipment statuses, set from the carrier's tracking events.
+const (
+	StatusInTransit      = "in_transit"
+	StatusOutForDelivery = "out_for_delivery"
+	StatusDelivered      = "delivered"
+	StatusException      = "exception"
+)
+
+var (
+	ErrShipmentNotFound     = errs.New(errs.NotFound, "SHIPMENT_NOT_FOUND", "shipment not found")
+	ErrTrackingNumberExists = errs.New(errs.AlreadyExists, "TRACKING_NUMBER_EXISTS", "a shipment with this tracking number already exists")
+)
+
+type Repository struct {
+	db *sql.DB
+}
+
+func NewRepository(db *sql.DB) *Repository {
+	return &Repository{
+		db: db,
+	}
+}
+
+// CreateShipment stores a shipment of part or all of an order's items.
+// check validates the shipment against the locked order and the
+// quantities already shipped by product. Once every item is shipped the
+// order becomes shipped, in the same transaction.
+func (r *Repository) CreateShipment(ctx context.Context, s *shipment.Shipment, actor *orderv1.Actor,
+	check func(o *orderv1.Order, shipped map[string]int32) error) (*shipment.Shipment, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	o, err := order.LockOrder(ctx, tx, s.OrderId)
+	if err != nil {
+		return nil, err
+	}
+	shipped, err := order.ShippedQuantities(ctx, tx, o.Id)
+	if err != nil {
+		return nil, err
+	}
+	if err := check(o, shipped); err != nil {
+		return nil, err
+	}
+
+	s.Id = uuid.New().String()
+	now := time.Now()
+	qctx, end := tracing.Query(ctx, "INSERT", "shipments")
+	_, err = tx.ExecContext(qctx, `
+		INSERT INTO shipments (id, order_id, carrier, tracking_number, status, status_at, shipped_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $6, $7, $7)
+	`, s.Id, s.OrderId, s.Carrier, s.TrackingNumber, StatusInTransit, s.ShippedAt.AsTime(), now)
+	end(err)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return nil, ErrTrackingNumberExists.WithResource("tracking_number", s.Carrier+"/"+s.TrackingNumber).
+				WithField("tracking_number", "already used for another shipment")
+		}
+		return nil, fmt.Errorf("failed to create shipment: %w", err)
+	}
+
+	for _, item := range s.Items {
+		qctx, end := tracing.Query(ctx, "INSERT", "shipment_items")
+		_, err = tx.ExecContext(qctx, `
+			INSERT INTO shipment_items (shipment_id, product_id, quantity)
+			VALUES ($1, $2, $3)
+		`, s.Id, item.ProductId, item.Quantity)
+		end(err)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create shipment item: %w", err)
+		}
+	}
+
+	if err := order.AdvanceStatus(ctx, tx, o, actor); err != nil {
+		return nil, err
+	}
+
+	created, err := getShipment(ctx, tx, "id = $1", s.Id)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return created, nil
+}
+
+func (r *Repository) GetShipment(ctx context.Context, id string) (*shipment.Shipment, error) {
+	return getShipment(ctx, r.db, "id = $1", id)
+}
+
+// ListShipments returns an order's shipments, oldest first.
+func (r *Repository) ListShipments(ctx context.Context, orderID string) ([]*shipment.Shipment, error) {
+	qctx, end := tracing.Query(ctx, "SELECT", "shipments")
+	rows, err := r.db.QueryContext(qctx, `
+		SELECT `+shipmentColumns+`
+		FROM shipments
+		WHERE order_id = $1
+		ORDER BY created_at, id
+	`, orderID)
+	end(err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list shipments: %w", err)
+	}
+	defer rows.Close()
+
+	var shipments []*shipment.Shipment
+	for rows.Next() {
+		s, err := scanShipment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan shipment: %w", err)
+		}
+		shipments = append(shipments, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating shipments: %w", err)
+	}
+
+	for _, s := range shipments {
+		if err := getDetails(ctx, r.db, s); err != nil {
+			return nil, err
+		}
+	}
+	return shipments, nil
+}
+
+// RecordTrackingEvent adds an event to the shipment matching where and
+// args, updating its status unless a later event already set it. When the
+// last shipment of a fully shipped order is delivered the order becomes
+// delivered, in the same transaction. An event whose event_id was already
+// recorded for the shipment changes nothing.
+func (r *Repository) RecordTrackingEvent(ctx context.Context, e *shipment.TrackingEvent, actor *orderv1.Actor, where string, args ...interface{}) (*shipment.Shipment, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Lock the order before the shipment, in the order CreateShipment
+	// takes them.
+	var shipmentID, orderID string
+	qctx, end := tracing.Query(ctx, "SELECT", "shipments")
+	err = tx.QueryRowContext(qctx, "SELECT id, order_id FROM shipments WHERE "+where, args...).Scan(&shipmentID, &orderID)
+	end(err)
+	if err == sql.ErrNoRows {
+		return nil, ErrShipmentNotFound.WithResource("shipment", resourceName(args))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shipment: %w", err)
+	}
+	o, err := order.LockOrder(ctx, tx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	s, err := getShipment(ctx, tx, "id = $1 FOR UPDATE", shipmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	qctx, end = tracing.Query(ctx, "INSERT", "tracking_events")
+	res, err := tx.ExecContext(qctx, `
+		INSERT INTO tracking_events (shipment_id, event_id, status, description, location, occurred_at, recorded_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (shipment_id, event_id) WHERE event_id <> '' DO NOTHING
+	`, s.Id, e.EventId, e.Status, e.Description, e.Location, e.OccurredAt.AsTime(), now)
+	end(err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record tracking event: %w", err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return nil, err
+	} else if n == 0 {
+		return s, nil
+	}
+
+	qctx, end = tracing.Query(ctx, "UPDATE", "shipments")
+	_, err = tx.ExecContext(qctx, `
+		UPDATE shipments
+		SET status = $2, status_at = $3, updated_at = $4,
+			delivered_at = CASE WHEN $2 = 'delivered' THEN $3 ELSE delivered_at END
+		WHERE id = $1 AND status <> 'delivered' AND (status_at <= $3 OR $2 = 'delivered')
+	`, s.Id, e.Status, e.OccurredAt.AsTime(), now)
+	end(err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update shipment status: %w", err)
+	}
+
+	if e.Status == StatusDelivered {
+		if err := order.AdvanceStatus(ctx, tx, o, actor); err != nil {
+			return nil, err
+		}
+	}
+
+	updated, err := getShipment(ctx, tx, "id = $1", s.Id)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return updated, nil
+}
+
+// querier is implemented by *sql.DB and *sql.Tx.
+type querier interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// getShipment reads the shipment matching where, with its items and events.
+func getShipment(ctx context.Context, q querier, where string, args ...interface{}) (*shipment.Shipment, error) {
+	qctx, end := tracing.Query(ctx, "SELECT", "shipments")
+	s, err := scanShipment(q.QueryRowContext(qctx, "SELECT "+shipmentColumns+" FROM shipments WHERE "+where, args...))
+	end(err)
+	if err == sql.ErrNoRows {
+		return nil, ErrShipmentNotFound.WithResource("shipment", resourceName(args))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shipment: %w", err)
+	}
+	if err := getDetails(ctx, q, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// getDetails reads the items and tracking events of s.
+func getDetails(ctx context.Context, q querier, s *shipment.Shipment) error {
+	qctx, end := tracing.Query(ctx, "SELECT", "shipment_items")
+	rows, err := q.QueryContext(qctx, `
+		SELECT product_id, quantity
+		FROM shipment_items
+		WHERE shipment_id = $1
+		ORDER BY product_id
+	`, s.Id)
+	end(err)
+	if err != nil {
+		return fmt.Errorf("failed to get shipment items: %w", err)
+	}
+	for rows.Next() {
+		item := &shipment.ShipmentItem{}
+		if err := rows.Scan(&item.ProductId, &item.Quantity); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan shipment item: %w", err)
+		}
+		s.Items = append(s.Items, item)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating shipment items: %w", err)
+	}
+
+	qctx, end = tracing.Query(ctx, "SELECT", "tracking_events")
+	rows, err = q.QueryContext(qctx, `
+		SELECT event_id, status, description, location, occurred_at, recorded_at
+		FROM tracking_events
+		WHERE shipment_id = $1
+		ORDER BY occurred_at, id
+	`, s.Id)
+	end(err)
+	if err != nil {
+		return fmt.Errorf("failed to get tracking events: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		e := &shipment.TrackingEvent{}
+		if err := rows.Scan(&e.EventId, &e.Status, &e.Description, &e.Location,
+			database.Timestamp(&e.OccurredAt), database.Timestamp(&e.RecordedAt)); err != nil {
+			return fmt.Errorf("failed to scan tracking event: %w", err)
+		}
+		s.Events = append(s.Events, e)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating tracking events: %w", err)
+	}
+	return nil
+}
+
+// shipmentColumns are the shipments columns read by scanShipment, in order.
+const shipmentColumns = "id, order_id, carrier, tracking_number, status, shipped_at, delivered_at, created_at, updated_at"
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanShipment(row rowScanner) (*shipment.Shipment, error) {
+	s := &shipment.Shipment{}
+	if err := row.Scan(&s.Id, &s.OrderId, &s.Carrier, &s.TrackingNumber, &s.Status,
+		database.Timestamp(&s.ShippedAt), database.Timestamp(&s.DeliveredAt),
+		database.Timestamp(&s.CreatedAt), database.Timestamp(&s.UpdatedAt)); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// resourceName names the shipment matched by a query's arguments in
+// errors, e.g. "ups/1Z999".
+func resourceName(args []interface{}) string {
+	parts := make([]string, len(args))
+	for i, a := range args {
+		parts[i] = fmt.Sprint(a)
+	}
+	return strings.Join(parts, "/")
+}
@@ -0,0 +1,48 @@
+package shipment
+
+import (
+	"context"
+
+	"github.com/dipendra-mule/microservice-with-grpc/proto/shipment"
+)
+
+type Server struct {
+	shipment.UnimplementedShipmentServiceServer
+	service *Service
+}
+
+func NewServer(s *Service) *Server {
+	return &Server{service: s}
+}
+
+func (s *Server) CreateShipment(ctx context.Context, r *shipment.CreateShipmentRequest) (*shipment.ShipmentResponse, error) {
+	created, err := s.service.CreateShipment(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	return &shipment.ShipmentResponse{Shipment: created}, nil
+}
+
+func (s *Server) GetShipment(ctx context.Context, r *shipment.GetShipmentRequest) (*shipment.ShipmentResponse, error) {
+	sh, err := s.service.GetShipment(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	return &shipment.ShipmentResponse{Shipment: sh}, nil
+}
+
+func (s *Server) ListShipments(ctx context.Context, r *shipment.ListShipmentsRequest) (*shipment.ListShipmentsResponse, error) {
+	shipments, err := s.service.ListShipments(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	return &shipment.ListShipmentsResponse{Shipments: shipments}, nil
+}
+
+func (s *Server) RecordTrackingEvent(ctx context.Context, r *shipment.RecordTrackingEventRequest) (*shipment.ShipmentResponse, error) {
+	updated, err := s.service.RecordTrackingEvent(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	return &shipment.ShipmentResponse{Shipment: updated}, nil
+}
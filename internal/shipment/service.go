@@ -0,0 +1,133 @@
+package shipment
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dipendra-mule/microservice-with-grpc/internal/order"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/errs"
+	orderv1 "github.com/dipendra-mule/microservice-with-grpc/proto/order"
+	"github.com/dipendra-mule/microservice-with-grpc/proto/shipment"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ActorCarrier is the role recorded on order changes made by tracking
+// events, which carriers send without a user token.
+const ActorCarrier = "carrier"
+
+var (
+	ErrOrderNotShippable = errs.New(errs.FailedPrecondition, "ORDER_NOT_SHIPPABLE", "order cannot be shipped")
+	ErrInvalidShipment   = errs.New(errs.InvalidArgument, "INVALID_SHIPMENT", "invalid shipment")
+)
+
+type Service struct {
+	repo *Repository
+}
+
+func NewService(repo *Repository) *Service {
+	return &Service{
+		repo: repo,
+	}
+}
+
+// CreateShipment records a parcel holding part or all of a confirmed
+// order's items.
+func (s *Service) CreateShipment(ctx context.Context, r *shipment.CreateShipmentRequest) (*shipment.Shipment, error) {
+	sh := &shipment.Shipment{
+		OrderId:        r.OrderId,
+		Carrier:        r.Carrier,
+		TrackingNumber: r.TrackingNumber,
+		Items:          r.Items,
+		ShippedAt:      r.ShippedAt,
+	}
+	if sh.ShippedAt == nil {
+		sh.ShippedAt = timestamppb.Now()
+	}
+	created, err := s.repo.CreateShipment(ctx, sh, order.ActorFromContext(ctx, order.ActorAdmin),
+		func(o *orderv1.Order, shipped map[string]int32) error {
+			return checkItems(o, shipped, r.Items)
+		})
+	if err != nil {
+		return nil, err
+	}
+	shipmentsCreated.WithLabelValues(created.Carrier).Inc()
+	return created, nil
+}
+
+func (s *Service) GetShipment(ctx context.Context, r *shipment.GetShipmentRequest) (*shipment.Shipment, error) {
+	return s.repo.GetShipment(ctx, r.Id)
+}
+
+func (s *Service) ListShipments(ctx context.Context, r *shipment.ListShipmentsRequest) ([]*shipment.Shipment, error) {
+	return s.repo.ListShipments(ctx, r.OrderId)
+}
+
+// RecordTrackingEvent adds a carrier's status update to a shipment, moving
+// its order to delivered once every shipment of the order is.
+func (s *Service) RecordTrackingEvent(ctx context.Context, r *shipment.RecordTrackingEventRequest) (*shipment.Shipment, error) {
+	e := &shipment.TrackingEvent{
+		EventId:     r.EventId,
+		Status:      r.Status,
+		Description: r.Description,
+		Location:    r.Location,
+		OccurredAt:  r.OccurredAt,
+	}
+	if e.OccurredAt == nil {
+		e.OccurredAt = timestamppb.Now()
+	}
+
+	actor := order.ActorFromContext(ctx, ActorCarrier)
+	var updated *shipment.Shipment
+	var err error
+	switch ref := r.Shipment.(type) {
+	case *shipment.RecordTrackingEventRequest_ShipmentId:
+		updated, err = s.repo.RecordTrackingEvent(ctx, e, actor, "id = $1", ref.ShipmentId)
+	case *shipment.RecordTrackingEventRequest_Tracking:
+		updated, err = s.repo.RecordTrackingEvent(ctx, e, actor, "carrier = $1 AND tracking_number = $2",
+			ref.Tracking.Carrier, ref.Tracking.TrackingNumber)
+	}
+	if err != nil {
+		return nil, err
+	}
+	trackingEvents.WithLabelValues(updated.Carrier, e.Status).Inc()
+	return updated, nil
+}
+
+// checkItems validates the items of a new shipment of o against what is
+// left to ship: ordered units that were neither refunded nor shipped.
+// An order has one line per product, so the shipped quantities by product
+// apply to that line.
+func checkItems(o *orderv1.Order, shipped map[string]int32, items []*shipment.ShipmentItem) error {
+	if o.Status != order.StatusConfirmed && o.Status != order.StatusShipped {
+		return ErrOrderNotShippable.WithResource("order", o.Id).WithMetadata("status", o.Status)
+	}
+
+	ordered := make(map[string]*orderv1.OrderItem, len(o.Items))
+	for _, item := range o.Items {
+		ordered[item.ProductId] = item
+	}
+
+	invalid := ErrInvalidShipment
+	valid := true
+	seen := make(map[string]bool, len(items))
+	for i, item := range items {
+		oi, ok := ordered[item.ProductId]
+		switch {
+		case !ok:
+			invalid, valid = invalid.WithField(fmt.Sprintf("items[%d].product_id", i), "not in the order"), false
+		case seen[item.ProductId]:
+			invalid, valid = invalid.WithField(fmt.Sprintf("items[%d].product_id", i), "listed more than once"), false
+		default:
+			left := oi.Quantity - oi.RefundedQuantity - shipped[item.ProductId]
+			if item.Quantity > left {
+				invalid, valid = invalid.WithField(fmt.Sprintf("items[%d].quantity", i),
+					fmt.Sprintf("at most %d left to ship", max(left, 0))), false
+			}
+		}
+		seen[item.ProductId] = true
+	}
+	if !valid {
+		return invalid
+	}
+	return nil
+}
@@ -0,0 +1,11 @@
+package user
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var authAttempts = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "user_authentications_total",
+	Help: "Total number of authentication attempts, by result.",
+}, []string{"result"})
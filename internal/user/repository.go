@@ -3,19 +3,28 @@ package user
 import (
 	"context"
 	"database/sql"
-	"errors"
-
+	"fmt"
 	"time"
 
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/database"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/errs"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/pagination"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/tracing"
 	"github.com/dipendra-mule/microservice-with-grpc/proto/user"
+	"github.com/lib/pq"
 	"golang.org/x/crypto/bcrypt"
 )
 
 var (
-	ErrUserNotFound = errors.New("user not found")
-	ErrEmailExists  = errors.New("email already exists")
+	ErrUserNotFound     = errs.New(errs.NotFound, "USER_NOT_FOUND", "user not found")
+	ErrEmailExists      = errs.New(errs.AlreadyExists, "EMAIL_EXISTS", "email already exists")
+	ErrAddressNotFound  = errs.New(errs.NotFound, "ADDRESS_NOT_FOUND", "address not found")
+	ErrTooManyAddresses = errs.New(errs.FailedPrecondition, "TOO_MANY_ADDRESSES", "address book is full")
 )
 
+// MaxAddresses is the number of addresses a user can keep.
+const MaxAddresses = 20
+
 type Repository struct {
 	db *sql.DB
 }
@@ -29,8 +38,10 @@ func NewRepository(db *sql.DB) *Repository {
 func (r *Repository) CreateUser(ctx context.Context, req *user.CreateUserRequest) (*user.User, error) {
 	// Check if email already exists
 	var count int
-	err := r.db.QueryRowContext(ctx,
+	qctx, end := tracing.Query(ctx, "SELECT COUNT", "users")
+	err := r.db.QueryRowContext(qctx,
 		"SELECT COUNT(*) FROM users WHERE email = $1", req.Email).Scan(&count)
+	end(err)
 	if err != nil {
 		return nil, err
 	}
@@ -51,7 +62,9 @@ func (r *Repository) CreateUser(ctx context.Context, req *user.CreateUserRequest
 		RETURNING id, email, name, role, created_at, updated_at
 	`
 	now := time.Now()
-	err = r.db.QueryRowContext(ctx, query, req.Email, string(hashedPassword), req.Name, req.Role, now, now).Scan(&u.Id, &u.Email, &u.Name, &u.Role, &u.CreatedAt, &u.UpdatedAt)
+	qctx, end = tracing.Query(ctx, "INSERT", "users")
+	err = r.db.QueryRowContext(qctx, query, req.Email, string(hashedPassword), req.Name, req.Role, now, now).Scan(&u.Id, &u.Email, &u.Name, &u.Role, database.Timestamp(&u.CreatedAt), database.Timestamp(&u.UpdatedAt))
+	end(err)
 	if err != nil {
 		return nil, err
 	}
@@ -66,12 +79,14 @@ func (r *Repository) GetUserByID(ctx context.Context, id string) (*user.User, er
 		WHERE id = $1
 	`
 
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&u.Id, &u.Email, &u.Name, &u.Role, &u.CreatedAt, &u.UpdatedAt,
+	qctx, end := tracing.Query(ctx, "SELECT", "users")
+	err := r.db.QueryRowContext(qctx, query, id).Scan(
+		&u.Id, &u.Email, &u.Name, &u.Role, database.Timestamp(&u.CreatedAt), database.Timestamp(&u.UpdatedAt),
 	)
+	end(err)
 
 	if err == sql.ErrNoRows {
-		return nil, ErrUserNotFound
+		return nil, ErrUserNotFound.WithResource("user", id)
 	}
 
 	if err != nil {
@@ -81,6 +96,33 @@ func (r *Repository) GetUserByID(ctx context.Context, id string) (*user.User, er
 	return &u, nil
 }
 
+// GetUsersByIDs returns the users with the given IDs in a single query.
+// IDs without a user are left out; the order of the result is unspecified.
+func (r *Repository) GetUsersByIDs(ctx context.Context, ids []string) ([]*user.User, error) {
+	query := `
+		SELECT id, email, name, role, created_at, updated_at
+		FROM users
+		WHERE id = ANY($1)
+	`
+	qctx, end := tracing.Query(ctx, "SELECT", "users")
+	rows, err := r.db.QueryContext(qctx, query, pq.Array(ids))
+	end(err)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	users := make([]*user.User, 0, len(ids))
+	for rows.Next() {
+		var u user.User
+		if err := rows.Scan(&u.Id, &u.Email, &u.Name, &u.Role, database.Timestamp(&u.CreatedAt), database.Timestamp(&u.UpdatedAt)); err != nil {
+			return nil, err
+		}
+		users = append(users, &u)
+	}
+	return users, rows.Err()
+}
+
 func (r *Repository) GetUserByEmail(ctx context.Context, email string) (*user.User, string, error) {
 	var u user.User
 	var passwordHash string
@@ -90,9 +132,11 @@ func (r *Repository) GetUserByEmail(ctx context.Context, email string) (*user.Us
 		FROM users
 		WHERE email = $1
 	`
-	err := r.db.QueryRowContext(ctx, query, email).Scan(
-		&u.Id, &u.Email, &u.Name, &u.Role, &passwordHash, &u.CreatedAt, &u.UpdatedAt,
+	qctx, end := tracing.Query(ctx, "SELECT", "users")
+	err := r.db.QueryRowContext(qctx, query, email).Scan(
+		&u.Id, &u.Email, &u.Name, &u.Role, &passwordHash, database.Timestamp(&u.CreatedAt), database.Timestamp(&u.UpdatedAt),
 	)
+	end(err)
 
 	if err == sql.ErrNoRows {
 		return nil, "", ErrUserNotFound
@@ -104,34 +148,242 @@ func (r *Repository) GetUserByEmail(ctx context.Context, email string) (*user.Us
 	return &u, passwordHash, nil
 }
 
-func (r *Repository) ListUsers(ctx context.Context, page, limit int32) ([]*user.User, int32, error) {
-	offset := (page - 1) * limit
+// ListUsers returns users, newest first, for the given page. It returns up
+// to page.Limit+1 rows; see pagination.Trim.
+func (r *Repository) ListUsers(ctx context.Context, page pagination.Request) ([]*user.User, error) {
+	after, afterArgs := page.Where(1)
+	limitOffset, limitArgs := page.LimitOffset(1 + len(afterArgs))
 
-	rows, err := r.db.QueryContext(ctx, `
+	qctx, end := tracing.Query(ctx, "SELECT", "users")
+	rows, err := r.db.QueryContext(qctx, `
 		SELECT id, email, name, role, created_at, updated_at
 		FROM users
-		ORDER BY created_at DESC
-		LIMIT $1 OFFSET $2
-	`, limit, offset)
+		WHERE `+after+`
+		`+pagination.OrderBy+`
+		`+limitOffset, append(afterArgs, limitArgs...)...)
+	end(err)
 	if err != nil {
-		return nil, 0, err
+		return nil, err
 	}
 	defer rows.Close()
 
 	var users []*user.User
 	for rows.Next() {
 		var u user.User
-		if err := rows.Scan(&u.Id, &u.Email, &u.Name, &u.Role, &u.CreatedAt, &u.UpdatedAt); err != nil {
-			return nil, 0, err
+		if err := rows.Scan(&u.Id, &u.Email, &u.Name, &u.Role, database.Timestamp(&u.CreatedAt), database.Timestamp(&u.UpdatedAt)); err != nil {
+			return nil, err
 		}
 		users = append(users, &u)
 	}
+	return users, rows.Err()
+}
+
+// CountUsers returns the total number of users.
+func (r *Repository) CountUsers(ctx context.Context) (int32, error) {
 	var total int32
-	err = r.db.QueryRowContext(ctx, `
+	qctx, end := tracing.Query(ctx, "SELECT COUNT", "users")
+	err := r.db.QueryRowContext(qctx, `
 		SELECT COUNT(*) FROM users
 	`).Scan(&total)
+	end(err)
+	return total, err
+}
+
+// CreateAddress adds an address to a user's address book. A user's first
+// address becomes the default for shipping and billing.
+func (r *Repository) CreateAddress(ctx context.Context, a *user.Address) (*user.Address, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := lockUser(ctx, tx, a.UserId); err != nil {
+		return nil, err
+	}
+	var count int
+	qctx, end := tracing.Query(ctx, "SELECT COUNT", "addresses")
+	err = tx.QueryRowContext(qctx, "SELECT COUNT(*) FROM addresses WHERE user_id = $1", a.UserId).Scan(&count)
+	end(err)
+	if err != nil {
+		return nil, err
+	}
+	if count >= MaxAddresses {
+		return nil, ErrTooManyAddresses.WithResource("user", a.UserId).
+			WithMetadata("limit", fmt.Sprint(MaxAddresses))
+	}
+	if count == 0 {
+		a.DefaultShipping, a.DefaultBilling = true, true
+	}
+	if err := clearDefaults(ctx, tx, a); err != nil {
+		return nil, err
+	}
+
+	p := a.Address
+	now := time.Now()
+	qctx, end = tracing.Query(ctx, "INSERT", "addresses")
+	created, err := scanAddress(tx.QueryRowContext(qctx, `
+		INSERT INTO addresses (user_id, label, recipient_name, line1, line2, city, region, postal_code,
+			country_code, phone, default_shipping, default_billing, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $13)
+		RETURNING `+addressColumns,
+		a.UserId, a.Label, p.RecipientName, p.Line1, p.Line2, p.City, p.Region, p.PostalCode,
+		p.CountryCode, p.Phone, a.DefaultShipping, a.DefaultBilling, now))
+	end(err)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+func (r *Repository) GetAddress(ctx context.Context, id, userID string) (*user.Address, error) {
+	qctx, end := tracing.Query(ctx, "SELECT", "addresses")
+	a, err := scanAddress(r.db.QueryRowContext(qctx, `
+		SELECT `+addressColumns+`
+		FROM addresses
+		WHERE id = $1 AND user_id = $2
+	`, id, userID))
+	end(err)
+	if err == sql.ErrNoRows {
+		return nil, ErrAddressNotFound.WithResource("address", id)
+	}
 	if err != nil {
-		return nil, 0, err
+		return nil, err
+	}
+	return a, nil
+}
+
+// ListAddresses returns a user's addresses, defaults first, then newest
+// first.
+func (r *Repository) ListAddresses(ctx context.Context, userID string) ([]*user.Address, error) {
+	qctx, end := tracing.Query(ctx, "SELECT", "addresses")
+	rows, err := r.db.QueryContext(qctx, `
+		SELECT `+addressColumns+`
+		FROM addresses
+		WHERE user_id = $1
+		ORDER BY default_shipping DESC, default_billing DESC, created_at DESC, id DESC
+	`, userID)
+	end(err)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var addresses []*user.Address
+	for rows.Next() {
+		a, err := scanAddress(rows)
+		if err != nil {
+			return nil, err
+		}
+		addresses = append(addresses, a)
+	}
+	return addresses, rows.Err()
+}
+
+// UpdateAddress replaces an address, including its default flags.
+func (r *Repository) UpdateAddress(ctx context.Context, a *user.Address) (*user.Address, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := lockUser(ctx, tx, a.UserId); err != nil {
+		return nil, err
+	}
+	if err := clearDefaults(ctx, tx, a); err != nil {
+		return nil, err
+	}
+
+	p := a.Address
+	qctx, end := tracing.Query(ctx, "UPDATE", "addresses")
+	updated, err := scanAddress(tx.QueryRowContext(qctx, `
+		UPDATE addresses
+		SET label = $3, recipient_name = $4, line1 = $5, line2 = $6, city = $7, region = $8,
+			postal_code = $9, country_code = $10, phone = $11, default_shipping = $12,
+			default_billing = $13, updated_at = $14
+		WHERE id = $1 AND user_id = $2
+		RETURNING `+addressColumns,
+		a.Id, a.UserId, a.Label, p.RecipientName, p.Line1, p.Line2, p.City, p.Region,
+		p.PostalCode, p.CountryCode, p.Phone, a.DefaultShipping, a.DefaultBilling, time.Now()))
+	end(err)
+	if err == sql.ErrNoRows {
+		return nil, ErrAddressNotFound.WithResource("address", a.Id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// DeleteAddress removes an address. Deleting a default address leaves the
+// user without that default.
+func (r *Repository) DeleteAddress(ctx context.Context, id, userID string) error {
+	qctx, end := tracing.Query(ctx, "DELETE", "addresses")
+	res, err := r.db.ExecContext(qctx, "DELETE FROM addresses WHERE id = $1 AND user_id = $2", id, userID)
+	end(err)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return ErrAddressNotFound.WithResource("address", id)
+	}
+	return nil
+}
+
+// lockUser locks the user's row for the rest of tx, serializing changes to
+// the user's address book.
+func lockUser(ctx context.Context, tx *sql.Tx, userID string) error {
+	var id string
+	qctx, end := tracing.Query(ctx, "SELECT", "users")
+	err := tx.QueryRowContext(qctx, "SELECT id FROM users WHERE id = $1 FOR UPDATE", userID).Scan(&id)
+	end(err)
+	if err == sql.ErrNoRows {
+		return ErrUserNotFound.WithResource("user", userID)
+	}
+	return err
+}
+
+// clearDefaults takes the default flags a is about to get from the user's
+// other addresses.
+func clearDefaults(ctx context.Context, tx *sql.Tx, a *user.Address) error {
+	if !a.DefaultShipping && !a.DefaultBilling {
+		return nil
+	}
+	qctx, end := tracing.Query(ctx, "UPDATE", "addresses")
+	_, err := tx.ExecContext(qctx, `
+		UPDATE addresses
+		SET default_shipping = default_shipping AND NOT $3,
+			default_billing = default_billing AND NOT $4
+		WHERE user_id = $1 AND id::text <> $2 AND ((default_shipping AND $3) OR (default_billing AND $4))
+	`, a.UserId, a.Id, a.DefaultShipping, a.DefaultBilling)
+	end(err)
+	return err
+}
+
+// addressColumns are the addresses columns read by scanAddress, in order.
+const addressColumns = "id, user_id, label, recipient_name, line1, line2, city, region, postal_code, " +
+	"country_code, phone, default_shipping, default_billing, created_at, updated_at"
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanAddress(row rowScanner) (*user.Address, error) {
+	a := &user.Address{Address: &user.PostalAddress{}}
+	p := a.Address
+	if err := row.Scan(&a.Id, &a.UserId, &a.Label, &p.RecipientName, &p.Line1, &p.Line2, &p.City, &p.Region,
+		&p.PostalCode, &p.CountryCode, &p.Phone, &a.DefaultShipping, &a.DefaultBilling,
+		database.Timestamp(&a.CreatedAt), database.Timestamp(&a.UpdatedAt)); err != nil {
+		return nil, err
 	}
-	return users, total, nil
+	return a, nil
 }
@@ -4,8 +4,6 @@ import (
 	"context"
 
 	"github.com/dipendra-mule/microservice-with-grpc/proto/user"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 )
 
 type Server struct {
@@ -20,10 +18,7 @@ func NewServer(service *Service) *Server {
 func (s *Server) CreateUser(ctx context.Context, req *user.CreateUserRequest) (*user.UserResponse, error) {
 	u, err := s.service.CreateUser(ctx, req)
 	if err != nil {
-		if err == ErrEmailExists {
-			return nil, status.Error(codes.AlreadyExists, "email already exists")
-		}
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, err
 	}
 
 	return &user.UserResponse{User: u}, nil
@@ -32,21 +27,18 @@ func (s *Server) CreateUser(ctx context.Context, req *user.CreateUserRequest) (*
 func (s *Server) GetUser(ctx context.Context, req *user.GetUserRequest) (*user.UserResponse, error) {
 	u, err := s.service.GetUser(ctx, req)
 	if err != nil {
-		if err == ErrUserNotFound {
-			return nil, status.Error(codes.NotFound, "user not found")
-		}
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, err
 	}
 
 	return &user.UserResponse{User: u}, nil
 }
 
+func (s *Server) BatchGetUsers(ctx context.Context, req *user.BatchGetUsersRequest) (*user.BatchGetUsersResponse, error) {
+	return s.service.BatchGetUsers(ctx, req)
+}
+
 func (s *Server) Authenticate(ctx context.Context, req *user.AuthRequest) (*user.AuthResponse, error) {
-	resp, err := s.service.Authenticate(ctx, req)
-	if err != nil {
-		return nil, status.Error(codes.Unauthenticated, "invalid credentials")
-	}
-	return resp, nil
+	return s.service.Authenticate(ctx, req)
 }
 
 func (s *Server) ValidateToken(ctx context.Context, req *user.ValidateTokenRequest) (*user.ValidateTokenResponse, error) {
@@ -56,3 +48,42 @@ func (s *Server) ValidateToken(ctx context.Context, req *user.ValidateTokenReque
 func (s *Server) ListUsers(ctx context.Context, req *user.ListUsersRequest) (*user.ListUsersResponse, error) {
 	return s.service.ListUsers(ctx, req)
 }
+
+func (s *Server) CreateAddress(ctx context.Context, req *user.CreateAddressRequest) (*user.AddressResponse, error) {
+	a, err := s.service.CreateAddress(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &user.AddressResponse{Address: a}, nil
+}
+
+func (s *Server) GetAddress(ctx context.Context, req *user.GetAddressRequest) (*user.AddressResponse, error) {
+	a, err := s.service.GetAddress(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &user.AddressResponse{Address: a}, nil
+}
+
+func (s *Server) ListAddresses(ctx context.Context, req *user.ListAddressesRequest) (*user.ListAddressesResponse, error) {
+	addresses, err := s.service.ListAddresses(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &user.ListAddressesResponse{Addresses: addresses}, nil
+}
+
+func (s *Server) UpdateAddress(ctx context.Context, req *user.UpdateAddressRequest) (*user.AddressResponse, error) {
+	a, err := s.service.UpdateAddress(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &user.AddressResponse{Address: a}, nil
+}
+
+func (s *Server) DeleteAddress(ctx context.Context, req *user.DeleteAddressRequest) (*user.DeleteAddressResponse, error) {
+	if err := s.service.DeleteAddress(ctx, req); err != nil {
+		return nil, err
+	}
+	return &user.DeleteAddressResponse{}, nil
+}
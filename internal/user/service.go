@@ -3,21 +3,34 @@ package user
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/dipendra-mule/microservice-with-grpc/pkg/auth"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/errs"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/pagination"
 	"github.com/dipendra-mule/microservice-with-grpc/proto/user"
 	"golang.org/x/crypto/bcrypt"
 )
 
+var (
+	ErrInvalidCredentials = errs.New(errs.Unauthenticated, "INVALID_CREDENTIALS", "invalid credentials")
+	ErrBatchTooLarge      = errs.New(errs.InvalidArgument, "BATCH_TOO_LARGE", "too many IDs in batch")
+)
+
 type Service struct {
 	repo       *Repository
 	jwtManager *auth.JWTManager
+	trusted    func(ctx context.Context) bool
 }
 
-func NewService(repo *Repository, jwtManager *auth.JWTManager) *Service {
+// NewService returns a user service. trusted reports whether a caller
+// without a token is a service that checked the user itself, such as the
+// order service reading the address book of the user placing an order.
+func NewService(repo *Repository, jwtManager *auth.JWTManager, trusted func(ctx context.Context) bool) *Service {
 	return &Service{
 		repo:       repo,
 		jwtManager: jwtManager,
+		trusted:    trusted,
 	}
 }
 
@@ -29,21 +42,70 @@ func (s *Service) GetUser(ctx context.Context, req *user.GetUserRequest) (*user.
 	return s.repo.GetUserByID(ctx, req.Id)
 }
 
+// MaxBatchGetUsers is the largest number of distinct IDs BatchGetUsers
+// accepts, matching the limit declared in user.proto.
+const MaxBatchGetUsers = 100
+
+// BatchGetUsers looks up many users with one query, returning the users in
+// request order and the IDs that matched no user.
+func (s *Service) BatchGetUsers(ctx context.Context, req *user.BatchGetUsersRequest) (*user.BatchGetUsersResponse, error) {
+	var ids []string
+	seen := make(map[string]bool, len(req.Ids))
+	for _, id := range req.Ids {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) > MaxBatchGetUsers {
+		return nil, ErrBatchTooLarge.WithField("ids", fmt.Sprintf("at most %d IDs per request", MaxBatchGetUsers))
+	}
+
+	found, err := s.repo.GetUsersByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*user.User, len(found))
+	for _, u := range found {
+		byID[u.Id] = u
+	}
+
+	resp := &user.BatchGetUsersResponse{}
+	for _, id := range ids {
+		if u, ok := byID[id]; ok {
+			resp.Users = append(resp.Users, u)
+		} else {
+			resp.MissingIds = append(resp.MissingIds, id)
+		}
+	}
+	return resp, nil
+}
+
 func (s *Service) Authenticate(ctx context.Context, req *user.AuthRequest) (*user.AuthResponse, error) {
 	u, passwordHash, err := s.repo.GetUserByEmail(ctx, req.Email)
 	if err != nil {
+		// An unknown email is reported exactly like a wrong password so
+		// callers cannot probe for registered addresses.
+		if errors.Is(err, ErrUserNotFound) {
+			authAttempts.WithLabelValues("unknown_user").Inc()
+			return nil, ErrInvalidCredentials
+		}
+		authAttempts.WithLabelValues("error").Inc()
 		return nil, err
 	}
 
 	err = bcrypt.CompareHashAndPassword([]byte(passwordHash), []byte(req.Password))
 	if err != nil {
-		return nil, errors.New("invalid credentials")
+		authAttempts.WithLabelValues("invalid_password").Inc()
+		return nil, ErrInvalidCredentials
 	}
 
 	token, err := s.jwtManager.Generate(u.Id, u.Email, u.Role)
 	if err != nil {
+		authAttempts.WithLabelValues("error").Inc()
 		return nil, err
 	}
+	authAttempts.WithLabelValues("success").Inc()
 
 	return &user.AuthResponse{
 		Token: token,
@@ -59,7 +121,12 @@ func (s *Service) ValidateToken(ctx context.Context, req *user.ValidateTokenRequ
 
 	u, err := s.repo.GetUserByID(ctx, claims.UserID)
 	if err != nil {
-		return &user.ValidateTokenResponse{Valid: false}, nil
+		// A token of a deleted user is invalid; failing to look the user
+		// up says nothing about the token.
+		if errors.Is(err, ErrUserNotFound) {
+			return &user.ValidateTokenResponse{Valid: false}, nil
+		}
+		return nil, err
 	}
 
 	return &user.ValidateTokenResponse{
@@ -68,17 +135,92 @@ func (s *Service) ValidateToken(ctx context.Context, req *user.ValidateTokenRequ
 	}, nil
 }
 
+// ListUsers returns one page of users, newest first. Pages are addressed by
+// page_token; the legacy page number still works and also returns the total.
 func (s *Service) ListUsers(ctx context.Context, req *user.ListUsersRequest) (*user.ListUsersResponse, error) {
-	users, total, err := s.repo.ListUsers(ctx, req.Page, req.Limit)
+	page, err := pagination.NewRequest(req.PageToken, req.Page, req.Limit, "")
 	if err != nil {
 		return nil, err
 	}
+	users, err := s.repo.ListUsers(ctx, page)
+	if err != nil {
+		return nil, err
+	}
+	users, next := pagination.Trim(users, page, "", func(u *user.User) pagination.Cursor {
+		return pagination.Cursor{CreatedAt: u.CreatedAt.AsTime(), ID: u.Id}
+	})
+
+	var total int32
+	if req.IncludeTotal || req.Page > 0 {
+		if total, err = s.repo.CountUsers(ctx); err != nil {
+			return nil, err
+		}
+	}
 	return &user.ListUsersResponse{
-		Users: users,
-		Total: total,
-		Page:  req.Page,
-		Limit: req.Limit,
+		Users:         users,
+		Total:         total,
+		Page:          req.Page,
+		Limit:         req.Limit,
+		NextPageToken: next,
 	}, nil
 }
 
+// authorizeUser allows a request for the data of userID from that user, an
+// admin or, without a token, a trusted service.
+func (s *Service) authorizeUser(ctx context.Context, userID string) error {
+	if _, ok := auth.FromContext(ctx); !ok && s.trusted != nil && s.trusted(ctx) {
+		return nil
+	}
+	_, err := auth.RequireUser(ctx, userID)
+	return err
+}
+
+func (s *Service) CreateAddress(ctx context.Context, req *user.CreateAddressRequest) (*user.Address, error) {
+	if err := s.authorizeUser(ctx, req.UserId); err != nil {
+		return nil, err
+	}
+	return s.repo.CreateAddress(ctx, &user.Address{
+		UserId:          req.UserId,
+		Label:           req.Label,
+		Address:         req.Address,
+		DefaultShipping: req.DefaultShipping,
+		DefaultBilling:  req.DefaultBilling,
+	})
+}
+
+func (s *Service) GetAddress(ctx context.Context, req *user.GetAddressRequest) (*user.Address, error) {
+	if err := s.authorizeUser(ctx, req.UserId); err != nil {
+		return nil, err
+	}
+	return s.repo.GetAddress(ctx, req.Id, req.UserId)
+}
+
+func (s *Service) ListAddresses(ctx context.Context, req *user.ListAddressesRequest) ([]*user.Address, error) {
+	if err := s.authorizeUser(ctx, req.UserId); err != nil {
+		return nil, err
+	}
+	return s.repo.ListAddresses(ctx, req.UserId)
+}
+
+func (s *Service) UpdateAddress(ctx context.Context, req *user.UpdateAddressRequest) (*user.Address, error) {
+	if err := s.authorizeUser(ctx, req.UserId); err != nil {
+		return nil, err
+	}
+	return s.repo.UpdateAddress(ctx, &user.Address{
+		Id:              req.Id,
+		UserId:          req.UserId,
+		Label:           req.Label,
+		Address:         req.Address,
+		DefaultShipping: req.DefaultShipping,
+		DefaultBilling:  req.DefaultBilling,
+	})
+}
+
+func (s *Service) DeleteAddress(ctx context.Context, req *user.DeleteAddressRequest) error {
+	if err := s.authorizeUser(ctx, req.UserId); err != nil {
+		return err
+	}
+	return s.repo.DeleteAddress(ctx, req.Id, req.UserId)
+}
+
 // implement UpdateUser method
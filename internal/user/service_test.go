@@ -0,0 +1,51 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/auth"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/errs"
+	"github.com/dipendra-mule/microservice-with-grpc/proto/user"
+)
+
+func TestAddressBookOfAnotherUser(t *testing.T) {
+	type trustedKey struct{}
+	// No repository: the calls must be rejected before reaching it.
+	s := NewService(nil, nil, func(ctx context.Context) bool { return ctx.Value(trustedKey{}) != nil })
+	other := auth.NewContext(context.Background(), &auth.Claims{UserID: "u2", Role: "user"})
+
+	calls := []struct {
+		name string
+		call func(ctx context.Context) error
+	}{
+		{"read", func(ctx context.Context) error {
+			_, err := s.GetAddress(ctx, &user.GetAddressRequest{Id: "a1", UserId: "u1"})
+			return err
+		}},
+		{"list", func(ctx context.Context) error {
+			_, err := s.ListAddresses(ctx, &user.ListAddressesRequest{UserId: "u1"})
+			return err
+		}},
+		{"delete", func(ctx context.Context) error {
+			return s.DeleteAddress(ctx, &user.DeleteAddressRequest{Id: "a1", UserId: "u1"})
+		}},
+	}
+	for _, c := range calls {
+		t.Run(c.name, func(t *testing.T) {
+			err := c.call(other)
+			if !errors.Is(err, auth.ErrNotOwner) || errs.KindOf(err) != errs.PermissionDenied {
+				t.Errorf("other user: error = %v, want permission denied", err)
+			}
+			if err := c.call(context.Background()); !errors.Is(err, auth.ErrTokenRequired) {
+				t.Errorf("anonymous: error = %v, want ErrTokenRequired", err)
+			}
+			// A trusted service presenting another user's token is still
+			// held to the token.
+			if err := c.call(context.WithValue(other, trustedKey{}, true)); !errors.Is(err, auth.ErrNotOwner) {
+				t.Errorf("trusted peer with token: error = %v, want ErrNotOwner", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+-- Base schema expected by the user, product and order repositories.
+
+CREATE EXTENSION IF NOT EXISTS pgcrypto;
+
+CREATE TABLE IF NOT EXISTS users (
+    id            UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    email         TEXT NOT NULL UNIQUE,
+    password_hash TEXT NOT NULL,
+    name          TEXT NOT NULL,
+    role          TEXT NOT NULL DEFAULT 'user',
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at    TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS products (
+    id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name        TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    price       REAL NOT NULL,
+    stock       INTEGER NOT NULL DEFAULT 0,
+    category    TEXT NOT NULL DEFAULT '',
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- user_id and product_id reference rows owned by other services, so they
+-- carry no foreign keys.
+CREATE TABLE IF NOT EXISTS orders (
+    id           UUID PRIMARY KEY,
+    user_id      UUID NOT NULL,
+    total_amount REAL NOT NULL,
+    status       TEXT NOT NULL,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at   TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS order_items (
+    id           UUID PRIMARY KEY,
+    order_id     UUID NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+    product_id   UUID NOT NULL,
+    quantity     INTEGER NOT NULL,
+    price        REAL NOT NULL,
+    product_name TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS order_items_order_id_idx ON order_items (order_id);
@@ -0,0 +1,7 @@
+-- Indexes backing keyset pagination, which orders lists by
+-- (created_at, id) descending and seeks past the previous page's last row.
+
+CREATE INDEX IF NOT EXISTS users_created_at_id_idx ON users (created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS products_created_at_id_idx ON products (created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS products_category_created_at_id_idx ON products (category, created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS orders_user_id_created_at_id_idx ON orders (user_id, created_at DESC, id DESC);
@@ -0,0 +1,14 @@
+-- Store prices and totals exactly. Existing REAL values are rounded to
+-- cents, the unit they were entered in, and assumed to be in USD.
+
+ALTER TABLE products
+    ALTER COLUMN price TYPE NUMERIC(19, 4) USING round(price::numeric, 2),
+    ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'USD';
+
+ALTER TABLE orders
+    ALTER COLUMN total_amount TYPE NUMERIC(19, 4) USING round(total_amount::numeric, 2),
+    ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'USD';
+
+-- Items are priced in their order's currency.
+ALTER TABLE order_items
+    ALTER COLUMN price TYPE NUMERIC(19, 4) USING round(price::numeric, 2);
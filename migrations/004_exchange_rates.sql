@@ -0,0 +1,16 @@
+-- Orders can be priced in another currency than their products. Items keep
+-- the product's own price and each order records the rates it used.
+
+ALTER TABLE order_items
+    ADD COLUMN IF NOT EXISTS original_price NUMERIC(19, 4),
+    ADD COLUMN IF NOT EXISTS original_currency CHAR(3);
+
+CREATE TABLE IF NOT EXISTS order_exchange_rates (
+    order_id      UUID NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+    from_currency CHAR(3) NOT NULL,
+    to_currency   CHAR(3) NOT NULL,
+    rate          NUMERIC(24, 12) NOT NULL,
+    as_of         TIMESTAMPTZ NOT NULL,
+    source        TEXT NOT NULL,
+    PRIMARY KEY (order_id, from_currency)
+);
@@ -0,0 +1,28 @@
+-- Orders carry a subtotal/tax/total breakdown and each item keeps the tax
+-- computed when the order was placed, so later rule changes never alter
+-- historical orders. total_amount remains the grand total.
+
+ALTER TABLE orders
+    ADD COLUMN IF NOT EXISTS subtotal NUMERIC(19, 4),
+    ADD COLUMN IF NOT EXISTS tax_total NUMERIC(19, 4),
+    ADD COLUMN IF NOT EXISTS shipping_region TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS tax_inclusive BOOLEAN NOT NULL DEFAULT FALSE;
+
+UPDATE orders SET subtotal = total_amount, tax_total = 0 WHERE subtotal IS NULL;
+
+ALTER TABLE orders
+    ALTER COLUMN subtotal SET NOT NULL,
+    ALTER COLUMN tax_total SET NOT NULL;
+
+-- tax_lines is a JSON array of {"name", "rate", "amount"} with decimal
+-- strings, in the order's currency.
+ALTER TABLE order_items
+    ADD COLUMN IF NOT EXISTS subtotal NUMERIC(19, 4),
+    ADD COLUMN IF NOT EXISTS tax NUMERIC(19, 4),
+    ADD COLUMN IF NOT EXISTS tax_lines JSONB NOT NULL DEFAULT '[]';
+
+UPDATE order_items SET subtotal = price * quantity, tax = 0 WHERE subtotal IS NULL;
+
+ALTER TABLE order_items
+    ALTER COLUMN subtotal SET NOT NULL,
+    ALTER COLUMN tax SET NOT NULL;
@@ -0,0 +1,53 @@
+-- Discount codes. A promotion has exactly one of percent_off, amount_off or
+-- buy_quantity/get_quantity; currency applies to amount_off and
+-- min_order_value. Zero limits mean unlimited.
+
+CREATE TABLE IF NOT EXISTS promotions (
+    id                UUID PRIMARY KEY,
+    code              TEXT NOT NULL UNIQUE,
+    description       TEXT NOT NULL DEFAULT '',
+    percent_off       NUMERIC(7, 4),
+    amount_off        NUMERIC(19, 4),
+    buy_quantity      INTEGER,
+    get_quantity      INTEGER,
+    currency          CHAR(3),
+    category          TEXT NOT NULL DEFAULT '',
+    min_order_value   NUMERIC(19, 4),
+    max_uses          INTEGER NOT NULL DEFAULT 0,
+    max_uses_per_user INTEGER NOT NULL DEFAULT 0,
+    times_used        INTEGER NOT NULL DEFAULT 0,
+    starts_at         TIMESTAMPTZ NOT NULL DEFAULT now(),
+    ends_at           TIMESTAMPTZ,
+    active            BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at        TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS promotion_redemptions (
+    promotion_id UUID NOT NULL REFERENCES promotions (id),
+    order_id     UUID NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+    user_id      UUID NOT NULL,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (promotion_id, order_id)
+);
+
+CREATE INDEX IF NOT EXISTS promotion_redemptions_user_idx ON promotion_redemptions (promotion_id, user_id);
+
+-- Adjustments are stored in the order's currency; amount is negative for
+-- discounts.
+CREATE TABLE IF NOT EXISTS order_adjustments (
+    order_id     UUID NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+    position     INTEGER NOT NULL,
+    kind         TEXT NOT NULL,
+    promotion_id UUID REFERENCES promotions (id),
+    code         TEXT NOT NULL DEFAULT '',
+    description  TEXT NOT NULL DEFAULT '',
+    product_id   UUID,
+    amount       NUMERIC(19, 4) NOT NULL,
+    PRIMARY KEY (order_id, position)
+);
+
+ALTER TABLE orders
+    ADD COLUMN IF NOT EXISTS discount_total NUMERIC(19, 4) NOT NULL DEFAULT 0;
+
+ALTER TABLE order_items
+    ADD COLUMN IF NOT EXISTS discount NUMERIC(19, 4) NOT NULL DEFAULT 0;
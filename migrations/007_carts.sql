@@ -0,0 +1,20 @@
+-- Shopping carts. A user has at most one cart; guest carts have no user.
+-- Carts past expires_at are ignored and periodically deleted.
+
+CREATE TABLE IF NOT EXISTS carts (
+    id         UUID PRIMARY KEY,
+    user_id    UUID UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS carts_expires_at_idx ON carts (expires_at);
+
+CREATE TABLE IF NOT EXISTS cart_items (
+    cart_id    UUID NOT NULL REFERENCES carts (id) ON DELETE CASCADE,
+    product_id UUID NOT NULL,
+    quantity   INTEGER NOT NULL,
+    added_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (cart_id, product_id)
+);
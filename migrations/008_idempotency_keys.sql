@@ -0,0 +1,17 @@
+-- Idempotency keys for CreateOrder, scoped to the ordering user. A key is
+-- claimed in the transaction that creates the order, so a concurrent retry
+-- waits for the first attempt and then replays its response. response is
+-- the serialized order.Order.
+
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    user_id      UUID NOT NULL,
+    key          TEXT NOT NULL,
+    request_hash BYTEA NOT NULL,
+    order_id     UUID,
+    response     BYTEA,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+    expires_at   TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (user_id, key)
+);
+
+CREATE INDEX IF NOT EXISTS idempotency_keys_expires_at_idx ON idempotency_keys (expires_at);
@@ -0,0 +1,58 @@
+-- Cancellations and refunds. Every change is appended to order_events, and
+-- stock to give back is queued in stock_releases in the same transaction,
+-- then sent to the product service until it is acknowledged.
+
+ALTER TABLE orders
+    ADD COLUMN IF NOT EXISTS refunded_total NUMERIC(19, 4) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS cancellation_reason TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS cancelled_at TIMESTAMPTZ;
+
+ALTER TABLE order_items
+    ADD COLUMN IF NOT EXISTS refunded_quantity INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS refunded_amount NUMERIC(19, 4) NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS refunds (
+    id         UUID PRIMARY KEY,
+    order_id   UUID NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+    amount     NUMERIC(19, 4) NOT NULL,
+    reason     TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS refunds_order_id_idx ON refunds (order_id);
+
+CREATE TABLE IF NOT EXISTS refund_items (
+    refund_id  UUID NOT NULL REFERENCES refunds (id) ON DELETE CASCADE,
+    product_id UUID NOT NULL,
+    quantity   INTEGER NOT NULL,
+    amount     NUMERIC(19, 4) NOT NULL,
+    PRIMARY KEY (refund_id, product_id)
+);
+
+CREATE TABLE IF NOT EXISTS order_events (
+    id              BIGSERIAL PRIMARY KEY,
+    order_id        UUID NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+    type            TEXT NOT NULL,
+    previous_status TEXT NOT NULL DEFAULT '',
+    new_status      TEXT NOT NULL DEFAULT '',
+    reason          TEXT NOT NULL DEFAULT '',
+    actor           TEXT NOT NULL DEFAULT '',
+    data            JSONB NOT NULL DEFAULT '{}',
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS order_events_order_id_idx ON order_events (order_id, id);
+
+-- reference identifies the cancellation or refund to the product service,
+-- which applies each reference once.
+CREATE TABLE IF NOT EXISTS stock_releases (
+    reference   TEXT NOT NULL,
+    product_id  UUID NOT NULL,
+    order_id    UUID NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+    quantity    INTEGER NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    released_at TIMESTAMPTZ,
+    PRIMARY KEY (reference, product_id)
+);
+
+CREATE INDEX IF NOT EXISTS stock_releases_pending_idx ON stock_releases (created_at) WHERE released_at IS NULL;
@@ -0,0 +1,11 @@
+-- Order events record who made each change from the caller's token:
+-- actor holds the user ID, actor_email and actor_role the rest.
+
+ALTER TABLE order_events
+    ADD COLUMN IF NOT EXISTS actor_email TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS actor_role TEXT NOT NULL DEFAULT '';
+
+-- Earlier events stored only the role stated in the request.
+UPDATE order_events
+SET actor_role = actor, actor = ''
+WHERE actor IN ('customer', 'admin') AND actor_role = '';
@@ -0,0 +1,30 @@
+-- Address books, and the shipping address each order was placed with.
+-- Orders keep a copy rather than a reference, so editing or deleting an
+-- address leaves past orders unchanged.
+
+CREATE TABLE IF NOT EXISTS addresses (
+    id               UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id          UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    label            TEXT NOT NULL DEFAULT '',
+    recipient_name   TEXT NOT NULL,
+    line1            TEXT NOT NULL,
+    line2            TEXT NOT NULL DEFAULT '',
+    city             TEXT NOT NULL,
+    region           TEXT NOT NULL DEFAULT '',
+    postal_code      TEXT NOT NULL DEFAULT '',
+    country_code     TEXT NOT NULL,
+    phone            TEXT NOT NULL DEFAULT '',
+    default_shipping BOOLEAN NOT NULL DEFAULT false,
+    default_billing  BOOLEAN NOT NULL DEFAULT false,
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at       TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS addresses_user_id_idx ON addresses (user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS addresses_default_shipping_idx ON addresses (user_id) WHERE default_shipping;
+CREATE UNIQUE INDEX IF NOT EXISTS addresses_default_billing_idx ON addresses (user_id) WHERE default_billing;
+
+-- shipping_address is the JSON form of a user.PostalAddress.
+ALTER TABLE orders
+    ADD COLUMN IF NOT EXISTS shipping_address JSONB,
+    ADD COLUMN IF NOT EXISTS shipping_address_id UUID;
@@ -0,0 +1,41 @@
+-- Shipments of orders and their carriers' tracking events. An order can
+-- ship in several shipments, each holding part of its items.
+
+CREATE TABLE IF NOT EXISTS shipments (
+    id              UUID PRIMARY KEY,
+    order_id        UUID NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+    carrier         TEXT NOT NULL,
+    tracking_number TEXT NOT NULL,
+    status          TEXT NOT NULL,
+    -- When the event that set status occurred; older events arriving late
+    -- do not change it.
+    status_at       TIMESTAMPTZ NOT NULL,
+    shipped_at      TIMESTAMPTZ NOT NULL,
+    delivered_at    TIMESTAMPTZ,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (carrier, tracking_number)
+);
+
+CREATE INDEX IF NOT EXISTS shipments_order_id_idx ON shipments (order_id);
+
+CREATE TABLE IF NOT EXISTS shipment_items (
+    shipment_id UUID NOT NULL REFERENCES shipments (id) ON DELETE CASCADE,
+    product_id  UUID NOT NULL,
+    quantity    INTEGER NOT NULL,
+    PRIMARY KEY (shipment_id, product_id)
+);
+
+CREATE TABLE IF NOT EXISTS tracking_events (
+    id          BIGSERIAL PRIMARY KEY,
+    shipment_id UUID NOT NULL REFERENCES shipments (id) ON DELETE CASCADE,
+    event_id    TEXT NOT NULL DEFAULT '',
+    status      TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    location    TEXT NOT NULL DEFAULT '',
+    occurred_at TIMESTAMPTZ NOT NULL,
+    recorded_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS tracking_events_shipment_id_idx ON tracking_events (shipment_id, occurred_at);
+CREATE UNIQUE INDEX IF NOT EXISTS tracking_events_event_id_idx ON tracking_events (shipment_id, event_id) WHERE event_id <> '';
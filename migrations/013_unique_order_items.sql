@@ -0,0 +1,49 @@
+-- An order has one line per product: refunds, stock releases and shipments
+-- all refer to order items by product. Lines that repeat a product in
+-- existing orders are merged into the first of them before the unique
+-- index is created.
+
+CREATE TEMPORARY TABLE duplicate_order_items AS
+SELECT order_id, product_id, MIN(id::text)::uuid AS keep
+FROM order_items
+GROUP BY order_id, product_id
+HAVING COUNT(*) > 1;
+
+UPDATE order_items i
+SET quantity = m.quantity,
+    subtotal = m.subtotal,
+    tax = m.tax,
+    discount = m.discount,
+    refunded_quantity = m.refunded_quantity,
+    refunded_amount = m.refunded_amount,
+    tax_lines = m.tax_lines
+FROM (
+    SELECT d.keep,
+        SUM(oi.quantity) AS quantity,
+        SUM(oi.subtotal) AS subtotal,
+        SUM(oi.tax) AS tax,
+        SUM(oi.discount) AS discount,
+        SUM(oi.refunded_quantity) AS refunded_quantity,
+        SUM(oi.refunded_amount) AS refunded_amount,
+        COALESCE((
+            SELECT jsonb_agg(jsonb_build_object('name', t.name, 'rate', t.rate, 'amount', t.amount::text))
+            FROM (
+                SELECT l->>'name' AS name, l->>'rate' AS rate, SUM((l->>'amount')::numeric) AS amount
+                FROM order_items li, jsonb_array_elements(li.tax_lines) l
+                WHERE li.order_id = d.order_id AND li.product_id = d.product_id
+                GROUP BY 1, 2
+            ) t
+        ), '[]') AS tax_lines
+    FROM duplicate_order_items d
+    JOIN order_items oi ON oi.order_id = d.order_id AND oi.product_id = d.product_id
+    GROUP BY d.keep, d.order_id, d.product_id
+) m
+WHERE i.id = m.keep;
+
+DELETE FROM order_items i
+USING duplicate_order_items d
+WHERE i.order_id = d.order_id AND i.product_id = d.product_id AND i.id <> d.keep;
+
+DROP TABLE duplicate_order_items;
+
+CREATE UNIQUE INDEX IF NOT EXISTS order_items_order_id_product_id_idx ON order_items (order_id, product_id);
@@ -0,0 +1,82 @@
+package auth
+
+import (
+	"context"
+	"strings"
+
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/errs"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// ErrUnauthenticated is returned for requests carrying a token that does
+// not verify.
+var ErrUnauthenticated = errs.New(errs.Unauthenticated, "INVALID_TOKEN", "invalid or expired token")
+
+type ctxKey struct{}
+
+// NewContext returns a context carrying the caller's claims.
+func NewContext(ctx context.Context, c *Claims) context.Context {
+	return context.WithValue(ctx, ctxKey{}, c)
+}
+
+// FromContext returns the claims of the authenticated caller, if any.
+func FromContext(ctx context.Context) (*Claims, bool) {
+	c, ok := ctx.Value(ctxKey{}).(*Claims)
+	return c, ok
+}
+
+// UnaryServerInterceptor verifies the bearer token in the authorization
+// metadata, which the gateway forwards from the Authorization header, and
+// puts its claims in the context. Requests without a token pass through
+// unauthenticated; handlers decide what they need.
+func UnaryServerInterceptor(m *JWTManager) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := authenticate(ctx, m)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor is the streaming counterpart of
+// UnaryServerInterceptor.
+func StreamServerInterceptor(m *JWTManager) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authenticate(ss.Context(), m)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+func authenticate(ctx context.Context, m *JWTManager) (context.Context, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx, nil
+	}
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return ctx, nil
+	}
+	token := strings.TrimSpace(values[0])
+	if len(token) > 7 && strings.EqualFold(token[:7], "bearer ") {
+		token = strings.TrimSpace(token[7:])
+	}
+	claims, err := m.Verify(token)
+	if err != nil {
+		return nil, ErrUnauthenticated
+	}
+	return NewContext(ctx, claims), nil
+}
+
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
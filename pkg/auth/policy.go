@@ -0,0 +1,84 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/errs"
+	"google.golang.org/grpc"
+)
+
+// RoleAdmin is the token role of operators.
+const RoleAdmin = "admin"
+
+var (
+	ErrTokenRequired    = errs.New(errs.Unauthenticated, "TOKEN_REQUIRED", "this method requires an authenticated caller")
+	ErrPermissionDenied = errs.New(errs.PermissionDenied, "PERMISSION_DENIED", "caller is not allowed to call this method")
+	ErrNotOwner         = errs.New(errs.PermissionDenied, "NOT_RESOURCE_OWNER", "resource belongs to another user")
+)
+
+// RequireUser returns the claims of the authenticated caller if it is the
+// user userID or an admin. Handlers call it for requests naming the user
+// whose data they touch, which the caller could otherwise set to anyone.
+func RequireUser(ctx context.Context, userID string) (*Claims, error) {
+	claims, ok := FromContext(ctx)
+	if !ok {
+		return nil, ErrTokenRequired
+	}
+	if claims.Role != RoleAdmin && claims.UserID != userID {
+		return nil, ErrNotOwner.WithResource("user", userID)
+	}
+	return claims, nil
+}
+
+// Rule restricts a method to callers whose token has one of Roles, or, if
+// Peer is set, to callers Peer accepts without a token, such as a trusted
+// workload identified by mTLS.
+type Rule struct {
+	Roles []string
+	Peer  func(ctx context.Context) bool
+}
+
+// Policy maps full method names, e.g. "/promotion.PromotionService/CreatePromotion",
+// to the rule guarding them. Methods not listed are open to every caller.
+type Policy map[string]Rule
+
+// UnaryServerInterceptor enforces p. It must run inside the interceptor
+// that puts the caller's claims in the context.
+func (p Policy) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := p.authorize(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor is the streaming counterpart of
+// UnaryServerInterceptor.
+func (p Policy) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := p.authorize(ss.Context(), info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+func (p Policy) authorize(ctx context.Context, fullMethod string) error {
+	rule, ok := p[fullMethod]
+	if !ok {
+		return nil
+	}
+	if claims, ok := FromContext(ctx); ok {
+		for _, role := range rule.Roles {
+			if claims.Role == role {
+				return nil
+			}
+		}
+		return ErrPermissionDenied.WithMetadata("method", fullMethod)
+	}
+	if rule.Peer != nil && rule.Peer(ctx) {
+		return nil
+	}
+	return ErrTokenRequired.WithMetadata("method", fullMethod)
+}
@@ -0,0 +1,69 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc"
+)
+
+func TestPolicy(t *testing.T) {
+	type peerKey struct{}
+	policy := Policy{
+		"/svc/Admin": {Roles: []string{RoleAdmin}},
+		"/svc/Peer": {
+			Roles: []string{RoleAdmin},
+			Peer:  func(ctx context.Context) bool { return ctx.Value(peerKey{}) != nil },
+		},
+	}
+	admin := NewContext(context.Background(), &Claims{UserID: "a", Role: RoleAdmin})
+	user := NewContext(context.Background(), &Claims{UserID: "u", Role: "user"})
+	anonymous := context.Background()
+	trusted := context.WithValue(anonymous, peerKey{}, true)
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		method  string
+		wantErr error
+	}{
+		{"open method", anonymous, "/svc/Open", nil},
+		{"admin", admin, "/svc/Admin", nil},
+		{"wrong role", user, "/svc/Admin", ErrPermissionDenied},
+		{"anonymous", anonymous, "/svc/Admin", ErrTokenRequired},
+		{"trusted peer", trusted, "/svc/Peer", nil},
+		{"untrusted peer", anonymous, "/svc/Peer", ErrTokenRequired},
+		{"peer rule still checks tokens", NewContext(trusted, &Claims{Role: "user"}), "/svc/Peer", ErrPermissionDenied},
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := policy.UnaryServerInterceptor()(tt.ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+			if tt.wantErr == nil && err != nil || tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRequireUser(t *testing.T) {
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		wantErr error
+	}{
+		{"owner", NewContext(context.Background(), &Claims{UserID: "u1", Role: "user"}), nil},
+		{"admin", NewContext(context.Background(), &Claims{UserID: "a", Role: RoleAdmin}), nil},
+		{"other user", NewContext(context.Background(), &Claims{UserID: "u2", Role: "user"}), ErrNotOwner},
+		{"anonymous", context.Background(), ErrTokenRequired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := RequireUser(tt.ctx, "u1")
+			if tt.wantErr == nil && err != nil || tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("RequireUser() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,246 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"go.uber.org/zap"
+)
+
+// HealthConfig controls how often the pool is checked and when it is
+// considered unhealthy.
+type HealthConfig struct {
+	Interval time.Duration // time between checks, defaults to 10s
+	Timeout  time.Duration // per-check ping timeout, defaults to 2s
+
+	// MaxReplicaLag marks a replica unhealthy once replay falls further
+	// behind than this. Zero disables the lag threshold.
+	MaxReplicaLag time.Duration
+}
+
+// HealthStatus is the result of a single health check.
+type HealthStatus struct {
+	Healthy    bool
+	Err        error
+	Stats      sql.DBStats
+	IsReplica  bool
+	ReplicaLag time.Duration
+	Latency    time.Duration
+	CheckedAt  time.Time
+}
+
+// Saturated reports whether every connection the pool may open is in use.
+func (s HealthStatus) Saturated() bool {
+	return s.Stats.MaxOpenConnections > 0 && s.Stats.InUse >= s.Stats.MaxOpenConnections
+}
+
+// HealthChecker periodically pings the database, samples pool statistics
+// and replica lag, and notifies listeners when the health state changes.
+// It also implements prometheus.Collector.
+type HealthChecker struct {
+	db     *sql.DB
+	cfg    HealthConfig
+	dbName string
+
+	mu        sync.RWMutex
+	status    HealthStatus
+	listeners []func(HealthStatus)
+
+	started  bool
+	stopOnce sync.Once
+	stop     chan struct{}
+	done     chan struct{}
+
+	statsCollector prometheus.Collector
+	upDesc         *prometheus.Desc
+	replicaLagDesc *prometheus.Desc
+	latencyDesc    *prometheus.Desc
+	saturatedDesc  *prometheus.Desc
+}
+
+func NewHealthChecker(db *sql.DB, dbName string, cfg HealthConfig) *HealthChecker {
+	if cfg.Interval <= 0 {
+		cfg.Interval = 10 * time.Second
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 2 * time.Second
+	}
+
+	labels := prometheus.Labels{"db_name": dbName}
+	return &HealthChecker{
+		db:             db,
+		cfg:            cfg,
+		dbName:         dbName,
+		stop:           make(chan struct{}),
+		done:           make(chan struct{}),
+		statsCollector: collectors.NewDBStatsCollector(db, dbName),
+		upDesc: prometheus.NewDesc(
+			"go_sql_up", "Whether the last database health check succeeded.", nil, labels),
+		replicaLagDesc: prometheus.NewDesc(
+			"go_sql_replica_lag_seconds", "Replication replay lag observed on the last health check.", nil, labels),
+		latencyDesc: prometheus.NewDesc(
+			"go_sql_ping_duration_seconds", "Duration of the last health check ping.", nil, labels),
+		saturatedDesc: prometheus.NewDesc(
+			"go_sql_pool_saturated", "Whether all connections allowed by the pool were in use on the last check.", nil, labels),
+	}
+}
+
+// OnChange registers fn to be called whenever the healthy state flips.
+// fn is also called once with the current status on registration.
+func (h *HealthChecker) OnChange(fn func(HealthStatus)) {
+	h.mu.Lock()
+	h.listeners = append(h.listeners, fn)
+	status := h.status
+	h.mu.Unlock()
+
+	fn(status)
+}
+
+// Status returns the result of the most recent check.
+func (h *HealthChecker) Status() HealthStatus {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.status
+}
+
+// Start runs an initial check synchronously and then keeps checking in the
+// background until Stop is called.
+func (h *HealthChecker) Start() {
+	h.mu.Lock()
+	h.started = true
+	h.mu.Unlock()
+
+	h.check()
+
+	go func() {
+		defer close(h.done)
+
+		ticker := time.NewTicker(h.cfg.Interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				h.check()
+			case <-h.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop halts background checks and waits for the running check to finish.
+// It may be called more than once, and does nothing if Start never was.
+func (h *HealthChecker) Stop() {
+	h.stopOnce.Do(func() { close(h.stop) })
+
+	h.mu.RLock()
+	started := h.started
+	h.mu.RUnlock()
+	if started {
+		<-h.done
+	}
+}
+
+func (h *HealthChecker) check() {
+	ctx, cancel := context.WithTimeout(context.Background(), h.cfg.Timeout)
+	defer cancel()
+
+	status := HealthStatus{CheckedAt: time.Now()}
+
+	start := time.Now()
+	err := h.db.PingContext(ctx)
+	status.Latency = time.Since(start)
+
+	if err == nil {
+		err = h.checkReplica(ctx, &status)
+	}
+	status.Err = err
+	status.Healthy = err == nil
+	status.Stats = h.db.Stats()
+
+	h.mu.Lock()
+	prev := h.status
+	h.status = status
+	listeners := append([]func(HealthStatus){}, h.listeners...)
+	h.mu.Unlock()
+
+	if status.Saturated() {
+		zap.L().Warn("Database pool saturated",
+			zap.String("db", h.dbName),
+			zap.Int("in_use", status.Stats.InUse),
+			zap.Int("max_open", status.Stats.MaxOpenConnections),
+			zap.Int64("wait_count", status.Stats.WaitCount),
+			zap.Duration("wait_duration", status.Stats.WaitDuration),
+		)
+	}
+
+	if prev.CheckedAt.IsZero() || prev.Healthy != status.Healthy {
+		if !status.Healthy {
+			zap.L().Error("Database unhealthy", zap.String("db", h.dbName), zap.Error(status.Err))
+		}
+		for _, fn := range listeners {
+			fn(status)
+		}
+	}
+}
+
+func (h *HealthChecker) checkReplica(ctx context.Context, status *HealthStatus) error {
+	var lagSeconds float64
+	err := h.db.QueryRowContext(ctx, `
+		SELECT pg_is_in_recovery(),
+			COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0)
+	`).Scan(&status.IsReplica, &lagSeconds)
+	if err != nil {
+		return err
+	}
+
+	if !status.IsReplica {
+		return nil
+	}
+
+	status.ReplicaLag = time.Duration(lagSeconds * float64(time.Second))
+	if h.cfg.MaxReplicaLag > 0 && status.ReplicaLag > h.cfg.MaxReplicaLag {
+		return &ReplicaLagError{Lag: status.ReplicaLag, Max: h.cfg.MaxReplicaLag}
+	}
+	return nil
+}
+
+// ReplicaLagError is reported when a replica falls too far behind its primary.
+type ReplicaLagError struct {
+	Lag time.Duration
+	Max time.Duration
+}
+
+func (e *ReplicaLagError) Error() string {
+	return "replica lag " + e.Lag.String() + " exceeds " + e.Max.String()
+}
+
+func (h *HealthChecker) Describe(ch chan<- *prometheus.Desc) {
+	h.statsCollector.Describe(ch)
+	ch <- h.upDesc
+	ch <- h.replicaLagDesc
+	ch <- h.latencyDesc
+	ch <- h.saturatedDesc
+}
+
+func (h *HealthChecker) Collect(ch chan<- prometheus.Metric) {
+	h.statsCollector.Collect(ch)
+
+	status := h.Status()
+	ch <- prometheus.MustNewConstMetric(h.upDesc, prometheus.GaugeValue, boolToFloat(status.Healthy))
+	ch <- prometheus.MustNewConstMetric(h.replicaLagDesc, prometheus.GaugeValue, status.ReplicaLag.Seconds())
+	ch <- prometheus.MustNewConstMetric(h.latencyDesc, prometheus.GaugeValue, status.Latency.Seconds())
+	ch <- prometheus.MustNewConstMetric(h.saturatedDesc, prometheus.GaugeValue, boolToFloat(status.Saturated()))
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -4,8 +4,9 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
-	"log"
 	"time"
+
+	"go.uber.org/zap"
 )
 
 type Config struct {
@@ -44,6 +45,6 @@ func NewPostgresConnection(cfg Config) (*sql.DB, error) {
 		return nil, err
 	}
 
-	log.Println("Connected to Postgres database")
+	zap.L().Info("Connected to Postgres database", zap.String("db", cfg.DBName))
 	return db, nil
 }
@@ -0,0 +1,31 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Timestamp lets a timestamp column be scanned into a protobuf timestamp
+// field, e.g. rows.Scan(&o.Id, database.Timestamp(&o.CreatedAt)).
+func Timestamp(ts **timestamppb.Timestamp) sql.Scanner {
+	return timestampScanner{ts: ts}
+}
+
+type timestampScanner struct {
+	ts **timestamppb.Timestamp
+}
+
+func (s timestampScanner) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case time.Time:
+		*s.ts = timestamppb.New(v)
+	case nil:
+		*s.ts = nil
+	default:
+		return fmt.Errorf("cannot scan %T into timestamp", src)
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package errs
+
+import (
+	"errors"
+	"strings"
+)
+
+// Kind classifies a domain error. Each kind maps to exactly one gRPC code.
+type Kind int
+
+const (
+	Internal Kind = iota
+	InvalidArgument
+	NotFound
+	AlreadyExists
+	FailedPrecondition
+	Unauthenticated
+	PermissionDenied
+	Unavailable
+)
+
+func (k Kind) String() string {
+	switch k {
+	case InvalidArgument:
+		return "invalid_argument"
+	case NotFound:
+		return "not_found"
+	case AlreadyExists:
+		return "already_exists"
+	case FailedPrecondition:
+		return "failed_precondition"
+	case Unauthenticated:
+		return "unauthenticated"
+	case PermissionDenied:
+		return "permission_denied"
+	case Unavailable:
+		return "unavailable"
+	default:
+		return "internal"
+	}
+}
+
+// FieldViolation describes a single invalid field in a request.
+type FieldViolation struct {
+	Field       string
+	Description string
+}
+
+// Resource identifies the entity an error refers to.
+type Resource struct {
+	Type string
+	Name string
+}
+
+// Error is the domain error type shared by all services. Message and the
+// details are safe to return to clients; the wrapped cause is not and is
+// only ever logged.
+type Error struct {
+	Kind     Kind
+	Reason   string // stable, UPPER_SNAKE_CASE machine-readable cause
+	Message  string
+	Resource *Resource
+	Fields   []FieldViolation
+	Metadata map[string]string
+	Err      error
+}
+
+func New(kind Kind, reason, message string) *Error {
+	return &Error{Kind: kind, Reason: reason, Message: message}
+}
+
+// Wrap returns a domain error with cause err attached.
+func Wrap(err error, kind Kind, reason, message string) *Error {
+	return &Error{Kind: kind, Reason: reason, Message: message, Err: err}
+}
+
+func (e *Error) Error() string {
+	var b strings.Builder
+	b.WriteString(e.Message)
+	if e.Resource != nil {
+		b.WriteString(" (" + e.Resource.Type + " " + e.Resource.Name + ")")
+	}
+	if e.Err != nil {
+		b.WriteString(": " + e.Err.Error())
+	}
+	return b.String()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is a domain error with the same kind and
+// reason, so errors.Is works against sentinel values even after they have
+// been copied with WithResource or wrapped with fmt.Errorf.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	if !ok {
+		return false
+	}
+	return e.Kind == t.Kind && e.Reason == t.Reason
+}
+
+// WithResource returns a copy of e that refers to the named resource.
+func (e *Error) WithResource(typ, name string) *Error {
+	c := *e
+	c.Resource = &Resource{Type: typ, Name: name}
+	return &c
+}
+
+// WithField returns a copy of e with an additional field violation.
+func (e *Error) WithField(field, description string) *Error {
+	c := *e
+	c.Fields = append(append([]FieldViolation{}, e.Fields...), FieldViolation{Field: field, Description: description})
+	return &c
+}
+
+// WithMetadata returns a copy of e with key set in its metadata.
+func (e *Error) WithMetadata(key, value string) *Error {
+	c := *e
+	c.Metadata = make(map[string]string, len(e.Metadata)+1)
+	for k, v := range e.Metadata {
+		c.Metadata[k] = v
+	}
+	c.Metadata[key] = value
+	return &c
+}
+
+// KindOf returns the kind of the first domain error in err's chain, or
+// Internal if there is none.
+func KindOf(err error) Kind {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Kind
+	}
+	return Internal
+}
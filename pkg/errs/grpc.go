@@ -0,0 +1,151 @@
+package errs
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/logger"
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// Domain is reported in ErrorInfo details.
+const Domain = "microservice-with-grpc"
+
+var kindCodes = map[Kind]codes.Code{
+	Internal:           codes.Internal,
+	InvalidArgument:    codes.InvalidArgument,
+	NotFound:           codes.NotFound,
+	AlreadyExists:      codes.AlreadyExists,
+	FailedPrecondition: codes.FailedPrecondition,
+	Unauthenticated:    codes.Unauthenticated,
+	PermissionDenied:   codes.PermissionDenied,
+	Unavailable:        codes.Unavailable,
+}
+
+// ToStatus converts err into a gRPC status. Domain errors keep their kind,
+// message and details; status errors created by handlers are passed
+// through; anything else becomes a generic Internal error so that SQL or
+// other implementation messages never reach clients.
+func ToStatus(err error) *status.Status {
+	if err == nil {
+		return nil
+	}
+
+	var e *Error
+	if errors.As(err, &e) {
+		return domainStatus(e)
+	}
+	if s, ok := err.(interface{ GRPCStatus() *status.Status }); ok {
+		return s.GRPCStatus()
+	}
+	switch {
+	case errors.Is(err, context.Canceled):
+		return status.New(codes.Canceled, "request canceled")
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.New(codes.DeadlineExceeded, "deadline exceeded")
+	}
+	return status.New(codes.Internal, "internal error")
+}
+
+func domainStatus(e *Error) *status.Status {
+	code := kindCodes[e.Kind]
+	msg := e.Message
+	if e.Kind == Internal || msg == "" {
+		msg = "internal error"
+	}
+	st := status.New(code, msg)
+
+	var details []protoadapt.MessageV1
+	if e.Reason != "" {
+		details = append(details, &errdetails.ErrorInfo{
+			Reason:   e.Reason,
+			Domain:   Domain,
+			Metadata: e.Metadata,
+		})
+	}
+	if len(e.Fields) > 0 {
+		br := &errdetails.BadRequest{}
+		for _, f := range e.Fields {
+			br.FieldViolations = append(br.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       f.Field,
+				Description: f.Description,
+			})
+		}
+		details = append(details, br)
+	}
+	if e.Resource != nil {
+		details = append(details, &errdetails.ResourceInfo{
+			ResourceType: e.Resource.Type,
+			ResourceName: e.Resource.Name,
+			Description:  e.Message,
+		})
+	}
+	if len(details) == 0 {
+		return st
+	}
+
+	withDetails, err := st.WithDetails(details...)
+	if err != nil {
+		return st
+	}
+	return withDetails
+}
+
+// FromRemote converts an error returned by a downstream gRPC call into a
+// domain error, so callers can propagate it without leaking the
+// downstream's internal messages.
+func FromRemote(err error, service string) *Error {
+	st, _ := status.FromError(err)
+	switch st.Code() {
+	case codes.NotFound:
+		return Wrap(err, NotFound, "", st.Message())
+	case codes.InvalidArgument:
+		return Wrap(err, InvalidArgument, "", st.Message())
+	case codes.FailedPrecondition:
+		return Wrap(err, FailedPrecondition, "", st.Message())
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return Wrap(err, Unavailable, "DEPENDENCY_UNAVAILABLE", service+" is unavailable").
+			WithMetadata("service", service)
+	default:
+		return Wrap(err, Internal, "", "")
+	}
+}
+
+// UnaryServerInterceptor maps every error returned by a handler to a gRPC
+// status via ToStatus, logging the original cause of sanitized errors.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		if err == nil {
+			return resp, nil
+		}
+
+		st := ToStatus(err)
+		if st.Code() == codes.Internal {
+			logger.FromContext(ctx).Error("Internal error", zap.Error(err))
+		}
+		return resp, st.Err()
+	}
+}
+
+// StreamServerInterceptor is the streaming counterpart of
+// UnaryServerInterceptor.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		err := handler(srv, ss)
+		if err == nil {
+			return nil
+		}
+
+		st := ToStatus(err)
+		if st.Code() == codes.Internal {
+			logger.FromContext(ss.Context()).Error("Internal error", zap.Error(err))
+		}
+		return st.Err()
+	}
+}
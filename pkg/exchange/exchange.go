@@ -0,0 +1,59 @@
+package exchange
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/errs"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/money"
+)
+
+var ErrRateUnavailable = errs.New(errs.FailedPrecondition, "EXCHANGE_RATE_UNAVAILABLE", "no exchange rate for currency pair")
+
+// Rate is the price of one unit of From in To, with where and when it was
+// quoted so that orders can record the rate they were priced at.
+type Rate struct {
+	From   string
+	To     string
+	Value  *big.Rat
+	AsOf   time.Time
+	Source string
+}
+
+// Decimal formats the rate value for storage and APIs.
+func (r Rate) Decimal() string {
+	return r.Value.FloatString(12)
+}
+
+// Provider supplies exchange rates.
+type Provider interface {
+	// Rate returns the current rate from one currency to another, or
+	// ErrRateUnavailable if the pair is not supported.
+	Rate(ctx context.Context, from, to string) (Rate, error)
+}
+
+// Convert converts a into currency to using p. The rate is nil when no
+// conversion was needed.
+func Convert(ctx context.Context, p Provider, a money.Amount, to string) (money.Amount, *Rate, error) {
+	if a.Currency == to {
+		return a, nil, nil
+	}
+	rate, err := p.Rate(ctx, a.Currency, to)
+	if err != nil {
+		return money.Amount{}, nil, err
+	}
+	converted, err := a.Convert(to, rate.Value)
+	if err != nil {
+		return money.Amount{}, nil, err
+	}
+	return converted, &rate, nil
+}
+
+// None supports no conversions. It is used when no rate source is
+// configured, so orders can only be priced in the products' own currency.
+type None struct{}
+
+func (None) Rate(_ context.Context, from, to string) (Rate, error) {
+	return Rate{}, ErrRateUnavailable.WithMetadata("pair", from+"/"+to)
+}
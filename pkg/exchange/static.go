@@ -0,0 +1,75 @@
+package exchange
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+)
+
+// Static serves rates from a fixed table. It suits tests and deployments
+// that publish a rate file, e.g. daily from a treasury system.
+type Static struct {
+	rates  map[string]*big.Rat // "FROM/TO" -> rate
+	asOf   time.Time
+	source string
+}
+
+// staticFile is the format read by LoadFile:
+//
+//	{"as_of": "2026-01-02T00:00:00Z", "rates": {"USD/EUR": "0.9213", "USD/JPY": "151.2"}}
+//
+// Inverse pairs are derived when only one direction is listed.
+type staticFile struct {
+	AsOf  time.Time         `json:"as_of"`
+	Rates map[string]string `json:"rates"`
+}
+
+// NewStatic builds a table from "FROM/TO" keys and decimal rates.
+func NewStatic(rates map[string]string, asOf time.Time, source string) (*Static, error) {
+	s := &Static{rates: make(map[string]*big.Rat, len(rates)), asOf: asOf, source: source}
+	for pair, value := range rates {
+		from, to, ok := strings.Cut(pair, "/")
+		if !ok || len(from) != 3 || len(to) != 3 {
+			return nil, fmt.Errorf("invalid currency pair %q", pair)
+		}
+		r, ok := new(big.Rat).SetString(value)
+		if !ok || r.Sign() <= 0 {
+			return nil, fmt.Errorf("invalid rate %q for %s", value, pair)
+		}
+		// Keep only the precision rates are recorded with on orders.
+		s.rates[from+"/"+to], _ = new(big.Rat).SetString(r.FloatString(12))
+	}
+	return s, nil
+}
+
+// LoadFile reads a rate table written in the staticFile format.
+func LoadFile(path string) (*Static, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read exchange rates: %w", err)
+	}
+	var f staticFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("failed to parse exchange rates %s: %w", path, err)
+	}
+	return NewStatic(f.Rates, f.AsOf, "file:"+path)
+}
+
+func (s *Static) Rate(_ context.Context, from, to string) (Rate, error) {
+	rate := Rate{From: from, To: to, AsOf: s.asOf, Source: s.source}
+	if r, ok := s.rates[from+"/"+to]; ok {
+		rate.Value = r
+		return rate, nil
+	}
+	if r, ok := s.rates[to+"/"+from]; ok {
+		// Round the inverse to the precision rates are recorded with, so
+		// the recorded rate reproduces the conversion exactly.
+		rate.Value, _ = new(big.Rat).SetString(new(big.Rat).Inv(r).FloatString(12))
+		return rate, nil
+	}
+	return Rate{}, ErrRateUnavailable.WithMetadata("pair", from+"/"+to)
+}
@@ -0,0 +1,87 @@
+package grpcclient
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sony/gobreaker"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var breakerState = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "grpc_client_circuit_breaker_state",
+	Help: "Circuit breaker state per downstream: 0 closed, 1 half-open, 2 open.",
+}, []string{"name"})
+
+// BreakerConfig controls when a downstream is considered failing.
+type BreakerConfig struct {
+	// ConsecutiveFailures opens the breaker, defaults to 5.
+	ConsecutiveFailures uint32
+	// OpenTimeout is how long the breaker stays open before letting a
+	// probe through, defaults to 30s.
+	OpenTimeout time.Duration
+	// HalfOpenRequests is the number of probes allowed while half-open,
+	// defaults to 1.
+	HalfOpenRequests uint32
+}
+
+// NewBreaker returns a circuit breaker named after the downstream it guards.
+func NewBreaker(name string, cfg BreakerConfig) *gobreaker.TwoStepCircuitBreaker {
+	if cfg.ConsecutiveFailures == 0 {
+		cfg.ConsecutiveFailures = 5
+	}
+	if cfg.OpenTimeout <= 0 {
+		cfg.OpenTimeout = 30 * time.Second
+	}
+	if cfg.HalfOpenRequests == 0 {
+		cfg.HalfOpenRequests = 1
+	}
+
+	breakerState.WithLabelValues(name).Set(float64(gobreaker.StateClosed))
+	return gobreaker.NewTwoStepCircuitBreaker(gobreaker.Settings{
+		Name:        name,
+		MaxRequests: cfg.HalfOpenRequests,
+		Timeout:     cfg.OpenTimeout,
+		ReadyToTrip: func(counts gobreaker.Counts) bool {
+			return counts.ConsecutiveFailures >= cfg.ConsecutiveFailures
+		},
+		OnStateChange: func(name string, from, to gobreaker.State) {
+			breakerState.WithLabelValues(name).Set(float64(to))
+			zap.L().Warn("Circuit breaker state changed",
+				zap.String("name", name),
+				zap.String("from", from.String()),
+				zap.String("to", to.String()),
+			)
+		},
+	})
+}
+
+// BreakerInterceptor fails calls fast with codes.Unavailable while cb is
+// open. Only errors that indicate the downstream itself is unhealthy count
+// as failures; business errors such as NotFound do not trip the breaker.
+func BreakerInterceptor(cb *gobreaker.TwoStepCircuitBreaker) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		done, err := cb.Allow()
+		if err != nil {
+			return status.Errorf(codes.Unavailable, "%s: circuit breaker open", cb.Name())
+		}
+
+		err = invoker(ctx, method, req, reply, cc, opts...)
+		done(!isDownstreamFailure(err))
+		return err
+	}
+}
+
+func isDownstreamFailure(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Internal, codes.Unknown:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,157 @@
+package grpcclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/logger"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/metrics"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/tracing"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/keepalive"
+)
+
+// Config describes an outbound connection to another service.
+type Config struct {
+	Name    string // short name used in logs and breaker metrics, e.g. "user-service"
+	Target  string // dns:///host:port, static:///a,b or file:///path; see resolver.go
+	Service string // fully qualified gRPC service name, e.g. "user.UserService"
+
+	// DefaultTimeout bounds calls whose context carries no deadline.
+	DefaultTimeout time.Duration
+
+	// RetryMethods lists idempotent methods that may be retried on
+	// transient failures. Non-idempotent methods are never retried.
+	RetryMethods []string
+	MaxAttempts  int
+
+	Breaker BreakerConfig
+
+	// Credentials secure the transport, normally from
+	// mtls.Source.ClientCredentials. They are required so that plaintext
+	// connections are always an explicit choice.
+	Credentials credentials.TransportCredentials
+}
+
+// Dial creates a client connection with the shared defaults: tracing,
+// logging and metrics interceptors, a default per-call deadline, retries
+// for idempotent methods, a circuit breaker, keepalive pings and round-robin
+// balancing across all resolved backends. The connection is established
+// lazily on first use.
+func Dial(cfg Config, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+	if cfg.Credentials == nil {
+		return nil, fmt.Errorf("no transport credentials configured for %s client", cfg.Name)
+	}
+	if cfg.DefaultTimeout <= 0 {
+		cfg.DefaultTimeout = 5 * time.Second
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 3
+	}
+
+	sc, err := serviceConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	defaults := []grpc.DialOption{
+		grpc.WithTransportCredentials(cfg.Credentials),
+		grpc.WithDefaultServiceConfig(sc),
+		grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                30 * time.Second,
+			Timeout:             10 * time.Second,
+			PermitWithoutStream: true,
+		}),
+		tracing.DialOption(),
+		grpc.WithChainUnaryInterceptor(
+			logger.UnaryClientInterceptor(),
+			metrics.UnaryClientInterceptor(),
+			DeadlineInterceptor(cfg.DefaultTimeout),
+			BreakerInterceptor(NewBreaker(cfg.Name, cfg.Breaker)),
+		),
+	}
+
+	conn, err := grpc.NewClient(cfg.Target, append(defaults, opts...)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s client: %w", cfg.Name, err)
+	}
+	return conn, nil
+}
+
+// ServerKeepaliveOptions lets servers accept the keepalive pings sent by
+// clients created with Dial instead of closing the connection with
+// ENHANCE_YOUR_CALM.
+func ServerKeepaliveOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             15 * time.Second,
+			PermitWithoutStream: true,
+		}),
+	}
+}
+
+// DeadlineInterceptor applies timeout to calls made without a deadline.
+func DeadlineInterceptor(timeout time.Duration) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if _, ok := ctx.Deadline(); !ok {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+type methodName struct {
+	Service string `json:"service"`
+	Method  string `json:"method"`
+}
+
+type retryPolicy struct {
+	MaxAttempts          int      `json:"maxAttempts"`
+	InitialBackoff       string   `json:"initialBackoff"`
+	MaxBackoff           string   `json:"maxBackoff"`
+	BackoffMultiplier    float64  `json:"backoffMultiplier"`
+	RetryableStatusCodes []string `json:"retryableStatusCodes"`
+}
+
+type methodConfig struct {
+	Name        []methodName `json:"name"`
+	RetryPolicy *retryPolicy `json:"retryPolicy,omitempty"`
+}
+
+func serviceConfig(cfg Config) (string, error) {
+	sc := struct {
+		LoadBalancingConfig []map[string]struct{} `json:"loadBalancingConfig"`
+		MethodConfig        []methodConfig        `json:"methodConfig,omitempty"`
+	}{
+		// Spread calls over every resolved backend instead of pinning the
+		// first one, which is grpc's default.
+		LoadBalancingConfig: []map[string]struct{}{{"round_robin": {}}},
+	}
+
+	if len(cfg.RetryMethods) > 0 {
+		mc := methodConfig{
+			RetryPolicy: &retryPolicy{
+				MaxAttempts:          cfg.MaxAttempts,
+				InitialBackoff:       "0.1s",
+				MaxBackoff:           "1s",
+				BackoffMultiplier:    2,
+				RetryableStatusCodes: []string{"UNAVAILABLE", "RESOURCE_EXHAUSTED"},
+			},
+		}
+		for _, m := range cfg.RetryMethods {
+			mc.Name = append(mc.Name, methodName{Service: cfg.Service, Method: m})
+		}
+		sc.MethodConfig = append(sc.MethodConfig, mc)
+	}
+
+	b, err := json.Marshal(sc)
+	if err != nil {
+		return "", fmt.Errorf("failed to build service config: %w", err)
+	}
+	return string(b), nil
+}
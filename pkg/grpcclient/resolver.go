@@ -0,0 +1,178 @@
+package grpcclient
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/resolver"
+)
+
+// Besides the built-in dns:/// scheme, targets may use:
+//
+//	static:///10.0.0.1:50053,10.0.0.2:50053   fixed list of backends
+//	file:///etc/endpoints/product-service     one address per line, reloaded on change
+//
+// Combined with the round_robin policy set by Dial, every resolved address
+// receives traffic, so a service can be scaled horizontally without a proxy.
+const (
+	StaticScheme = "static"
+	FileScheme   = "file"
+)
+
+func init() {
+	resolver.Register(staticBuilder{})
+	resolver.Register(fileBuilder{})
+}
+
+type staticBuilder struct{}
+
+func (staticBuilder) Scheme() string { return StaticScheme }
+
+func (staticBuilder) Build(target resolver.Target, cc resolver.ClientConn, _ resolver.BuildOptions) (resolver.Resolver, error) {
+	addrs := parseAddresses(strings.Split(target.Endpoint(), ","))
+	if len(addrs) == 0 {
+		return nil, fmt.Errorf("static resolver: no addresses in %q", target.Endpoint())
+	}
+	if err := cc.UpdateState(resolver.State{Addresses: addrs}); err != nil {
+		return nil, err
+	}
+	return nopResolver{}, nil
+}
+
+type nopResolver struct{}
+
+func (nopResolver) ResolveNow(resolver.ResolveNowOptions) {}
+func (nopResolver) Close()                                {}
+
+type fileBuilder struct{}
+
+func (fileBuilder) Scheme() string { return FileScheme }
+
+func (fileBuilder) Build(target resolver.Target, cc resolver.ClientConn, _ resolver.BuildOptions) (resolver.Resolver, error) {
+	path := target.URL.Path
+	if path == "" {
+		return nil, fmt.Errorf("file resolver: empty path in %q", target.URL.String())
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("file resolver: %w", err)
+	}
+	// Watch the directory rather than the file so replacements made by
+	// renaming a new file into place (as Kubernetes ConfigMaps do) are seen.
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		watcher.Close()
+		return nil, fmt.Errorf("file resolver: %w", err)
+	}
+
+	r := &fileResolver{path: path, cc: cc, watcher: watcher, done: make(chan struct{})}
+	if err := r.load(); err != nil {
+		watcher.Close()
+		return nil, err
+	}
+	go r.watch()
+	return r, nil
+}
+
+type fileResolver struct {
+	path    string
+	cc      resolver.ClientConn
+	watcher *fsnotify.Watcher
+
+	mu   sync.Mutex
+	last []byte
+
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func (r *fileResolver) load() error {
+	data, err := os.ReadFile(r.path)
+	if err != nil {
+		return fmt.Errorf("file resolver: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.last != nil && bytes.Equal(data, r.last) {
+		return nil
+	}
+
+	var lines []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	addrs := parseAddresses(lines)
+	if len(addrs) == 0 {
+		return fmt.Errorf("file resolver: no addresses in %s", r.path)
+	}
+
+	r.last = data
+	zap.L().Info("Resolved backends from file", zap.String("path", r.path), zap.Int("addresses", len(addrs)))
+	return r.cc.UpdateState(resolver.State{Addresses: addrs})
+}
+
+func (r *fileResolver) watch() {
+	for {
+		select {
+		case ev, ok := <-r.watcher.Events:
+			if !ok {
+				return
+			}
+			// Any change in the directory may have replaced the file, e.g.
+			// a ConfigMap symlink swap; load ignores unchanged content.
+			if ev.Has(fsnotify.Chmod) {
+				continue
+			}
+			if err := r.load(); err != nil {
+				// Keep the previous addresses; a half-written file must not
+				// drop every backend.
+				r.cc.ReportError(err)
+			}
+		case err, ok := <-r.watcher.Errors:
+			if !ok {
+				return
+			}
+			zap.L().Warn("File resolver watch error", zap.String("path", r.path), zap.Error(err))
+		case <-r.done:
+			return
+		}
+	}
+}
+
+func (r *fileResolver) ResolveNow(resolver.ResolveNowOptions) {
+	if err := r.load(); err != nil {
+		r.cc.ReportError(err)
+	}
+}
+
+func (r *fileResolver) Close() {
+	r.closeOnce.Do(func() {
+		close(r.done)
+		r.watcher.Close()
+	})
+}
+
+// parseAddresses trims entries and drops blanks and # comments.
+func parseAddresses(entries []string) []resolver.Address {
+	var addrs []resolver.Address
+	for _, e := range entries {
+		e = strings.TrimSpace(e)
+		if i := strings.IndexByte(e, '#'); i >= 0 {
+			e = strings.TrimSpace(e[:i])
+		}
+		if e == "" {
+			continue
+		}
+		addrs = append(addrs, resolver.Address{Addr: e})
+	}
+	return addrs
+}
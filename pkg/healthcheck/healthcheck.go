@@ -0,0 +1,116 @@
+package healthcheck
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+// Checker publishes a service's health over grpc.health.v1. The service is
+// SERVING only while every registered dependency reports healthy.
+type Checker struct {
+	server   *health.Server
+	services []string
+
+	mu   sync.Mutex
+	deps map[string]bool
+}
+
+// New creates a Checker reporting on the overall server ("") and on each of
+// the given fully qualified service names.
+func New(services ...string) *Checker {
+	c := &Checker{
+		server:   health.NewServer(),
+		services: append([]string{""}, services...),
+		deps:     make(map[string]bool),
+	}
+	c.mu.Lock()
+	c.update()
+	c.mu.Unlock()
+	return c
+}
+
+// Register installs the health service and server reflection on s.
+func (c *Checker) Register(s *grpc.Server) {
+	healthpb.RegisterHealthServer(s, c.server)
+	reflection.Register(s)
+}
+
+// SetDependency records the health of a named dependency and recomputes the
+// serving status.
+func (c *Checker) SetDependency(name string, healthy bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	prev, known := c.deps[name]
+	c.deps[name] = healthy
+	if known && prev == healthy {
+		return
+	}
+	if !healthy {
+		zap.L().Warn("Dependency is unhealthy", zap.String("dependency", name))
+	} else if known {
+		zap.L().Info("Dependency recovered", zap.String("dependency", name))
+	}
+	c.update()
+}
+
+// WatchService polls the grpc.health.v1 endpoint of a downstream service on
+// conn every interval and records the result as dependency name. It stops
+// when ctx is cancelled.
+func (c *Checker) WatchService(ctx context.Context, name string, conn grpc.ClientConnInterface, interval time.Duration) {
+	client := healthpb.NewHealthClient(conn)
+
+	probe := func() {
+		probeCtx, cancel := context.WithTimeout(ctx, interval)
+		defer cancel()
+
+		resp, err := client.Check(probeCtx, &healthpb.HealthCheckRequest{})
+		c.SetDependency(name, err == nil && resp.Status == healthpb.HealthCheckResponse_SERVING)
+	}
+
+	c.SetDependency(name, false)
+	go func() {
+		probe()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				probe()
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// Shutdown marks every service NOT_SERVING and ignores further dependency
+// updates so load balancers drain traffic before the server stops.
+func (c *Checker) Shutdown() {
+	c.server.Shutdown()
+}
+
+// update must be called with c.mu held.
+func (c *Checker) update() {
+	healthy := true
+	for _, ok := range c.deps {
+		healthy = healthy && ok
+	}
+
+	status := healthpb.HealthCheckResponse_SERVING
+	if !healthy {
+		status = healthpb.HealthCheckResponse_NOT_SERVING
+	}
+	for _, svc := range c.services {
+		c.server.SetServingStatus(svc, status)
+	}
+}
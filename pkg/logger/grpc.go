@@ -0,0 +1,136 @@
+package logger
+
+import (
+	"context"
+	"path"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// UserIDHeader is the metadata key callers use to identify the end user a
+// request is made on behalf of.
+const UserIDHeader = "x-user-id"
+
+// UnaryServerInterceptor stores a request-scoped logger in the context and
+// logs every call with its method, duration, status code, peer and user ID.
+// Request payloads are only logged at debug level, with secrets redacted.
+func UnaryServerInterceptor(l *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		reqLogger := l.With(requestFields(ctx, info.FullMethod, req)...)
+		ctx = WithContext(ctx, reqLogger)
+
+		if ce := reqLogger.Check(zapcore.DebugLevel, "request received"); ce != nil {
+			if m, ok := req.(proto.Message); ok {
+				ce.Write(zap.String("request", protojson.Format(Redact(m))))
+			}
+		}
+
+		resp, err := handler(ctx, req)
+		logCompletion(reqLogger, start, err)
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor is the streaming counterpart of
+// UnaryServerInterceptor.
+func StreamServerInterceptor(l *zap.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		reqLogger := l.With(requestFields(ss.Context(), info.FullMethod, nil)...)
+
+		err := handler(srv, &loggedStream{ServerStream: ss, ctx: WithContext(ss.Context(), reqLogger)})
+		logCompletion(reqLogger, start, err)
+		return err
+	}
+}
+
+// UnaryClientInterceptor logs outbound calls using the logger carried in
+// the call context and forwards the caller's user ID downstream.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		start := time.Now()
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if ids := md.Get(UserIDHeader); len(ids) > 0 {
+				ctx = metadata.AppendToOutgoingContext(ctx, UserIDHeader, ids[0])
+			}
+		}
+
+		err := invoker(ctx, method, req, reply, cc, opts...)
+
+		FromContext(ctx).Debug("outbound call finished",
+			zap.String("grpc.target", cc.Target()),
+			zap.String("grpc.method", method),
+			zap.String("grpc.code", status.Code(err).String()),
+			zap.Duration("grpc.duration", time.Since(start)),
+			zap.Error(err),
+		)
+		return err
+	}
+}
+
+type loggedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *loggedStream) Context() context.Context {
+	return s.ctx
+}
+
+func requestFields(ctx context.Context, fullMethod string, req interface{}) []zap.Field {
+	fields := []zap.Field{
+		zap.String("grpc.service", path.Dir(fullMethod)[1:]),
+		zap.String("grpc.method", path.Base(fullMethod)),
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		fields = append(fields, zap.String("peer.address", p.Addr.String()))
+	}
+	if userID := userIDFromRequest(ctx, req); userID != "" {
+		fields = append(fields, zap.String("user.id", userID))
+	}
+	return fields
+}
+
+// userIDFromRequest prefers the x-user-id metadata and falls back to a
+// user_id field on the request message, as on CreateOrderRequest.
+func userIDFromRequest(ctx context.Context, req interface{}) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ids := md.Get(UserIDHeader); len(ids) > 0 {
+			return ids[0]
+		}
+	}
+	if r, ok := req.(interface{ GetUserId() string }); ok {
+		return r.GetUserId()
+	}
+	return ""
+}
+
+func logCompletion(l *zap.Logger, start time.Time, err error) {
+	code := status.Code(err)
+	fields := []zap.Field{
+		zap.String("grpc.code", code.String()),
+		zap.Duration("grpc.duration", time.Since(start)),
+	}
+	if err != nil {
+		fields = append(fields, zap.Error(err))
+	}
+
+	switch code {
+	case codes.OK, codes.NotFound, codes.AlreadyExists, codes.InvalidArgument, codes.Unauthenticated, codes.PermissionDenied, codes.Canceled:
+		l.Info("finished call", fields...)
+	case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unimplemented:
+		l.Error("finished call", fields...)
+	default:
+		l.Warn("finished call", fields...)
+	}
+}
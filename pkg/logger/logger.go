@@ -0,0 +1,92 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+type Config struct {
+	Level   string // debug, info, warn, error
+	Format  string // json or console
+	Service string
+}
+
+// ConfigFromEnv reads LOG_LEVEL and LOG_FORMAT, defaulting to info level
+// and JSON output.
+func ConfigFromEnv(service string) Config {
+	return Config{
+		Level:   getEnv("LOG_LEVEL", "info"),
+		Format:  getEnv("LOG_FORMAT", "json"),
+		Service: service,
+	}
+}
+
+func New(cfg Config) (*zap.Logger, error) {
+	level, err := zapcore.ParseLevel(cfg.Level)
+	if err != nil {
+		return nil, fmt.Errorf("invalid log level %q: %w", cfg.Level, err)
+	}
+
+	var zc zap.Config
+	switch strings.ToLower(cfg.Format) {
+	case "json", "":
+		zc = zap.NewProductionConfig()
+		zc.EncoderConfig.TimeKey = "time"
+		zc.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+	case "console":
+		zc = zap.NewDevelopmentConfig()
+	default:
+		return nil, fmt.Errorf("invalid log format %q", cfg.Format)
+	}
+	zc.Level = zap.NewAtomicLevelAt(level)
+
+	l, err := zc.Build()
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Service != "" {
+		l = l.With(zap.String("service", cfg.Service))
+	}
+	return l, nil
+}
+
+// Init builds a logger from the environment and installs it as the zap
+// global so packages without an injected logger can use zap.L(). It exits
+// the process if the configuration is invalid.
+func Init(service string) *zap.Logger {
+	l, err := New(ConfigFromEnv(service))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to initialize logger: %v\n", err)
+		os.Exit(1)
+	}
+	zap.ReplaceGlobals(l)
+	return l
+}
+
+type ctxKey struct{}
+
+// WithContext returns a copy of ctx carrying l.
+func WithContext(ctx context.Context, l *zap.Logger) context.Context {
+	return context.WithValue(ctx, ctxKey{}, l)
+}
+
+// FromContext returns the request-scoped logger stored in ctx, or the
+// global logger if there is none.
+func FromContext(ctx context.Context) *zap.Logger {
+	if l, ok := ctx.Value(ctxKey{}).(*zap.Logger); ok {
+		return l
+	}
+	return zap.L()
+}
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
@@ -0,0 +1,52 @@
+package logger
+
+import (
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const redacted = "[REDACTED]"
+
+// sensitiveFields lists field names whose values must never be logged,
+// e.g. CreateUserRequest.password and AuthRequest.password.
+var sensitiveFields = []string{"password", "token", "secret"}
+
+// Redact returns a copy of m with every sensitive string field, at any
+// depth, replaced by a placeholder. m itself is not modified.
+func Redact(m proto.Message) proto.Message {
+	if m == nil {
+		return nil
+	}
+	c := proto.Clone(m)
+	redactMessage(c.ProtoReflect())
+	return c
+}
+
+func redactMessage(m protoreflect.Message) {
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.Kind() == protoreflect.StringKind && !fd.IsList() && !fd.IsMap() && isSensitive(fd.Name()):
+			m.Set(fd, protoreflect.ValueOfString(redacted))
+		case fd.Kind() == protoreflect.MessageKind && fd.IsList():
+			list := v.List()
+			for i := 0; i < list.Len(); i++ {
+				redactMessage(list.Get(i).Message())
+			}
+		case fd.Kind() == protoreflect.MessageKind && !fd.IsMap():
+			redactMessage(v.Message())
+		}
+		return true
+	})
+}
+
+func isSensitive(name protoreflect.Name) bool {
+	n := strings.ToLower(string(name))
+	for _, s := range sensitiveFields {
+		if strings.Contains(n, s) {
+			return true
+		}
+	}
+	return false
+}
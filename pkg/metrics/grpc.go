@@ -0,0 +1,90 @@
+package metrics
+
+import (
+	"context"
+	"path"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	serverStarted = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "grpc_server_started_total",
+		Help: "Total number of RPCs started on the server.",
+	}, []string{"grpc_service", "grpc_method"})
+
+	serverHandled = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "grpc_server_handled_total",
+		Help: "Total number of RPCs completed on the server, by status code.",
+	}, []string{"grpc_service", "grpc_method", "grpc_code"})
+
+	serverDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "grpc_server_handling_seconds",
+		Help:    "Time taken by the server to handle RPCs.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"grpc_service", "grpc_method"})
+
+	clientHandled = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "grpc_client_handled_total",
+		Help: "Total number of RPCs completed by the client, by status code.",
+	}, []string{"grpc_service", "grpc_method", "grpc_code"})
+
+	clientDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "grpc_client_handling_seconds",
+		Help:    "Time taken by outbound RPCs until the response is received.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"grpc_service", "grpc_method"})
+)
+
+// UnaryServerInterceptor records rate, errors and duration for every RPC.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		service, method := splitMethod(info.FullMethod)
+		serverStarted.WithLabelValues(service, method).Inc()
+
+		start := time.Now()
+		resp, err := handler(ctx, req)
+
+		serverDuration.WithLabelValues(service, method).Observe(time.Since(start).Seconds())
+		serverHandled.WithLabelValues(service, method, status.Code(err).String()).Inc()
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor is the streaming counterpart of
+// UnaryServerInterceptor.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		service, method := splitMethod(info.FullMethod)
+		serverStarted.WithLabelValues(service, method).Inc()
+
+		start := time.Now()
+		err := handler(srv, ss)
+
+		serverDuration.WithLabelValues(service, method).Observe(time.Since(start).Seconds())
+		serverHandled.WithLabelValues(service, method, status.Code(err).String()).Inc()
+		return err
+	}
+}
+
+// UnaryClientInterceptor records rate, errors and duration for outbound RPCs.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, fullMethod string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		service, method := splitMethod(fullMethod)
+
+		start := time.Now()
+		err := invoker(ctx, fullMethod, req, reply, cc, opts...)
+
+		clientDuration.WithLabelValues(service, method).Observe(time.Since(start).Seconds())
+		clientHandled.WithLabelValues(service, method, status.Code(err).String()).Inc()
+		return err
+	}
+}
+
+func splitMethod(fullMethod string) (string, string) {
+	return path.Dir(fullMethod)[1:], path.Base(fullMethod)
+}
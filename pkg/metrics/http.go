@@ -0,0 +1,43 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/felixge/httpsnoop"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	httpRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "Total number of HTTP requests handled by the gateway, by route and status code.",
+	}, []string{"route", "method", "code"})
+
+	httpDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "Time taken by the gateway to serve HTTP requests.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"route", "method"})
+)
+
+// GatewayMiddleware records per-route HTTP metrics. It must be installed
+// with runtime.WithMiddlewares so the matched route pattern is known,
+// keeping label cardinality bounded by the number of routes.
+func GatewayMiddleware(next runtime.HandlerFunc) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		route := "unknown"
+		if p, ok := runtime.HTTPPattern(r.Context()); ok {
+			route = p.String()
+		}
+
+		m := httpsnoop.CaptureMetricsFn(w, func(w http.ResponseWriter) {
+			next(w, r, pathParams)
+		})
+
+		httpRequests.WithLabelValues(route, r.Method, strconv.Itoa(m.Code)).Inc()
+		httpDuration.WithLabelValues(route, r.Method).Observe(m.Duration.Seconds())
+	}
+}
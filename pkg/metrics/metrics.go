@@ -0,0 +1,27 @@
+package metrics
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.uber.org/zap"
+)
+
+// Serve exposes the default Prometheus registry at /metrics on a separate
+// admin listener so scrapes never compete with API traffic. The server runs
+// in the background; call Shutdown on the result when exiting.
+func Serve(addr string) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+
+	srv := &http.Server{Addr: addr, Handler: mux}
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			zap.L().Error("Metrics server failed", zap.String("addr", addr), zap.Error(err))
+		}
+	}()
+
+	zap.L().Info("Metrics server starting", zap.String("addr", addr))
+	return srv
+}
@@ -0,0 +1,192 @@
+package money
+
+import (
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/errs"
+	moneypb "github.com/dipendra-mule/microservice-with-grpc/proto/money"
+)
+
+// DefaultCurrency is assumed for amounts that only arrive through the
+// deprecated float price fields.
+const DefaultCurrency = "USD"
+
+var (
+	ErrCurrencyMismatch = errs.New(errs.FailedPrecondition, "CURRENCY_MISMATCH", "amounts are in different currencies")
+	ErrInvalidAmount    = errs.New(errs.InvalidArgument, "INVALID_AMOUNT", "invalid monetary amount")
+	ErrOverflow         = errs.New(errs.InvalidArgument, "AMOUNT_OUT_OF_RANGE", "monetary amount out of range")
+)
+
+// exponents lists currencies whose minor unit is not 1/100. All other
+// currencies use two decimal places.
+var exponents = map[string]int{
+	"BHD": 3, "CLP": 0, "IQD": 3, "ISK": 0, "JOD": 3, "JPY": 0, "KRW": 0,
+	"KWD": 3, "LYD": 3, "OMR": 3, "TND": 3, "UGX": 0, "VND": 0, "XAF": 0, "XOF": 0,
+}
+
+// Exponent returns the number of decimal places of currency's minor unit.
+func Exponent(currency string) int {
+	if e, ok := exponents[currency]; ok {
+		return e
+	}
+	return 2
+}
+
+// Amount is an exact amount in minor units of a currency. The zero value is
+// not a valid amount; use New, Parse or FromProto.
+type Amount struct {
+	Currency string
+	Minor    int64
+}
+
+// New returns minor units of currency.
+func New(currency string, minor int64) Amount {
+	return Amount{Currency: currency, Minor: minor}
+}
+
+// Zero returns a zero amount of currency.
+func Zero(currency string) Amount {
+	return Amount{Currency: currency}
+}
+
+// Parse reads a decimal string such as "19.99" or a NUMERIC column value
+// such as "19.9900". Digits beyond the currency's minor unit must be zero.
+func Parse(currency, s string) (Amount, error) {
+	r, ok := new(big.Rat).SetString(strings.TrimSpace(s))
+	if !ok {
+		return Amount{}, ErrInvalidAmount.WithMetadata("value", s)
+	}
+	r.Mul(r, new(big.Rat).SetInt(pow10(Exponent(currency))))
+	if !r.IsInt() {
+		return Amount{}, ErrInvalidAmount.WithMetadata("value", s)
+	}
+	if !r.Num().IsInt64() {
+		return Amount{}, ErrOverflow
+	}
+	return Amount{Currency: currency, Minor: r.Num().Int64()}, nil
+}
+
+// FromFloat converts a legacy float price, rounding to the nearest minor
+// unit. It exists only for the deprecated float fields. NaN and infinities
+// are rejected with ErrInvalidAmount and values beyond int64 minor units
+// with ErrOverflow.
+func FromFloat(currency string, f float32) (Amount, error) {
+	// Round the shortest decimal form of the float32 exactly, so that 19.99
+	// becomes 1999 rather than 1998.9999 and 1.005 rounds up to 101.
+	v, ok := new(big.Rat).SetString(strconv.FormatFloat(float64(f), 'g', -1, 32))
+	if !ok {
+		return Amount{}, ErrInvalidAmount.WithMetadata("value", strconv.FormatFloat(float64(f), 'g', -1, 32))
+	}
+	v.Mul(v, new(big.Rat).SetInt(pow10(Exponent(currency))))
+	return round(currency, v)
+}
+
+// FromProto converts m, validating its currency code.
+func FromProto(m *moneypb.Money) (Amount, error) {
+	if m == nil || len(m.CurrencyCode) != 3 || strings.ToUpper(m.CurrencyCode) != m.CurrencyCode {
+		return Amount{}, ErrInvalidAmount
+	}
+	return Amount{Currency: m.CurrencyCode, Minor: m.MinorUnits}, nil
+}
+
+// Proto returns a as a protobuf message.
+func (a Amount) Proto() *moneypb.Money {
+	return &moneypb.Money{CurrencyCode: a.Currency, MinorUnits: a.Minor}
+}
+
+// Add returns a+b. Both must be in the same currency.
+func (a Amount) Add(b Amount) (Amount, error) {
+	if a.Currency != b.Currency {
+		return Amount{}, ErrCurrencyMismatch.WithMetadata("currencies", a.Currency+","+b.Currency)
+	}
+	sum := a.Minor + b.Minor
+	if (sum > a.Minor) != (b.Minor > 0) {
+		return Amount{}, ErrOverflow
+	}
+	return Amount{Currency: a.Currency, Minor: sum}, nil
+}
+
+// Sub returns a-b. Both must be in the same currency.
+func (a Amount) Sub(b Amount) (Amount, error) {
+	if b.Minor == math.MinInt64 {
+		return Amount{}, ErrOverflow
+	}
+	return a.Add(Amount{Currency: b.Currency, Minor: -b.Minor})
+}
+
+// Mul returns a multiplied by a whole quantity.
+func (a Amount) Mul(qty int64) (Amount, error) {
+	if a.Minor == 0 || qty == 0 {
+		return Amount{Currency: a.Currency}, nil
+	}
+	p := a.Minor * qty
+	if p/qty != a.Minor || (a.Minor == -1 && qty == math.MinInt64) || (qty == -1 && a.Minor == math.MinInt64) {
+		return Amount{}, ErrOverflow
+	}
+	return Amount{Currency: a.Currency, Minor: p}, nil
+}
+
+// Convert converts a into currency to at rate, the price of one unit of
+// a's currency in to. The result is rounded half away from zero to the
+// minor unit of to.
+func (a Amount) Convert(to string, rate *big.Rat) (Amount, error) {
+	if rate == nil || rate.Sign() <= 0 {
+		return Amount{}, ErrInvalidAmount.WithMetadata("rate", "non-positive")
+	}
+	v := new(big.Rat).SetInt64(a.Minor)
+	v.Mul(v, rate)
+	v.Mul(v, new(big.Rat).SetFrac(pow10(Exponent(to)), pow10(Exponent(a.Currency))))
+	return round(to, v)
+}
+
+// MulRat returns a multiplied by a fraction such as a tax rate, rounded half
+// away from zero to the minor unit.
+func (a Amount) MulRat(f *big.Rat) (Amount, error) {
+	v := new(big.Rat).SetInt64(a.Minor)
+	return round(a.Currency, v.Mul(v, f))
+}
+
+// round rounds v minor units half away from zero.
+func round(currency string, v *big.Rat) (Amount, error) {
+	abs := new(big.Rat).Abs(v)
+	abs.Add(abs, big.NewRat(1, 2))
+	q := new(big.Int).Quo(abs.Num(), abs.Denom())
+	if v.Sign() < 0 {
+		q.Neg(q)
+	}
+	if !q.IsInt64() {
+		return Amount{}, ErrOverflow
+	}
+	return Amount{Currency: currency, Minor: q.Int64()}, nil
+}
+
+// IsNegative reports whether a is below zero.
+func (a Amount) IsNegative() bool {
+	return a.Minor < 0
+}
+
+// Decimal formats a as a plain decimal string, e.g. "19.99", suitable for
+// NUMERIC columns.
+func (a Amount) Decimal() string {
+	exp := Exponent(a.Currency)
+	r := new(big.Rat).SetFrac(big.NewInt(a.Minor), pow10(exp))
+	return r.FloatString(exp)
+}
+
+// Float returns a as a float32 for the deprecated float fields. It must
+// never be used for arithmetic.
+func (a Amount) Float() float32 {
+	return float32(float64(a.Minor) / math.Pow10(Exponent(a.Currency)))
+}
+
+// String formats a for logs and messages, e.g. "19.99 USD".
+func (a Amount) String() string {
+	return a.Decimal() + " " + a.Currency
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
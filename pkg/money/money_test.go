@@ -0,0 +1,249 @@
+package money
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+
+	moneypb "github.com/dipendra-mule/microservice-with-grpc/proto/money"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		currency string
+		in       string
+		want     int64
+		wantErr  error
+	}{
+		{"USD", "19.99", 1999, nil},
+		{"USD", "19.9900", 1999, nil},
+		{"USD", " 7 ", 700, nil},
+		{"USD", "-0.01", -1, nil},
+		{"JPY", "1500", 1500, nil},
+		{"KWD", "1.234", 1234, nil},
+		{"USD", "19.999", 0, ErrInvalidAmount},
+		{"JPY", "15.5", 0, ErrInvalidAmount},
+		{"USD", "abc", 0, ErrInvalidAmount},
+		{"USD", "100000000000000000000", 0, ErrOverflow},
+	}
+	for _, tt := range tests {
+		t.Run(tt.currency+" "+tt.in, func(t *testing.T) {
+			got, err := Parse(tt.currency, tt.in)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Parse() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != New(tt.currency, tt.want) {
+				t.Errorf("Parse() = %v, want %d minor units", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromFloat(t *testing.T) {
+	tests := []struct {
+		currency string
+		in       float32
+		want     int64
+		wantErr  error
+	}{
+		{"USD", 19.99, 1999, nil},
+		{"USD", 0.1, 10, nil},
+		{"USD", 1.005, 101, nil},
+		{"JPY", 1499.6, 1500, nil},
+		{"KWD", 2.345, 2345, nil},
+		{"USD", float32(math.NaN()), 0, ErrInvalidAmount},
+		{"USD", float32(math.Inf(1)), 0, ErrInvalidAmount},
+		{"USD", float32(math.Inf(-1)), 0, ErrInvalidAmount},
+		{"USD", 1e30, 0, ErrOverflow},
+		{"USD", -1e30, 0, ErrOverflow},
+	}
+	for _, tt := range tests {
+		got, err := FromFloat(tt.currency, tt.in)
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("FromFloat(%s, %v) error = %v, want %v", tt.currency, tt.in, err, tt.wantErr)
+			continue
+		}
+		if err == nil && got.Minor != tt.want {
+			t.Errorf("FromFloat(%s, %v) = %d, want %d", tt.currency, tt.in, got.Minor, tt.want)
+		}
+	}
+}
+
+func TestFromProto(t *testing.T) {
+	tests := []struct {
+		name string
+		in   *moneypb.Money
+		ok   bool
+	}{
+		{"valid", &moneypb.Money{CurrencyCode: "EUR", MinorUnits: 5}, true},
+		{"nil", nil, false},
+		{"lower case", &moneypb.Money{CurrencyCode: "eur"}, false},
+		{"too long", &moneypb.Money{CurrencyCode: "EURO"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := FromProto(tt.in)
+			if (err == nil) != tt.ok {
+				t.Errorf("FromProto() error = %v, want ok %v", err, tt.ok)
+			}
+		})
+	}
+}
+
+func TestAddSub(t *testing.T) {
+	tests := []struct {
+		name    string
+		a, b    Amount
+		add     int64
+		sub     int64
+		wantErr error
+	}{
+		{"simple", New("USD", 150), New("USD", 75), 225, 75, nil},
+		{"negative", New("USD", -10), New("USD", 25), 15, -35, nil},
+		{"currency mismatch", New("USD", 1), New("EUR", 1), 0, 0, ErrCurrencyMismatch},
+		{"overflow", New("USD", math.MaxInt64), New("USD", 1), 0, 0, ErrOverflow},
+		{"underflow", New("USD", math.MinInt64), New("USD", -1), 0, 0, ErrOverflow},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sum, err := tt.a.Add(tt.b)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("Add() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || sum.Minor != tt.add {
+				t.Errorf("Add() = %v, %v; want %d", sum, err, tt.add)
+			}
+			diff, err := tt.a.Sub(tt.b)
+			if err != nil || diff.Minor != tt.sub {
+				t.Errorf("Sub() = %v, %v; want %d", diff, err, tt.sub)
+			}
+		})
+	}
+}
+
+func TestSubMinInt64(t *testing.T) {
+	_, err := New("USD", 0).Sub(New("USD", math.MinInt64))
+	if !errors.Is(err, ErrOverflow) {
+		t.Errorf("Sub(MinInt64) error = %v, want ErrOverflow", err)
+	}
+}
+
+func TestMul(t *testing.T) {
+	tests := []struct {
+		name    string
+		a       int64
+		qty     int64
+		want    int64
+		wantErr bool
+	}{
+		{"simple", 1999, 3, 5997, false},
+		{"zero amount", 0, math.MaxInt64, 0, false},
+		{"zero quantity", math.MaxInt64, 0, 0, false},
+		{"negative", -250, 4, -1000, false},
+		{"overflow", math.MaxInt64 / 2, 3, 0, true},
+		{"min times minus one", math.MinInt64, -1, 0, true},
+		{"minus one times min", -1, math.MinInt64, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := New("USD", tt.a).Mul(tt.qty)
+			if tt.wantErr {
+				if !errors.Is(err, ErrOverflow) {
+					t.Errorf("Mul() error = %v, want ErrOverflow", err)
+				}
+				return
+			}
+			if err != nil || got.Minor != tt.want {
+				t.Errorf("Mul() = %v, %v; want %d", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestMulRatRoundsHalfAwayFromZero(t *testing.T) {
+	tests := []struct {
+		minor int64
+		f     *big.Rat
+		want  int64
+	}{
+		{1000, big.NewRat(725, 10000), 73},   // 72.5
+		{-1000, big.NewRat(725, 10000), -73}, // -72.5
+		{1000, big.NewRat(724, 10000), 72},   // 72.4
+		{999, big.NewRat(1, 3), 333},         // 333
+		{1000, big.NewRat(1, 3), 333},        // 333.33
+		{1000, big.NewRat(2, 3), 667},        // 666.67
+		{1, big.NewRat(1, 2), 1},             // 0.5
+		{math.MaxInt64, big.NewRat(1, 1), math.MaxInt64},
+	}
+	for _, tt := range tests {
+		got, err := New("USD", tt.minor).MulRat(tt.f)
+		if err != nil || got.Minor != tt.want {
+			t.Errorf("MulRat(%d, %v) = %v, %v; want %d", tt.minor, tt.f, got, err, tt.want)
+		}
+	}
+
+	if _, err := New("USD", math.MaxInt64).MulRat(big.NewRat(2, 1)); !errors.Is(err, ErrOverflow) {
+		t.Errorf("MulRat overflow error = %v, want ErrOverflow", err)
+	}
+}
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		name string
+		from Amount
+		to   string
+		rate *big.Rat
+		want int64
+	}{
+		{"same exponent", New("USD", 1000), "EUR", big.NewRat(92, 100), 920},
+		{"to zero decimals", New("USD", 1999), "JPY", big.NewRat(15050, 100), 3008},
+		{"from zero decimals", New("JPY", 1500), "USD", big.NewRat(1, 150), 1000},
+		{"to three decimals", New("USD", 100), "KWD", big.NewRat(307, 1000), 307},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.from.Convert(tt.to, tt.rate)
+			if err != nil || got != New(tt.to, tt.want) {
+				t.Errorf("Convert() = %v, %v; want %d %s", got, err, tt.want, tt.to)
+			}
+		})
+	}
+
+	for _, rate := range []*big.Rat{nil, big.NewRat(0, 1), big.NewRat(-1, 1)} {
+		if _, err := New("USD", 100).Convert("EUR", rate); !errors.Is(err, ErrInvalidAmount) {
+			t.Errorf("Convert(rate %v) error = %v, want ErrInvalidAmount", rate, err)
+		}
+	}
+}
+
+func TestDecimal(t *testing.T) {
+	tests := []struct {
+		a    Amount
+		want string
+	}{
+		{New("USD", 1999), "19.99"},
+		{New("USD", 5), "0.05"},
+		{New("USD", -150), "-1.50"},
+		{New("JPY", 1500), "1500"},
+		{New("KWD", 1234), "1.234"},
+	}
+	for _, tt := range tests {
+		if got := tt.a.Decimal(); got != tt.want {
+			t.Errorf("%#v.Decimal() = %q, want %q", tt.a, got, tt.want)
+		}
+		back, err := Parse(tt.a.Currency, tt.a.Decimal())
+		if err != nil || back != tt.a {
+			t.Errorf("Parse(Decimal()) = %v, %v; want %v", back, err, tt.a)
+		}
+	}
+}
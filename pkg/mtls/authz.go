@@ -0,0 +1,115 @@
+package mtls
+
+import (
+	"context"
+	"strings"
+
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/errs"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/logger"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// ErrPeerNotAllowed is returned when the caller's SPIFFE ID is not in
+// the server's allow list.
+var ErrPeerNotAllowed = errs.New(errs.PermissionDenied, "PEER_NOT_ALLOWED", "caller is not allowed to use this service")
+
+// Health checks and reflection stay open to every workload that passed the
+// TLS handshake, so probes do not need their own allow-list entries.
+var openServices = []string{
+	"/grpc.health.v1.Health/",
+	"/grpc.reflection.",
+}
+
+// PeerID returns the SPIFFE ID of the caller authenticated by mTLS.
+func PeerID(ctx context.Context) (string, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return "", false
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.PeerCertificates) == 0 {
+		return "", false
+	}
+	id, err := spiffeID(info.State.PeerCertificates[0])
+	if err != nil {
+		return "", false
+	}
+	return id, true
+}
+
+// PeerIs returns a check that the caller is one of services, e.g. for an
+// auth.Rule. In insecure mode, where callers are not authenticated, every
+// caller passes.
+func (s *Source) PeerIs(services ...string) func(ctx context.Context) bool {
+	ids := make(map[string]bool, len(services))
+	for _, service := range services {
+		ids[s.cfg.ID(service)] = true
+	}
+	return func(ctx context.Context) bool {
+		if s.cfg.Insecure {
+			return true
+		}
+		id, ok := PeerID(ctx)
+		return ok && ids[id]
+	}
+}
+
+// UnaryServerInterceptor rejects callers whose SPIFFE ID is not allowed and
+// adds the caller's ID to the request logger. It must run inside
+// errs.UnaryServerInterceptor.
+func (s *Source) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := s.authorize(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor is the streaming counterpart of
+// UnaryServerInterceptor.
+func (s *Source) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := s.authorize(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authorizedStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+func (s *Source) authorize(ctx context.Context, fullMethod string) (context.Context, error) {
+	if s.cfg.Insecure {
+		return ctx, nil
+	}
+
+	id, ok := PeerID(ctx)
+	if !ok {
+		return ctx, ErrPeerNotAllowed
+	}
+	ctx = logger.WithContext(ctx, logger.FromContext(ctx).With(zap.String("peer_id", id)))
+
+	if len(s.allowed) == 0 || s.allowed[id] {
+		return ctx, nil
+	}
+	for _, prefix := range openServices {
+		if strings.HasPrefix(fullMethod, prefix) {
+			return ctx, nil
+		}
+	}
+	logger.FromContext(ctx).Warn("Rejected call from peer not in allow list")
+	return ctx, ErrPeerNotAllowed.WithMetadata("peer", id)
+}
+
+type authorizedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authorizedStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,236 @@
+package mtls
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/fsnotify/fsnotify"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// DefaultTrustDomain is used when TLS_TRUST_DOMAIN is not set.
+const DefaultTrustDomain = "microservice-with-grpc"
+
+// Config locates a workload's certificate material and lists the peers
+// allowed to call it. Workloads are identified by a SPIFFE ID carried as a
+// URI SAN, spiffe://<TrustDomain>/<service>, rather than by hostname.
+type Config struct {
+	CertFile    string
+	KeyFile     string
+	CAFile      string
+	TrustDomain string
+
+	// AllowedPeers lists the service names or full SPIFFE IDs that may
+	// call this server. Empty allows any workload in the trust domain.
+	AllowedPeers []string
+
+	// Insecure disables TLS and peer authorization. Local development only.
+	Insecure bool
+}
+
+// ConfigFromEnv reads TLS_CERT_FILE, TLS_KEY_FILE, TLS_CA_FILE,
+// TLS_TRUST_DOMAIN, TLS_ALLOWED_PEERS (comma separated) and TLS_INSECURE.
+func ConfigFromEnv() Config {
+	insecureMode, _ := strconv.ParseBool(os.Getenv("TLS_INSECURE"))
+	var peers []string
+	for _, p := range strings.Split(os.Getenv("TLS_ALLOWED_PEERS"), ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			peers = append(peers, p)
+		}
+	}
+	return Config{
+		CertFile:     os.Getenv("TLS_CERT_FILE"),
+		KeyFile:      os.Getenv("TLS_KEY_FILE"),
+		CAFile:       os.Getenv("TLS_CA_FILE"),
+		TrustDomain:  getEnv("TLS_TRUST_DOMAIN", DefaultTrustDomain),
+		AllowedPeers: peers,
+		Insecure:     insecureMode,
+	}
+}
+
+// ID returns the SPIFFE ID of a service in the trust domain. Values that
+// already are SPIFFE IDs are returned unchanged.
+func (c Config) ID(service string) string {
+	if strings.HasPrefix(service, "spiffe://") {
+		return service
+	}
+	return "spiffe://" + c.TrustDomain + "/" + service
+}
+
+// Source serves the current certificate and CA bundle to TLS handshakes and
+// reloads both when the files change, so certificates can be rotated
+// without restarting the process.
+type Source struct {
+	cfg     Config
+	allowed map[string]bool
+
+	current atomic.Pointer[material]
+	watcher *fsnotify.Watcher
+
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+type material struct {
+	cert  *tls.Certificate
+	roots *x509.CertPool
+	caPEM []byte
+}
+
+// Load reads the certificate material described by cfg and starts watching
+// it for changes. In insecure mode no files are read.
+func Load(cfg Config) (*Source, error) {
+	if cfg.TrustDomain == "" {
+		cfg.TrustDomain = DefaultTrustDomain
+	}
+	s := &Source{cfg: cfg, allowed: make(map[string]bool), done: make(chan struct{})}
+	for _, p := range cfg.AllowedPeers {
+		s.allowed[cfg.ID(p)] = true
+	}
+
+	if cfg.Insecure {
+		zap.L().Warn("TLS is disabled by TLS_INSECURE; never run like this outside local development")
+		return s, nil
+	}
+	if cfg.CertFile == "" || cfg.KeyFile == "" || cfg.CAFile == "" {
+		return nil, errors.New("mtls: TLS_CERT_FILE, TLS_KEY_FILE and TLS_CA_FILE are required unless TLS_INSECURE=true")
+	}
+
+	if err := s.reload(); err != nil {
+		return nil, err
+	}
+	if err := s.startWatching(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Insecure reports whether TLS is disabled.
+func (s *Source) Insecure() bool {
+	return s.cfg.Insecure
+}
+
+// ServerOption configures a gRPC server to require a client certificate
+// issued by the CA for a workload in the trust domain.
+func (s *Source) ServerOption() grpc.ServerOption {
+	if s.cfg.Insecure {
+		return grpc.Creds(insecure.NewCredentials())
+	}
+	return grpc.Creds(credentials.NewTLS(&tls.Config{
+		MinVersion: tls.VersionTLS13,
+		// The chain is checked in VerifyPeerCertificate against the
+		// current CA bundle, which tls.Config.ClientCAs cannot reload.
+		ClientAuth: tls.RequireAnyClientCert,
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			return s.current.Load().cert, nil
+		},
+		VerifyPeerCertificate: s.verifier(x509.ExtKeyUsageClientAuth, ""),
+	}))
+}
+
+// ClientCredentials returns transport credentials for calling service,
+// presenting this workload's certificate and accepting only a server whose
+// SPIFFE ID matches service.
+func (s *Source) ClientCredentials(service string) credentials.TransportCredentials {
+	if s.cfg.Insecure {
+		return insecure.NewCredentials()
+	}
+	return credentials.NewTLS(&tls.Config{
+		MinVersion: tls.VersionTLS13,
+		// Workloads are authenticated by SPIFFE ID, not hostname, so the
+		// standard verification is replaced by VerifyPeerCertificate.
+		InsecureSkipVerify: true,
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return s.current.Load().cert, nil
+		},
+		VerifyPeerCertificate: s.verifier(x509.ExtKeyUsageServerAuth, s.cfg.ID(service)),
+	})
+}
+
+// Close stops watching the certificate files.
+func (s *Source) Close() {
+	s.closeOnce.Do(func() {
+		close(s.done)
+		if s.watcher != nil {
+			s.watcher.Close()
+		}
+	})
+}
+
+// verifier checks the peer's chain against the current CA bundle and its
+// SPIFFE ID against the trust domain and, if set, the expected ID.
+func (s *Source) verifier(usage x509.ExtKeyUsage, want string) func([][]byte, [][]*x509.Certificate) error {
+	return func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+		if len(rawCerts) == 0 {
+			return errors.New("mtls: peer presented no certificate")
+		}
+		certs := make([]*x509.Certificate, len(rawCerts))
+		for i, raw := range rawCerts {
+			cert, err := x509.ParseCertificate(raw)
+			if err != nil {
+				return fmt.Errorf("mtls: %w", err)
+			}
+			certs[i] = cert
+		}
+
+		intermediates := x509.NewCertPool()
+		for _, cert := range certs[1:] {
+			intermediates.AddCert(cert)
+		}
+		if _, err := certs[0].Verify(x509.VerifyOptions{
+			Roots:         s.current.Load().roots,
+			Intermediates: intermediates,
+			KeyUsages:     []x509.ExtKeyUsage{usage},
+		}); err != nil {
+			return fmt.Errorf("mtls: %w", err)
+		}
+
+		id, err := spiffeID(certs[0])
+		if err != nil {
+			return err
+		}
+		if !strings.HasPrefix(id, "spiffe://"+s.cfg.TrustDomain+"/") {
+			return fmt.Errorf("mtls: peer %s is outside trust domain %s", id, s.cfg.TrustDomain)
+		}
+		if want != "" && id != want {
+			return fmt.Errorf("mtls: peer is %s, want %s", id, want)
+		}
+		return nil
+	}
+}
+
+// spiffeID returns the single spiffe:// URI SAN of cert.
+func spiffeID(cert *x509.Certificate) (string, error) {
+	var id *url.URL
+	for _, u := range cert.URIs {
+		if u.Scheme != "spiffe" {
+			continue
+		}
+		if id != nil {
+			return "", errors.New("mtls: certificate has more than one SPIFFE ID")
+		}
+		id = u
+	}
+	if id == nil {
+		return "", errors.New("mtls: certificate has no SPIFFE ID")
+	}
+	return id.String(), nil
+}
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
@@ -0,0 +1,115 @@
+package mtls
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+)
+
+var certExpiry = promauto.NewGauge(prometheus.GaugeOpts{
+	Name: "tls_certificate_expiry_timestamp_seconds",
+	Help: "Expiry of the currently served workload certificate as a Unix timestamp.",
+})
+
+// reloadDelay is how long the certificate files must stay unchanged
+// before they are reloaded.
+const reloadDelay = 200 * time.Millisecond
+
+// reload reads the key pair and CA bundle and swaps them in atomically.
+// On error the previous material stays in use.
+func (s *Source) reload() error {
+	cert, err := tls.LoadX509KeyPair(s.cfg.CertFile, s.cfg.KeyFile)
+	if err != nil {
+		return fmt.Errorf("mtls: load key pair: %w", err)
+	}
+	id, err := spiffeID(cert.Leaf)
+	if err != nil {
+		return err
+	}
+
+	caPEM, err := os.ReadFile(s.cfg.CAFile)
+	if err != nil {
+		return fmt.Errorf("mtls: read CA bundle: %w", err)
+	}
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(caPEM) {
+		return fmt.Errorf("mtls: no certificates in %s", s.cfg.CAFile)
+	}
+
+	if cur := s.current.Load(); cur != nil &&
+		bytes.Equal(cur.cert.Certificate[0], cert.Certificate[0]) && bytes.Equal(cur.caPEM, caPEM) {
+		return nil
+	}
+	s.current.Store(&material{cert: &cert, roots: roots, caPEM: caPEM})
+	certExpiry.Set(float64(cert.Leaf.NotAfter.Unix()))
+	zap.L().Info("Loaded TLS certificate",
+		zap.String("spiffe_id", id),
+		zap.Time("not_after", cert.Leaf.NotAfter),
+	)
+	return nil
+}
+
+// startWatching watches the directories holding the certificate files, so
+// replacements made by renaming files into place (as cert-manager and
+// Kubernetes secret volumes do) are picked up.
+func (s *Source) startWatching() error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("mtls: %w", err)
+	}
+	dirs := make(map[string]bool)
+	for _, f := range []string{s.cfg.CertFile, s.cfg.KeyFile, s.cfg.CAFile} {
+		dir := filepath.Dir(f)
+		if dirs[dir] {
+			continue
+		}
+		dirs[dir] = true
+		if err := watcher.Add(dir); err != nil {
+			watcher.Close()
+			return fmt.Errorf("mtls: %w", err)
+		}
+	}
+	s.watcher = watcher
+	go s.watch()
+	return nil
+}
+
+func (s *Source) watch() {
+	// Certificate, key and CA are usually written one after the other, so
+	// events are coalesced and the files reloaded once they settle.
+	settle := time.NewTimer(time.Hour)
+	settle.Stop()
+	defer settle.Stop()
+
+	for {
+		select {
+		case ev, ok := <-s.watcher.Events:
+			if !ok {
+				return
+			}
+			if !ev.Has(fsnotify.Chmod) {
+				settle.Reset(reloadDelay)
+			}
+		case <-settle.C:
+			if err := s.reload(); err != nil {
+				zap.L().Warn("Keeping previous TLS certificate", zap.Error(err))
+			}
+		case err, ok := <-s.watcher.Errors:
+			if !ok {
+				return
+			}
+			zap.L().Warn("TLS certificate watch error", zap.Error(err))
+		case <-s.done:
+			return
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"time"
+
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/errs"
+	"github.com/google/uuid"
+)
+
+var ErrInvalidPageToken = errs.New(errs.InvalidArgument, "INVALID_PAGE_TOKEN", "invalid page token")
+
+// Cursor is the position after the last row of a page. Lists are ordered by
+// (created_at, id) descending, so the next page holds the rows that sort
+// strictly after it; rows inserted meanwhile cannot shift the page.
+type Cursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+// Request is a page request in either keyset or legacy offset mode.
+type Request struct {
+	After  *Cursor // keyset mode: rows after this cursor
+	Offset int32   // legacy mode: rows to skip
+	Limit  int32
+}
+
+type token struct {
+	CreatedAt int64  `json:"t"`
+	ID        string `json:"i"`
+	Filter    string `json:"f,omitempty"`
+}
+
+// Encode returns an opaque page token for c. filter identifies the list's
+// filters (e.g. the user ID of an order list) so that a token cannot be
+// replayed against a different list.
+func Encode(c Cursor, filter string) string {
+	b, _ := json.Marshal(token{
+		CreatedAt: c.CreatedAt.UnixMicro(),
+		ID:        c.ID,
+		Filter:    filterHash(filter),
+	})
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// Decode parses a page token created by Encode with the same filter.
+func Decode(pageToken, filter string) (Cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(pageToken)
+	if err != nil {
+		return Cursor{}, ErrInvalidPageToken.WithField("page_token", "malformed token")
+	}
+	var t token
+	if err := json.Unmarshal(b, &t); err != nil {
+		return Cursor{}, ErrInvalidPageToken.WithField("page_token", "malformed token")
+	}
+	// The ID is compared against uuid columns, where anything else fails
+	// the query.
+	if _, err := uuid.Parse(t.ID); err != nil {
+		return Cursor{}, ErrInvalidPageToken.WithField("page_token", "malformed token")
+	}
+	if t.Filter != filterHash(filter) {
+		return Cursor{}, ErrInvalidPageToken.WithField("page_token", "token belongs to a different query")
+	}
+	return Cursor{CreatedAt: time.UnixMicro(t.CreatedAt).UTC(), ID: t.ID}, nil
+}
+
+// NewRequest builds the page request for a list RPC. A page token takes
+// precedence over the legacy 1-based page number; with neither, the first
+// page is returned in keyset mode.
+func NewRequest(pageToken string, page, limit int32, filter string) (Request, error) {
+	req := Request{Limit: limit}
+	switch {
+	case pageToken != "":
+		c, err := Decode(pageToken, filter)
+		if err != nil {
+			return Request{}, err
+		}
+		req.After = &c
+	case page > 1:
+		req.Offset = (page - 1) * limit
+	}
+	return req, nil
+}
+
+// Where returns the SQL condition selecting the rows after req.After,
+// numbering its placeholders from next, and the matching arguments. Without
+// a cursor the condition is TRUE.
+func (r Request) Where(next int) (string, []interface{}) {
+	if r.After == nil {
+		return "TRUE", nil
+	}
+	cond := fmt.Sprintf("(created_at, id) < ($%d, $%d)", next, next+1)
+	return cond, []interface{}{r.After.CreatedAt, r.After.ID}
+}
+
+// OrderBy is the sort order every paginated query must use.
+const OrderBy = "ORDER BY created_at DESC, id DESC"
+
+// LimitOffset returns the LIMIT/OFFSET clause, fetching one row more than
+// the page size so Trim can tell whether another page follows.
+func (r Request) LimitOffset(next int) (string, []interface{}) {
+	return fmt.Sprintf("LIMIT $%d OFFSET $%d", next, next+1), []interface{}{r.Limit + 1, r.Offset}
+}
+
+// Trim cuts rows, fetched with a limit of req.Limit+1, down to one page and
+// returns the token for the next page, or "" on the last page.
+func Trim[T any](rows []T, req Request, filter string, cursor func(T) Cursor) ([]T, string) {
+	if int32(len(rows)) <= req.Limit {
+		return rows, ""
+	}
+	rows = rows[:req.Limit]
+	return rows, Encode(cursor(rows[len(rows)-1]), filter)
+}
+
+func filterHash(filter string) string {
+	if filter == "" {
+		return ""
+	}
+	h := fnv.New64a()
+	h.Write([]byte(filter))
+	return strconv.FormatUint(h.Sum64(), 36)
+}
@@ -0,0 +1,118 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+)
+
+const testID = "9b2f0c3e-5d4a-4c1b-8e7f-0a1b2c3d4e5f"
+
+func rawToken(json string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(json))
+}
+
+func TestEncodeDecode(t *testing.T) {
+	c := Cursor{CreatedAt: time.Date(2024, 5, 1, 12, 30, 0, 123456000, time.UTC), ID: testID}
+
+	for _, filter := range []string{"", "user-1"} {
+		got, err := Decode(Encode(c, filter), filter)
+		if err != nil {
+			t.Fatalf("Decode(filter %q): %v", filter, err)
+		}
+		if !got.CreatedAt.Equal(c.CreatedAt) || got.ID != c.ID {
+			t.Errorf("Decode(filter %q) = %+v, want %+v", filter, got, c)
+		}
+	}
+}
+
+func TestDecodeTruncatesToMicroseconds(t *testing.T) {
+	c := Cursor{CreatedAt: time.Date(2024, 5, 1, 12, 30, 0, 123456789, time.UTC), ID: testID}
+
+	got, err := Decode(Encode(c, ""), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := c.CreatedAt.Truncate(time.Microsecond); !got.CreatedAt.Equal(want) {
+		t.Errorf("CreatedAt = %v, want %v", got.CreatedAt, want)
+	}
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	valid := Encode(Cursor{CreatedAt: time.Now(), ID: testID}, "user-1")
+
+	tests := []struct {
+		name   string
+		token  string
+		filter string
+	}{
+		{"not base64", "!!!", ""},
+		{"not json", rawToken("nope"), ""},
+		{"missing id", rawToken(`{"t":1}`), ""},
+		{"non-uuid id", rawToken(`{"t":1,"i":"1' OR '1'='1"}`), ""},
+		{"other filter", valid, "user-2"},
+		{"filter dropped", valid, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Decode(tt.token, tt.filter)
+			if !errors.Is(err, ErrInvalidPageToken) {
+				t.Errorf("Decode() error = %v, want ErrInvalidPageToken", err)
+			}
+		})
+	}
+}
+
+func TestNewRequest(t *testing.T) {
+	token := Encode(Cursor{CreatedAt: time.Now(), ID: testID}, "")
+
+	tests := []struct {
+		name       string
+		token      string
+		page       int32
+		wantCursor bool
+		wantOffset int32
+	}{
+		{"first page", "", 0, false, 0},
+		{"legacy page one", "", 1, false, 0},
+		{"legacy page three", "", 3, false, 20},
+		{"token wins over page", token, 3, true, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := NewRequest(tt.token, tt.page, 10, "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if (req.After != nil) != tt.wantCursor || req.Offset != tt.wantOffset || req.Limit != 10 {
+				t.Errorf("NewRequest() = %+v, want cursor %v offset %d", req, tt.wantCursor, tt.wantOffset)
+			}
+		})
+	}
+}
+
+func TestTrim(t *testing.T) {
+	base := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	rows := []int{3, 2, 1}
+	cursor := func(i int) Cursor {
+		return Cursor{CreatedAt: base.Add(time.Duration(i) * time.Hour), ID: testID}
+	}
+
+	page, next := Trim(rows, Request{Limit: 3}, "", cursor)
+	if len(page) != 3 || next != "" {
+		t.Errorf("Trim(last page) = %v, %q; want all rows and no token", page, next)
+	}
+
+	page, next = Trim(rows, Request{Limit: 2}, "", cursor)
+	if len(page) != 2 || next == "" {
+		t.Fatalf("Trim(full page) = %v, %q; want 2 rows and a token", page, next)
+	}
+	c, err := Decode(next, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !c.CreatedAt.Equal(cursor(2).CreatedAt) {
+		t.Errorf("next cursor = %v, want the last row on the page", c.CreatedAt)
+	}
+}
@@ -0,0 +1,112 @@
+package recovery
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"runtime/debug"
+
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/logger"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// CorrelationIDHeader carries the ID that links an Internal error returned
+// to a client with the panic logged by the server.
+const CorrelationIDHeader = "x-correlation-id"
+
+var (
+	grpcPanics = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "grpc_server_panics_recovered_total",
+		Help: "Total number of panics recovered in gRPC handlers.",
+	}, []string{"grpc_service", "grpc_method"})
+
+	httpPanics = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "http_panics_recovered_total",
+		Help: "Total number of panics recovered in HTTP handlers.",
+	})
+)
+
+// UnaryServerInterceptor turns a panic in a handler into a codes.Internal
+// error instead of crashing the process.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer func() {
+			if p := recover(); p != nil {
+				err = recovered(ctx, info.FullMethod, p)
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor is the streaming counterpart of
+// UnaryServerInterceptor.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if p := recover(); p != nil {
+				err = recovered(ss.Context(), info.FullMethod, p)
+			}
+		}()
+		return handler(srv, ss)
+	}
+}
+
+func recovered(ctx context.Context, fullMethod string, p interface{}) error {
+	id := uuid.New().String()
+	grpcPanics.WithLabelValues(path.Dir(fullMethod)[1:], path.Base(fullMethod)).Inc()
+
+	logger.FromContext(ctx).Error("Recovered from panic",
+		zap.String("correlation_id", id),
+		zap.Any("panic", p),
+		zap.ByteString("stack", debug.Stack()),
+	)
+
+	_ = grpc.SetHeader(ctx, metadata.Pairs(CorrelationIDHeader, id))
+	return status.Errorf(codes.Internal, "internal error (correlation id: %s)", id)
+}
+
+// HTTPMiddleware recovers panics in h and responds with a 500 carrying a
+// correlation ID.
+func HTTPMiddleware(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			p := recover()
+			if p == nil {
+				return
+			}
+			if p == http.ErrAbortHandler {
+				// Deliberate abort; let net/http handle it.
+				panic(p)
+			}
+
+			id := uuid.New().String()
+			httpPanics.Inc()
+			logger.FromContext(r.Context()).Error("Recovered from panic",
+				zap.String("correlation_id", id),
+				zap.String("http.method", r.Method),
+				zap.String("http.path", r.URL.Path),
+				zap.Any("panic", p),
+				zap.ByteString("stack", debug.Stack()),
+			)
+
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set(CorrelationIDHeader, id)
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"code":    codes.Internal,
+				"message": fmt.Sprintf("internal error (correlation id: %s)", id),
+			})
+		}()
+		h.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,60 @@
+package shutdown
+
+import (
+	"context"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+// Serve runs srv on lis until it fails or the process receives SIGINT or
+// SIGTERM. On a signal, beforeStop is called (typically to flip health
+// checks to NOT_SERVING) and the server is stopped gracefully, waiting at
+// most timeout for in-flight RPCs before forcing remaining connections
+// closed. Serve returns nil after a signal-triggered shutdown.
+func Serve(srv *grpc.Server, lis net.Listener, timeout time.Duration, beforeStop func()) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- srv.Serve(lis)
+	}()
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
+	}
+
+	zap.L().Info("Shutdown signal received, draining", zap.Duration("timeout", timeout))
+	if beforeStop != nil {
+		beforeStop()
+	}
+	GracefulStop(srv, timeout)
+	return nil
+}
+
+// GracefulStop stops srv, letting in-flight RPCs finish. If they have not
+// finished within timeout the server is stopped forcibly.
+func GracefulStop(srv *grpc.Server, timeout time.Duration) {
+	done := make(chan struct{})
+	go func() {
+		srv.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		zap.L().Info("Server stopped gracefully")
+	case <-time.After(timeout):
+		zap.L().Warn("Graceful stop timed out, forcing shutdown", zap.Duration("timeout", timeout))
+		srv.Stop()
+		<-done
+	}
+}
@@ -0,0 +1,130 @@
+package tax
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+)
+
+// RuleTable looks taxes up in a table of rules per region, read from a
+// file in the ruleFile format.
+type RuleTable struct {
+	regions map[string]regionRules
+}
+
+type regionRules struct {
+	inclusive bool
+	rules     []rule
+}
+
+type rule struct {
+	name       string
+	rate       *big.Rat
+	categories map[string]bool // empty: every category
+}
+
+// ruleFile is the format read by LoadFile:
+//
+//	{"regions": {
+//	  "US-CA": {"rules": [{"name": "CA sales tax", "rate": "0.0725"}]},
+//	  "DE": {"inclusive": true, "rules": [
+//	    {"name": "VAT", "rate": "0.19"},
+//	    {"name": "VAT", "rate": "0.07", "categories": ["food", "books"]}
+//	  ]}
+//	}}
+//
+// For each tax name, a rule listing the product's category wins over the
+// rule without categories; a rate of 0 exempts a category. A region such
+// as "US-CA" falls back to its country "US", then to "*".
+type ruleFile struct {
+	Regions map[string]struct {
+		Inclusive bool `json:"inclusive"`
+		Rules     []struct {
+			Name       string   `json:"name"`
+			Rate       string   `json:"rate"`
+			Categories []string `json:"categories"`
+		} `json:"rules"`
+	} `json:"regions"`
+}
+
+// LoadFile reads a rule table.
+func LoadFile(path string) (*RuleTable, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tax rules: %w", err)
+	}
+	var f ruleFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("failed to parse tax rules %s: %w", path, err)
+	}
+
+	t := &RuleTable{regions: make(map[string]regionRules, len(f.Regions))}
+	for region, rr := range f.Regions {
+		parsed := regionRules{inclusive: rr.Inclusive}
+		for _, r := range rr.Rules {
+			rate, ok := new(big.Rat).SetString(r.Rate)
+			if !ok || rate.Sign() < 0 || r.Name == "" {
+				return nil, fmt.Errorf("invalid tax rule %q in region %s", r.Name, region)
+			}
+			categories := make(map[string]bool, len(r.Categories))
+			for _, c := range r.Categories {
+				categories[c] = true
+			}
+			parsed.rules = append(parsed.rules, rule{name: r.Name, rate: rate, categories: categories})
+		}
+		t.regions[region] = parsed
+	}
+	return t, nil
+}
+
+func (t *RuleTable) Rates(_ context.Context, region, category string) (Rates, error) {
+	rr, ok := t.lookup(region)
+	if !ok {
+		return Rates{}, nil
+	}
+
+	// Pick one rule per tax name, preferring a category match, and keep
+	// the order in which names first appear in the file.
+	var names []string
+	listed := make(map[string]bool)
+	chosen := make(map[string]rule)
+	for _, r := range rr.rules {
+		if !listed[r.name] {
+			listed[r.name] = true
+			names = append(names, r.name)
+		}
+		prev, seen := chosen[r.name]
+		switch {
+		case len(r.categories) == 0 && !seen:
+			chosen[r.name] = r
+		case r.categories[category] && (!seen || len(prev.categories) == 0):
+			chosen[r.name] = r
+		}
+	}
+
+	rates := Rates{Inclusive: rr.inclusive}
+	for _, name := range names {
+		r, ok := chosen[name]
+		if !ok || r.rate.Sign() == 0 {
+			continue
+		}
+		rates.Components = append(rates.Components, Component{Name: r.name, Rate: r.rate})
+	}
+	return rates, nil
+}
+
+func (t *RuleTable) lookup(region string) (regionRules, bool) {
+	if rr, ok := t.regions[region]; ok {
+		return rr, true
+	}
+	if country, _, ok := strings.Cut(region, "-"); ok {
+		if rr, ok := t.regions[country]; ok {
+			return rr, true
+		}
+	}
+	rr, ok := t.regions["*"]
+	return rr, ok
+}
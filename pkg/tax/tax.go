@@ -0,0 +1,94 @@
+package tax
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/money"
+)
+
+// Component is one tax levied on a line, e.g. a state sales tax.
+type Component struct {
+	Name string
+	Rate *big.Rat // e.g. 0.0725
+}
+
+// Rates are the taxes that apply to one product category in one region.
+type Rates struct {
+	// Inclusive means prices in the region already include the tax.
+	Inclusive  bool
+	Components []Component
+}
+
+// Calculator looks up the taxes for a product category shipped to a
+// region, e.g. "US-CA" or "DE".
+type Calculator interface {
+	Rates(ctx context.Context, region, category string) (Rates, error)
+}
+
+// Line is the tax of one component on one order line.
+type Line struct {
+	Name   string
+	Rate   *big.Rat
+	Amount money.Amount
+}
+
+// Result splits an order line into its net amount and taxes.
+type Result struct {
+	Net   money.Amount
+	Tax   money.Amount
+	Lines []Line
+}
+
+// Apply computes the taxes on amount, the price of a whole order line.
+// With exclusive rates amount is net and each tax is rounded separately.
+// With inclusive rates amount is gross: the net amount is extracted with
+// the combined rate and the tax split across components, the last one
+// taking the rounding remainder so that net plus taxes equals amount.
+func Apply(amount money.Amount, rates Rates) (Result, error) {
+	res := Result{Net: amount, Tax: money.Zero(amount.Currency)}
+	if len(rates.Components) == 0 {
+		return res, nil
+	}
+
+	if rates.Inclusive {
+		combined := big.NewRat(1, 1)
+		for _, c := range rates.Components {
+			combined.Add(combined, c.Rate)
+		}
+		net, err := amount.MulRat(new(big.Rat).Inv(combined))
+		if err != nil {
+			return Result{}, err
+		}
+		res.Net = net
+	}
+
+	for i, c := range rates.Components {
+		line, err := res.Net.MulRat(c.Rate)
+		if err != nil {
+			return Result{}, err
+		}
+		if rates.Inclusive && i == len(rates.Components)-1 {
+			// amount - net - taxes so far
+			remainder, err := amount.Sub(res.Net)
+			if err == nil {
+				line, err = remainder.Sub(res.Tax)
+			}
+			if err != nil {
+				return Result{}, err
+			}
+		}
+		if res.Tax, err = res.Tax.Add(line); err != nil {
+			return Result{}, err
+		}
+		res.Lines = append(res.Lines, Line{Name: c.Name, Rate: c.Rate, Amount: line})
+	}
+	return res, nil
+}
+
+// None levies no taxes. It is used when no tax rules are configured.
+type None struct{}
+
+func (None) Rates(context.Context, string, string) (Rates, error) {
+	return Rates{}, nil
+}
@@ -0,0 +1,203 @@
+package tax
+
+import (
+	"context"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/money"
+)
+
+func rate(s string) *big.Rat {
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		panic("bad rate " + s)
+	}
+	return r
+}
+
+func TestApply(t *testing.T) {
+	tests := []struct {
+		name      string
+		amount    int64
+		rates     Rates
+		wantNet   int64
+		wantLines []int64
+	}{
+		{
+			name:    "no taxes",
+			amount:  1999,
+			wantNet: 1999,
+		},
+		{
+			name:      "exclusive rounds half up",
+			amount:    1000,
+			rates:     Rates{Components: []Component{{"CA", rate("0.0725")}}},
+			wantNet:   1000,
+			wantLines: []int64{73},
+		},
+		{
+			name:   "exclusive rounds each component",
+			amount: 1999,
+			rates: Rates{Components: []Component{
+				{"state", rate("0.06")},
+				{"county", rate("0.0125")},
+			}},
+			wantNet:   1999,
+			wantLines: []int64{120, 25},
+		},
+		{
+			name:      "inclusive exact",
+			amount:    1190,
+			rates:     Rates{Inclusive: true, Components: []Component{{"VAT", rate("0.19")}}},
+			wantNet:   1000,
+			wantLines: []int64{190},
+		},
+		{
+			name:      "inclusive rounded",
+			amount:    999,
+			rates:     Rates{Inclusive: true, Components: []Component{{"VAT", rate("0.19")}}},
+			wantNet:   839,
+			wantLines: []int64{160},
+		},
+		{
+			name:   "inclusive last component takes the remainder",
+			amount: 1000,
+			rates: Rates{Inclusive: true, Components: []Component{
+				{"GST", rate("0.05")},
+				{"PST", rate("0.07")},
+			}},
+			wantNet:   893,
+			wantLines: []int64{45, 62},
+		},
+		{
+			name:      "zero amount",
+			amount:    0,
+			rates:     Rates{Inclusive: true, Components: []Component{{"VAT", rate("0.19")}}},
+			wantNet:   0,
+			wantLines: []int64{0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := Apply(money.New("EUR", tt.amount), tt.rates)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res.Net != money.New("EUR", tt.wantNet) {
+				t.Errorf("Net = %v, want %d", res.Net, tt.wantNet)
+			}
+			if len(res.Lines) != len(tt.wantLines) {
+				t.Fatalf("got %d tax lines, want %d", len(res.Lines), len(tt.wantLines))
+			}
+			var sum int64
+			for i, line := range res.Lines {
+				if line.Amount.Minor != tt.wantLines[i] {
+					t.Errorf("Lines[%d] (%s) = %v, want %d", i, line.Name, line.Amount, tt.wantLines[i])
+				}
+				sum += line.Amount.Minor
+			}
+			if res.Tax.Minor != sum {
+				t.Errorf("Tax = %v, want the sum of the lines %d", res.Tax, sum)
+			}
+			if tt.rates.Inclusive && res.Net.Minor+res.Tax.Minor != tt.amount {
+				t.Errorf("inclusive Net+Tax = %d, want %d", res.Net.Minor+res.Tax.Minor, tt.amount)
+			}
+		})
+	}
+}
+
+const testRules = `{"regions": {
+  "US-CA": {"rules": [
+    {"name": "state", "rate": "0.06"},
+    {"name": "county", "rate": "0.0125"},
+    {"name": "state", "rate": "0", "categories": ["food"]}
+  ]},
+  "US": {"rules": [{"name": "federal", "rate": "0.01"}]},
+  "DE": {"inclusive": true, "rules": [
+    {"name": "VAT", "rate": "0.07", "categories": ["books"]},
+    {"name": "VAT", "rate": "0.19"}
+  ]},
+  "*": {"rules": [{"name": "default", "rate": "0.1"}]}
+}}`
+
+func loadRules(t *testing.T, data string) (*RuleTable, error) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "rules.json")
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return LoadFile(path)
+}
+
+func TestRuleTableRates(t *testing.T) {
+	table, err := loadRules(t, testRules)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		region, category string
+		wantInclusive    bool
+		want             []string // name=rate
+	}{
+		{"US-CA", "electronics", false, []string{"state=3/50", "county=1/80"}},
+		{"US-CA", "food", false, []string{"county=1/80"}},
+		{"US-NY", "electronics", false, []string{"federal=1/100"}},
+		{"DE", "electronics", true, []string{"VAT=19/100"}},
+		{"DE", "books", true, []string{"VAT=7/100"}},
+		{"FR", "books", false, []string{"default=1/10"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.region+"/"+tt.category, func(t *testing.T) {
+			rates, err := table.Rates(context.Background(), tt.region, tt.category)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if rates.Inclusive != tt.wantInclusive {
+				t.Errorf("Inclusive = %v, want %v", rates.Inclusive, tt.wantInclusive)
+			}
+			var got []string
+			for _, c := range rates.Components {
+				got = append(got, c.Name+"="+c.Rate.RatString())
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("components = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("components = %v, want %v", got, tt.want)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestRuleTableNoFallback(t *testing.T) {
+	table, err := loadRules(t, `{"regions": {"US": {"rules": [{"name": "federal", "rate": "0.01"}]}}}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rates, err := table.Rates(context.Background(), "DE", "books")
+	if err != nil || len(rates.Components) != 0 {
+		t.Errorf("Rates(DE) = %+v, %v; want no taxes", rates, err)
+	}
+}
+
+func TestLoadFileInvalid(t *testing.T) {
+	for name, data := range map[string]string{
+		"not json":      `{`,
+		"bad rate":      `{"regions": {"US": {"rules": [{"name": "x", "rate": "abc"}]}}}`,
+		"negative rate": `{"regions": {"US": {"rules": [{"name": "x", "rate": "-0.1"}]}}}`,
+		"missing name":  `{"regions": {"US": {"rules": [{"rate": "0.1"}]}}}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := loadRules(t, data); err == nil {
+				t.Error("LoadFile() succeeded, want an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package validation
+
+import (
+	"context"
+	"errors"
+
+	"buf.build/go/protovalidate"
+	"github.com/dipendra-mule/microservice-with-grpc/pkg/errs"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// ErrValidation is returned, with one field violation per broken rule, when
+// a request does not satisfy the constraints declared in its proto.
+var ErrValidation = errs.New(errs.InvalidArgument, "VALIDATION_FAILED", "request validation failed")
+
+// UnaryServerInterceptor validates every request message against its
+// buf.validate annotations before the handler runs.
+func UnaryServerInterceptor(v protovalidate.Validator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if m, ok := req.(proto.Message); ok {
+			if err := Validate(v, m); err != nil {
+				return nil, err
+			}
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor validates every message received on a stream.
+func StreamServerInterceptor(v protovalidate.Validator) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &validatingStream{ServerStream: ss, validator: v})
+	}
+}
+
+// Validate checks m and converts any violations into a domain error.
+func Validate(v protovalidate.Validator, m proto.Message) error {
+	err := v.Validate(m)
+	if err == nil {
+		return nil
+	}
+
+	var verr *protovalidate.ValidationError
+	if !errors.As(err, &verr) {
+		return errs.Wrap(err, errs.Internal, "", "")
+	}
+
+	out := ErrValidation
+	for _, violation := range verr.Violations {
+		out = out.WithField(
+			protovalidate.FieldPathString(violation.Proto.GetField()),
+			violation.Proto.GetMessage(),
+		)
+	}
+	return out
+}
+
+type validatingStream struct {
+	grpc.ServerStream
+	validator protovalidate.Validator
+}
+
+func (s *validatingStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if msg, ok := m.(proto.Message); ok {
+		return Validate(s.validator, msg)
+	}
+	return nil
+}
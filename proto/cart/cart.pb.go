@@ -0,0 +1,1094 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v6.33.0
+// source: proto/cart/cart.proto
+
+package cart
+
+import (
+	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	money "github.com/dipendra-mule/microservice-with-grpc/proto/money"
+	order "github.com/dipendra-mule/microservice-with-grpc/proto/order"
+	user "github.com/dipendra-mule/microservice-with-grpc/proto/user"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Cart struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Id     string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                       // Empty for a user who has no cart yet
+	UserId string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // Empty for a guest cart
+	Items  []*CartItem            `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`
+	// Sum of the line totals, before discounts and tax. Unset when the items
+	// are priced in different currencies or could not be priced.
+	Subtotal      *money.Money           `protobuf:"bytes,4,opt,name=subtotal,proto3" json:"subtotal,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Cart) Reset() {
+	*x = Cart{}
+	mi := &file_proto_cart_cart_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Cart) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Cart) ProtoMessage() {}
+
+func (x *Cart) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cart_cart_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Cart.ProtoReflect.Descriptor instead.
+func (*Cart) Descriptor() ([]byte, []int) {
+	return file_proto_cart_cart_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Cart) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Cart) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Cart) GetItems() []*CartItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *Cart) GetSubtotal() *money.Money {
+	if x != nil {
+		return x.Subtotal
+	}
+	return nil
+}
+
+func (x *Cart) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Cart) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Cart) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+// CartItem is priced at the product's current price each time the cart is
+// read.
+type CartItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	ProductName   string                 `protobuf:"bytes,3,opt,name=product_name,json=productName,proto3" json:"product_name,omitempty"`
+	UnitPrice     *money.Money           `protobuf:"bytes,4,opt,name=unit_price,json=unitPrice,proto3" json:"unit_price,omitempty"`
+	LineTotal     *money.Money           `protobuf:"bytes,5,opt,name=line_total,json=lineTotal,proto3" json:"line_total,omitempty"`
+	AddedAt       *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=added_at,json=addedAt,proto3" json:"added_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CartItem) Reset() {
+	*x = CartItem{}
+	mi := &file_proto_cart_cart_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CartItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CartItem) ProtoMessage() {}
+
+func (x *CartItem) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cart_cart_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CartItem.ProtoReflect.Descriptor instead.
+func (*CartItem) Descriptor() ([]byte, []int) {
+	return file_proto_cart_cart_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CartItem) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *CartItem) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *CartItem) GetProductName() string {
+	if x != nil {
+		return x.ProductName
+	}
+	return ""
+}
+
+func (x *CartItem) GetUnitPrice() *money.Money {
+	if x != nil {
+		return x.UnitPrice
+	}
+	return nil
+}
+
+func (x *CartItem) GetLineTotal() *money.Money {
+	if x != nil {
+		return x.LineTotal
+	}
+	return nil
+}
+
+func (x *CartItem) GetAddedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AddedAt
+	}
+	return nil
+}
+
+// CartWarning reports data a cart response could not include, e.g. a
+// product that is no longer available.
+type CartWarning struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reason        string                 `protobuf:"bytes,1,opt,name=reason,proto3" json:"reason,omitempty"` // e.g. PRODUCT_UNAVAILABLE, PRICING_UNAVAILABLE, MIXED_CURRENCIES
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	ProductId     string                 `protobuf:"bytes,3,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CartWarning) Reset() {
+	*x = CartWarning{}
+	mi := &file_proto_cart_cart_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CartWarning) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CartWarning) ProtoMessage() {}
+
+func (x *CartWarning) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cart_cart_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CartWarning.ProtoReflect.Descriptor instead.
+func (*CartWarning) Descriptor() ([]byte, []int) {
+	return file_proto_cart_cart_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CartWarning) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *CartWarning) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *CartWarning) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+type GetCartRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Cart:
+	//
+	//	*GetCartRequest_CartId
+	//	*GetCartRequest_UserId
+	Cart          isGetCartRequest_Cart `protobuf_oneof:"cart"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCartRequest) Reset() {
+	*x = GetCartRequest{}
+	mi := &file_proto_cart_cart_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCartRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCartRequest) ProtoMessage() {}
+
+func (x *GetCartRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cart_cart_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCartRequest.ProtoReflect.Descriptor instead.
+func (*GetCartRequest) Descriptor() ([]byte, []int) {
+	return file_proto_cart_cart_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetCartRequest) GetCart() isGetCartRequest_Cart {
+	if x != nil {
+		return x.Cart
+	}
+	return nil
+}
+
+func (x *GetCartRequest) GetCartId() string {
+	if x != nil {
+		if x, ok := x.Cart.(*GetCartRequest_CartId); ok {
+			return x.CartId
+		}
+	}
+	return ""
+}
+
+func (x *GetCartRequest) GetUserId() string {
+	if x != nil {
+		if x, ok := x.Cart.(*GetCartRequest_UserId); ok {
+			return x.UserId
+		}
+	}
+	return ""
+}
+
+type isGetCartRequest_Cart interface {
+	isGetCartRequest_Cart()
+}
+
+type GetCartRequest_CartId struct {
+	CartId string `protobuf:"bytes,1,opt,name=cart_id,json=cartId,proto3,oneof"`
+}
+
+type GetCartRequest_UserId struct {
+	UserId string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3,oneof"`
+}
+
+func (*GetCartRequest_CartId) isGetCartRequest_Cart() {}
+
+func (*GetCartRequest_UserId) isGetCartRequest_Cart() {}
+
+type AddCartItemRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The cart to add to. With neither set a new guest cart is created, and
+	// a user's cart is created on first use.
+	//
+	// Types that are valid to be assigned to Cart:
+	//
+	//	*AddCartItemRequest_CartId
+	//	*AddCartItemRequest_UserId
+	Cart          isAddCartItemRequest_Cart `protobuf_oneof:"cart"`
+	ProductId     string                    `protobuf:"bytes,3,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity      int32                     `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddCartItemRequest) Reset() {
+	*x = AddCartItemRequest{}
+	mi := &file_proto_cart_cart_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddCartItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddCartItemRequest) ProtoMessage() {}
+
+func (x *AddCartItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cart_cart_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddCartItemRequest.ProtoReflect.Descriptor instead.
+func (*AddCartItemRequest) Descriptor() ([]byte, []int) {
+	return file_proto_cart_cart_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *AddCartItemRequest) GetCart() isAddCartItemRequest_Cart {
+	if x != nil {
+		return x.Cart
+	}
+	return nil
+}
+
+func (x *AddCartItemRequest) GetCartId() string {
+	if x != nil {
+		if x, ok := x.Cart.(*AddCartItemRequest_CartId); ok {
+			return x.CartId
+		}
+	}
+	return ""
+}
+
+func (x *AddCartItemRequest) GetUserId() string {
+	if x != nil {
+		if x, ok := x.Cart.(*AddCartItemRequest_UserId); ok {
+			return x.UserId
+		}
+	}
+	return ""
+}
+
+func (x *AddCartItemRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *AddCartItemRequest) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+type isAddCartItemRequest_Cart interface {
+	isAddCartItemRequest_Cart()
+}
+
+type AddCartItemRequest_CartId struct {
+	CartId string `protobuf:"bytes,1,opt,name=cart_id,json=cartId,proto3,oneof"`
+}
+
+type AddCartItemRequest_UserId struct {
+	UserId string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3,oneof"`
+}
+
+func (*AddCartItemRequest_CartId) isAddCartItemRequest_Cart() {}
+
+func (*AddCartItemRequest_UserId) isAddCartItemRequest_Cart() {}
+
+type UpdateCartItemRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Cart:
+	//
+	//	*UpdateCartItemRequest_CartId
+	//	*UpdateCartItemRequest_UserId
+	Cart          isUpdateCartItemRequest_Cart `protobuf_oneof:"cart"`
+	ProductId     string                       `protobuf:"bytes,3,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity      int32                        `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateCartItemRequest) Reset() {
+	*x = UpdateCartItemRequest{}
+	mi := &file_proto_cart_cart_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCartItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCartItemRequest) ProtoMessage() {}
+
+func (x *UpdateCartItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cart_cart_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCartItemRequest.ProtoReflect.Descriptor instead.
+func (*UpdateCartItemRequest) Descriptor() ([]byte, []int) {
+	return file_proto_cart_cart_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *UpdateCartItemRequest) GetCart() isUpdateCartItemRequest_Cart {
+	if x != nil {
+		return x.Cart
+	}
+	return nil
+}
+
+func (x *UpdateCartItemRequest) GetCartId() string {
+	if x != nil {
+		if x, ok := x.Cart.(*UpdateCartItemRequest_CartId); ok {
+			return x.CartId
+		}
+	}
+	return ""
+}
+
+func (x *UpdateCartItemRequest) GetUserId() string {
+	if x != nil {
+		if x, ok := x.Cart.(*UpdateCartItemRequest_UserId); ok {
+			return x.UserId
+		}
+	}
+	return ""
+}
+
+func (x *UpdateCartItemRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *UpdateCartItemRequest) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+type isUpdateCartItemRequest_Cart interface {
+	isUpdateCartItemRequest_Cart()
+}
+
+type UpdateCartItemRequest_CartId struct {
+	CartId string `protobuf:"bytes,1,opt,name=cart_id,json=cartId,proto3,oneof"`
+}
+
+type UpdateCartItemRequest_UserId struct {
+	UserId string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3,oneof"`
+}
+
+func (*UpdateCartItemRequest_CartId) isUpdateCartItemRequest_Cart() {}
+
+func (*UpdateCartItemRequest_UserId) isUpdateCartItemRequest_Cart() {}
+
+type RemoveCartItemRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Cart:
+	//
+	//	*RemoveCartItemRequest_CartId
+	//	*RemoveCartItemRequest_UserId
+	Cart          isRemoveCartItemRequest_Cart `protobuf_oneof:"cart"`
+	ProductId     string                       `protobuf:"bytes,3,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveCartItemRequest) Reset() {
+	*x = RemoveCartItemRequest{}
+	mi := &file_proto_cart_cart_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveCartItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveCartItemRequest) ProtoMessage() {}
+
+func (x *RemoveCartItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cart_cart_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveCartItemRequest.ProtoReflect.Descriptor instead.
+func (*RemoveCartItemRequest) Descriptor() ([]byte, []int) {
+	return file_proto_cart_cart_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *RemoveCartItemRequest) GetCart() isRemoveCartItemRequest_Cart {
+	if x != nil {
+		return x.Cart
+	}
+	return nil
+}
+
+func (x *RemoveCartItemRequest) GetCartId() string {
+	if x != nil {
+		if x, ok := x.Cart.(*RemoveCartItemRequest_CartId); ok {
+			return x.CartId
+		}
+	}
+	return ""
+}
+
+func (x *RemoveCartItemRequest) GetUserId() string {
+	if x != nil {
+		if x, ok := x.Cart.(*RemoveCartItemRequest_UserId); ok {
+			return x.UserId
+		}
+	}
+	return ""
+}
+
+func (x *RemoveCartItemRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+type isRemoveCartItemRequest_Cart interface {
+	isRemoveCartItemRequest_Cart()
+}
+
+type RemoveCartItemRequest_CartId struct {
+	CartId string `protobuf:"bytes,1,opt,name=cart_id,json=cartId,proto3,oneof"`
+}
+
+type RemoveCartItemRequest_UserId struct {
+	UserId string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3,oneof"`
+}
+
+func (*RemoveCartItemRequest_CartId) isRemoveCartItemRequest_Cart() {}
+
+func (*RemoveCartItemRequest_UserId) isRemoveCartItemRequest_Cart() {}
+
+type MergeCartsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GuestCartId   string                 `protobuf:"bytes,1,opt,name=guest_cart_id,json=guestCartId,proto3" json:"guest_cart_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MergeCartsRequest) Reset() {
+	*x = MergeCartsRequest{}
+	mi := &file_proto_cart_cart_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergeCartsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeCartsRequest) ProtoMessage() {}
+
+func (x *MergeCartsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cart_cart_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeCartsRequest.ProtoReflect.Descriptor instead.
+func (*MergeCartsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_cart_cart_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *MergeCartsRequest) GetGuestCartId() string {
+	if x != nil {
+		return x.GuestCartId
+	}
+	return ""
+}
+
+func (x *MergeCartsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type CheckoutRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// The remaining fields are passed on to CreateOrder.
+	Currency       string   `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	ShippingRegion string   `protobuf:"bytes,3,opt,name=shipping_region,json=shippingRegion,proto3" json:"shipping_region,omitempty"`
+	CouponCodes    []string `protobuf:"bytes,4,rep,name=coupon_codes,json=couponCodes,proto3" json:"coupon_codes,omitempty"`
+	// Types that are valid to be assigned to Shipping:
+	//
+	//	*CheckoutRequest_ShippingAddressId
+	//	*CheckoutRequest_ShippingAddress
+	Shipping      isCheckoutRequest_Shipping `protobuf_oneof:"shipping"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckoutRequest) Reset() {
+	*x = CheckoutRequest{}
+	mi := &file_proto_cart_cart_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckoutRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckoutRequest) ProtoMessage() {}
+
+func (x *CheckoutRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cart_cart_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckoutRequest.ProtoReflect.Descriptor instead.
+func (*CheckoutRequest) Descriptor() ([]byte, []int) {
+	return file_proto_cart_cart_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CheckoutRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CheckoutRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *CheckoutRequest) GetShippingRegion() string {
+	if x != nil {
+		return x.ShippingRegion
+	}
+	return ""
+}
+
+func (x *CheckoutRequest) GetCouponCodes() []string {
+	if x != nil {
+		return x.CouponCodes
+	}
+	return nil
+}
+
+func (x *CheckoutRequest) GetShipping() isCheckoutRequest_Shipping {
+	if x != nil {
+		return x.Shipping
+	}
+	return nil
+}
+
+func (x *CheckoutRequest) GetShippingAddressId() string {
+	if x != nil {
+		if x, ok := x.Shipping.(*CheckoutRequest_ShippingAddressId); ok {
+			return x.ShippingAddressId
+		}
+	}
+	return ""
+}
+
+func (x *CheckoutRequest) GetShippingAddress() *user.PostalAddress {
+	if x != nil {
+		if x, ok := x.Shipping.(*CheckoutRequest_ShippingAddress); ok {
+			return x.ShippingAddress
+		}
+	}
+	return nil
+}
+
+type isCheckoutRequest_Shipping interface {
+	isCheckoutRequest_Shipping()
+}
+
+type CheckoutRequest_ShippingAddressId struct {
+	ShippingAddressId string `protobuf:"bytes,5,opt,name=shipping_address_id,json=shippingAddressId,proto3,oneof"`
+}
+
+type CheckoutRequest_ShippingAddress struct {
+	ShippingAddress *user.PostalAddress `protobuf:"bytes,6,opt,name=shipping_address,json=shippingAddress,proto3,oneof"`
+}
+
+func (*CheckoutRequest_ShippingAddressId) isCheckoutRequest_Shipping() {}
+
+func (*CheckoutRequest_ShippingAddress) isCheckoutRequest_Shipping() {}
+
+type CartResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cart          *Cart                  `protobuf:"bytes,1,opt,name=cart,proto3" json:"cart,omitempty"`
+	Warnings      []*CartWarning         `protobuf:"bytes,2,rep,name=warnings,proto3" json:"warnings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CartResponse) Reset() {
+	*x = CartResponse{}
+	mi := &file_proto_cart_cart_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CartResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CartResponse) ProtoMessage() {}
+
+func (x *CartResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cart_cart_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CartResponse.ProtoReflect.Descriptor instead.
+func (*CartResponse) Descriptor() ([]byte, []int) {
+	return file_proto_cart_cart_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CartResponse) GetCart() *Cart {
+	if x != nil {
+		return x.Cart
+	}
+	return nil
+}
+
+func (x *CartResponse) GetWarnings() []*CartWarning {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
+type CheckoutResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *order.Order           `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckoutResponse) Reset() {
+	*x = CheckoutResponse{}
+	mi := &file_proto_cart_cart_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckoutResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckoutResponse) ProtoMessage() {}
+
+func (x *CheckoutResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cart_cart_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckoutResponse.ProtoReflect.Descriptor instead.
+func (*CheckoutResponse) Descriptor() ([]byte, []int) {
+	return file_proto_cart_cart_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CheckoutResponse) GetOrder() *order.Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+var File_proto_cart_cart_proto protoreflect.FileDescriptor
+
+const file_proto_cart_cart_proto_rawDesc = "" +
+	"\n" +
+	"\x15proto/cart/cart.proto\x12\x04cart\x1a\x1bbuf/validate/validate.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x17proto/money/money.proto\x1a\x17proto/order/order.proto\x1a\x15proto/user/user.proto\"\xb0\x02\n" +
+	"\x04Cart\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12$\n" +
+	"\x05items\x18\x03 \x03(\v2\x0e.cart.CartItemR\x05items\x12(\n" +
+	"\bsubtotal\x18\x04 \x01(\v2\f.money.MoneyR\bsubtotal\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x129\n" +
+	"\n" +
+	"expires_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"\xf9\x01\n" +
+	"\bCartItem\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12!\n" +
+	"\fproduct_name\x18\x03 \x01(\tR\vproductName\x12+\n" +
+	"\n" +
+	"unit_price\x18\x04 \x01(\v2\f.money.MoneyR\tunitPrice\x12+\n" +
+	"\n" +
+	"line_total\x18\x05 \x01(\v2\f.money.MoneyR\tlineTotal\x125\n" +
+	"\badded_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\aaddedAt\"^\n" +
+	"\vCartWarning\x12\x16\n" +
+	"\x06reason\x18\x01 \x01(\tR\x06reason\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x03 \x01(\tR\tproductId\"_\n" +
+	"\x0eGetCartRequest\x12#\n" +
+	"\acart_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01H\x00R\x06cartId\x12\x19\n" +
+	"\auser_id\x18\x02 \x01(\tH\x00R\x06userIdB\r\n" +
+	"\x04cart\x12\x05\xbaH\x02\b\x01\"\xac\x01\n" +
+	"\x12AddCartItemRequest\x12#\n" +
+	"\acart_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01H\x00R\x06cartId\x12\x19\n" +
+	"\auser_id\x18\x02 \x01(\tH\x00R\x06userId\x12&\n" +
+	"\n" +
+	"product_id\x18\x03 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\tproductId\x12&\n" +
+	"\bquantity\x18\x04 \x01(\x05B\n" +
+	"\xbaH\a\x1a\x05\x18\xe8\a \x00R\bquantityB\x06\n" +
+	"\x04cart\"\xb6\x01\n" +
+	"\x15UpdateCartItemRequest\x12#\n" +
+	"\acart_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01H\x00R\x06cartId\x12\x19\n" +
+	"\auser_id\x18\x02 \x01(\tH\x00R\x06userId\x12&\n" +
+	"\n" +
+	"product_id\x18\x03 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\tproductId\x12&\n" +
+	"\bquantity\x18\x04 \x01(\x05B\n" +
+	"\xbaH\a\x1a\x05\x18\xe8\a \x00R\bquantityB\r\n" +
+	"\x04cart\x12\x05\xbaH\x02\b\x01\"\x8e\x01\n" +
+	"\x15RemoveCartItemRequest\x12#\n" +
+	"\acart_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01H\x00R\x06cartId\x12\x19\n" +
+	"\auser_id\x18\x02 \x01(\tH\x00R\x06userId\x12&\n" +
+	"\n" +
+	"product_id\x18\x03 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\tproductIdB\r\n" +
+	"\x04cart\x12\x05\xbaH\x02\b\x01\"c\n" +
+	"\x11MergeCartsRequest\x12,\n" +
+	"\rguest_cart_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\vguestCartId\x12 \n" +
+	"\auser_id\x18\x02 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x06userId\"\xec\x02\n" +
+	"\x0fCheckoutRequest\x12 \n" +
+	"\auser_id\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x06userId\x120\n" +
+	"\bcurrency\x18\x02 \x01(\tB\x14\xbaH\x11\xd8\x01\x01r\f2\n" +
+	"^[A-Z]{3}$R\bcurrency\x12N\n" +
+	"\x0fshipping_region\x18\x03 \x01(\tB%\xbaH\"\xd8\x01\x01r\x1d2\x1b^[A-Z]{2}(-[A-Z0-9]{1,3})?$R\x0eshippingRegion\x125\n" +
+	"\fcoupon_codes\x18\x04 \x03(\tB\x12\xbaH\x0f\x92\x01\f\x10\x05\x18\x01\"\x06r\x04\x10\x01\x18 R\vcouponCodes\x120\n" +
+	"\x13shipping_address_id\x18\x05 \x01(\tH\x00R\x11shippingAddressId\x12@\n" +
+	"\x10shipping_address\x18\x06 \x01(\v2\x13.user.PostalAddressH\x00R\x0fshippingAddressB\n" +
+	"\n" +
+	"\bshipping\"]\n" +
+	"\fCartResponse\x12\x1e\n" +
+	"\x04cart\x18\x01 \x01(\v2\n" +
+	".cart.CartR\x04cart\x12-\n" +
+	"\bwarnings\x18\x02 \x03(\v2\x11.cart.CartWarningR\bwarnings\"6\n" +
+	"\x10CheckoutResponse\x12\"\n" +
+	"\x05order\x18\x01 \x01(\v2\f.order.OrderR\x05order2\x87\x03\n" +
+	"\vCartService\x125\n" +
+	"\aGetCart\x12\x14.cart.GetCartRequest\x1a\x12.cart.CartResponse\"\x00\x12=\n" +
+	"\vAddCartItem\x12\x18.cart.AddCartItemRequest\x1a\x12.cart.CartResponse\"\x00\x12C\n" +
+	"\x0eUpdateCartItem\x12\x1b.cart.UpdateCartItemRequest\x1a\x12.cart.CartResponse\"\x00\x12C\n" +
+	"\x0eRemoveCartItem\x12\x1b.cart.RemoveCartItemRequest\x1a\x12.cart.CartResponse\"\x00\x12;\n" +
+	"\n" +
+	"MergeCarts\x12\x17.cart.MergeCartsRequest\x1a\x12.cart.CartResponse\"\x00\x12;\n" +
+	"\bCheckout\x12\x15.cart.CheckoutRequest\x1a\x16.cart.CheckoutResponse\"\x00B<Z:github.com/dipendra-mule/microservice-with-grpc/proto/cartb\x06proto3"
+
+var (
+	file_proto_cart_cart_proto_rawDescOnce sync.Once
+	file_proto_cart_cart_proto_rawDescData []byte
+)
+
+func file_proto_cart_cart_proto_rawDescGZIP() []byte {
+	file_proto_cart_cart_proto_rawDescOnce.Do(func() {
+		file_proto_cart_cart_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_cart_cart_proto_rawDesc), len(file_proto_cart_cart_proto_rawDesc)))
+	})
+	return file_proto_cart_cart_proto_rawDescData
+}
+
+var file_proto_cart_cart_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_proto_cart_cart_proto_goTypes = []any{
+	(*Cart)(nil),                  // 0: cart.Cart
+	(*CartItem)(nil),              // 1: cart.CartItem
+	(*CartWarning)(nil),           // 2: cart.CartWarning
+	(*GetCartRequest)(nil),        // 3: cart.GetCartRequest
+	(*AddCartItemRequest)(nil),    // 4: cart.AddCartItemRequest
+	(*UpdateCartItemRequest)(nil), // 5: cart.UpdateCartItemRequest
+	(*RemoveCartItemRequest)(nil), // 6: cart.RemoveCartItemRequest
+	(*MergeCartsRequest)(nil),     // 7: cart.MergeCartsRequest
+	(*CheckoutRequest)(nil),       // 8: cart.CheckoutRequest
+	(*CartResponse)(nil),          // 9: cart.CartResponse
+	(*CheckoutResponse)(nil),      // 10: cart.CheckoutResponse
+	(*money.Money)(nil),           // 11: money.Money
+	(*timestamppb.Timestamp)(nil), // 12: google.protobuf.Timestamp
+	(*user.PostalAddress)(nil),    // 13: user.PostalAddress
+	(*order.Order)(nil),           // 14: order.Order
+}
+var file_proto_cart_cart_proto_depIdxs = []int32{
+	1,  // 0: cart.Cart.items:type_name -> cart.CartItem
+	11, // 1: cart.Cart.subtotal:type_name -> money.Money
+	12, // 2: cart.Cart.created_at:type_name -> google.protobuf.Timestamp
+	12, // 3: cart.Cart.updated_at:type_name -> google.protobuf.Timestamp
+	12, // 4: cart.Cart.expires_at:type_name -> google.protobuf.Timestamp
+	11, // 5: cart.CartItem.unit_price:type_name -> money.Money
+	11, // 6: cart.CartItem.line_total:type_name -> money.Money
+	12, // 7: cart.CartItem.added_at:type_name -> google.protobuf.Timestamp
+	13, // 8: cart.CheckoutRequest.shipping_address:type_name -> user.PostalAddress
+	0,  // 9: cart.CartResponse.cart:type_name -> cart.Cart
+	2,  // 10: cart.CartResponse.warnings:type_name -> cart.CartWarning
+	14, // 11: cart.CheckoutResponse.order:type_name -> order.Order
+	3,  // 12: cart.CartService.GetCart:input_type -> cart.GetCartRequest
+	4,  // 13: cart.CartService.AddCartItem:input_type -> cart.AddCartItemRequest
+	5,  // 14: cart.CartService.UpdateCartItem:input_type -> cart.UpdateCartItemRequest
+	6,  // 15: cart.CartService.RemoveCartItem:input_type -> cart.RemoveCartItemRequest
+	7,  // 16: cart.CartService.MergeCarts:input_type -> cart.MergeCartsRequest
+	8,  // 17: cart.CartService.Checkout:input_type -> cart.CheckoutRequest
+	9,  // 18: cart.CartService.GetCart:output_type -> cart.CartResponse
+	9,  // 19: cart.CartService.AddCartItem:output_type -> cart.CartResponse
+	9,  // 20: cart.CartService.UpdateCartItem:output_type -> cart.CartResponse
+	9,  // 21: cart.CartService.RemoveCartItem:output_type -> cart.CartResponse
+	9,  // 22: cart.CartService.MergeCarts:output_type -> cart.CartResponse
+	10, // 23: cart.CartService.Checkout:output_type -> cart.CheckoutResponse
+	18, // [18:24] is the sub-list for method output_type
+	12, // [12:18] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
+}
+
+func init() { file_proto_cart_cart_proto_init() }
+func file_proto_cart_cart_proto_init() {
+	if File_proto_cart_cart_proto != nil {
+		return
+	}
+	file_proto_cart_cart_proto_msgTypes[3].OneofWrappers = []any{
+		(*GetCartRequest_CartId)(nil),
+		(*GetCartRequest_UserId)(nil),
+	}
+	file_proto_cart_cart_proto_msgTypes[4].OneofWrappers = []any{
+		(*AddCartItemRequest_CartId)(nil),
+		(*AddCartItemRequest_UserId)(nil),
+	}
+	file_proto_cart_cart_proto_msgTypes[5].OneofWrappers = []any{
+		(*UpdateCartItemRequest_CartId)(nil),
+		(*UpdateCartItemRequest_UserId)(nil),
+	}
+	file_proto_cart_cart_proto_msgTypes[6].OneofWrappers = []any{
+		(*RemoveCartItemRequest_CartId)(nil),
+		(*RemoveCartItemRequest_UserId)(nil),
+	}
+	file_proto_cart_cart_proto_msgTypes[8].OneofWrappers = []any{
+		(*CheckoutRequest_ShippingAddressId)(nil),
+		(*CheckoutRequest_ShippingAddress)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_cart_cart_proto_rawDesc), len(file_proto_cart_cart_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_cart_cart_proto_goTypes,
+		DependencyIndexes: file_proto_cart_cart_proto_depIdxs,
+		MessageInfos:      file_proto_cart_cart_proto_msgTypes,
+	}.Build()
+	File_proto_cart_cart_proto = out.File
+	file_proto_cart_cart_proto_goTypes = nil
+	file_proto_cart_cart_proto_depIdxs = nil
+}
@@ -7,6 +7,8 @@
 package order
 
 import (
+	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	money "github.com/dipendra-mule/microservice-with-grpc/proto/money"
 	user "github.com/dipendra-mule/microservice-with-grpc/proto/user"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
@@ -24,17 +26,33 @@ const (
 )
 
 type Order struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Items         []*OrderItem           `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`
-	TotalAmount   float32                `protobuf:"fixed32,4,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"`
-	Status        string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	User          *user.User             `protobuf:"bytes,8,opt,name=user,proto3" json:"user,omitempty"` // User info from user service
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Id     string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Items  []*OrderItem           `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`
+	// Deprecated: Marked as deprecated in proto/order/order.proto.
+	TotalAmount        float32                `protobuf:"fixed32,4,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"` // Use total
+	Status             string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	CreatedAt          *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt          *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	User               *user.User             `protobuf:"bytes,8,opt,name=user,proto3" json:"user,omitempty"`                                         // User info from user service
+	Total              *money.Money           `protobuf:"bytes,9,opt,name=total,proto3" json:"total,omitempty"`                                       // subtotal + tax_total
+	ExchangeRates      []*ExchangeRate        `protobuf:"bytes,10,rep,name=exchange_rates,json=exchangeRates,proto3" json:"exchange_rates,omitempty"` // Rates applied when pricing the order
+	Subtotal           *money.Money           `protobuf:"bytes,11,opt,name=subtotal,proto3" json:"subtotal,omitempty"`                                // Sum of the items' net amounts after discounts
+	TaxTotal           *money.Money           `protobuf:"bytes,12,opt,name=tax_total,json=taxTotal,proto3" json:"tax_total,omitempty"`
+	ShippingRegion     string                 `protobuf:"bytes,13,opt,name=shipping_region,json=shippingRegion,proto3" json:"shipping_region,omitempty"`
+	TaxInclusive       bool                   `protobuf:"varint,14,opt,name=tax_inclusive,json=taxInclusive,proto3" json:"tax_inclusive,omitempty"` // Unit prices include tax
+	Adjustments        []*Adjustment          `protobuf:"bytes,15,rep,name=adjustments,proto3" json:"adjustments,omitempty"`
+	DiscountTotal      *money.Money           `protobuf:"bytes,16,opt,name=discount_total,json=discountTotal,proto3" json:"discount_total,omitempty"` // Sum of discounts, as a positive amount
+	RefundedTotal      *money.Money           `protobuf:"bytes,17,opt,name=refunded_total,json=refundedTotal,proto3" json:"refunded_total,omitempty"`
+	CancellationReason string                 `protobuf:"bytes,18,opt,name=cancellation_reason,json=cancellationReason,proto3" json:"cancellation_reason,omitempty"`
+	CancelledAt        *timestamppb.Timestamp `protobuf:"bytes,19,opt,name=cancelled_at,json=cancelledAt,proto3" json:"cancelled_at,omitempty"`
+	// Where the order ships, copied when it was placed so that later edits
+	// to the address book leave it unchanged.
+	ShippingAddress   *user.PostalAddress `protobuf:"bytes,20,opt,name=shipping_address,json=shippingAddress,proto3" json:"shipping_address,omitempty"`
+	ShippingAddressId string              `protobuf:"bytes,21,opt,name=shipping_address_id,json=shippingAddressId,proto3" json:"shipping_address_id,omitempty"` // Address book entry it was copied from, if any
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *Order) Reset() {
@@ -88,6 +106,7 @@ func (x *Order) GetItems() []*OrderItem {
 	return nil
 }
 
+// Deprecated: Marked as deprecated in proto/order/order.proto.
 func (x *Order) GetTotalAmount() float32 {
 	if x != nil {
 		return x.TotalAmount
@@ -97,57 +116,1256 @@ func (x *Order) GetTotalAmount() float32 {
 
 func (x *Order) GetStatus() string {
 	if x != nil {
-		return x.Status
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Order) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Order) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Order) GetUser() *user.User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *Order) GetTotal() *money.Money {
+	if x != nil {
+		return x.Total
+	}
+	return nil
+}
+
+func (x *Order) GetExchangeRates() []*ExchangeRate {
+	if x != nil {
+		return x.ExchangeRates
+	}
+	return nil
+}
+
+func (x *Order) GetSubtotal() *money.Money {
+	if x != nil {
+		return x.Subtotal
+	}
+	return nil
+}
+
+func (x *Order) GetTaxTotal() *money.Money {
+	if x != nil {
+		return x.TaxTotal
+	}
+	return nil
+}
+
+func (x *Order) GetShippingRegion() string {
+	if x != nil {
+		return x.ShippingRegion
+	}
+	return ""
+}
+
+func (x *Order) GetTaxInclusive() bool {
+	if x != nil {
+		return x.TaxInclusive
+	}
+	return false
+}
+
+func (x *Order) GetAdjustments() []*Adjustment {
+	if x != nil {
+		return x.Adjustments
+	}
+	return nil
+}
+
+func (x *Order) GetDiscountTotal() *money.Money {
+	if x != nil {
+		return x.DiscountTotal
+	}
+	return nil
+}
+
+func (x *Order) GetRefundedTotal() *money.Money {
+	if x != nil {
+		return x.RefundedTotal
+	}
+	return nil
+}
+
+func (x *Order) GetCancellationReason() string {
+	if x != nil {
+		return x.CancellationReason
+	}
+	return ""
+}
+
+func (x *Order) GetCancelledAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CancelledAt
+	}
+	return nil
+}
+
+func (x *Order) GetShippingAddress() *user.PostalAddress {
+	if x != nil {
+		return x.ShippingAddress
+	}
+	return nil
+}
+
+func (x *Order) GetShippingAddressId() string {
+	if x != nil {
+		return x.ShippingAddressId
+	}
+	return ""
+}
+
+// Adjustment is a change to an order's price, such as a discount from a
+// coupon code, attributed to the item it applies to.
+type Adjustment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kind          string                 `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"` // "promotion"
+	PromotionId   string                 `protobuf:"bytes,2,opt,name=promotion_id,json=promotionId,proto3" json:"promotion_id,omitempty"`
+	Code          string                 `protobuf:"bytes,3,opt,name=code,proto3" json:"code,omitempty"`
+	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	ProductId     string                 `protobuf:"bytes,5,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Amount        *money.Money           `protobuf:"bytes,6,opt,name=amount,proto3" json:"amount,omitempty"` // Negative for discounts
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Adjustment) Reset() {
+	*x = Adjustment{}
+	mi := &file_proto_order_order_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Adjustment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Adjustment) ProtoMessage() {}
+
+func (x *Adjustment) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_order_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Adjustment.ProtoReflect.Descriptor instead.
+func (*Adjustment) Descriptor() ([]byte, []int) {
+	return file_proto_order_order_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Adjustment) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Adjustment) GetPromotionId() string {
+	if x != nil {
+		return x.PromotionId
+	}
+	return ""
+}
+
+func (x *Adjustment) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Adjustment) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Adjustment) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *Adjustment) GetAmount() *money.Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+// TaxLine is one tax levied on an order item, as computed when the order
+// was placed.
+type TaxLine struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Rate          string                 `protobuf:"bytes,2,opt,name=rate,proto3" json:"rate,omitempty"` // Decimal, e.g. "0.0725"
+	Amount        *money.Money           `protobuf:"bytes,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaxLine) Reset() {
+	*x = TaxLine{}
+	mi := &file_proto_order_order_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaxLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaxLine) ProtoMessage() {}
+
+func (x *TaxLine) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_order_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaxLine.ProtoReflect.Descriptor instead.
+func (*TaxLine) Descriptor() ([]byte, []int) {
+	return file_proto_order_order_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *TaxLine) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TaxLine) GetRate() string {
+	if x != nil {
+		return x.Rate
+	}
+	return ""
+}
+
+func (x *TaxLine) GetAmount() *money.Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+// ExchangeRate records a conversion applied to an order's prices.
+type ExchangeRate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FromCurrency  string                 `protobuf:"bytes,1,opt,name=from_currency,json=fromCurrency,proto3" json:"from_currency,omitempty"`
+	ToCurrency    string                 `protobuf:"bytes,2,opt,name=to_currency,json=toCurrency,proto3" json:"to_currency,omitempty"`
+	Rate          string                 `protobuf:"bytes,3,opt,name=rate,proto3" json:"rate,omitempty"` // Decimal, price of one unit of from_currency in to_currency
+	AsOf          *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty"`
+	Source        string                 `protobuf:"bytes,5,opt,name=source,proto3" json:"source,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExchangeRate) Reset() {
+	*x = ExchangeRate{}
+	mi := &file_proto_order_order_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExchangeRate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExchangeRate) ProtoMessage() {}
+
+func (x *ExchangeRate) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_order_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExchangeRate.ProtoReflect.Descriptor instead.
+func (*ExchangeRate) Descriptor() ([]byte, []int) {
+	return file_proto_order_order_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ExchangeRate) GetFromCurrency() string {
+	if x != nil {
+		return x.FromCurrency
+	}
+	return ""
+}
+
+func (x *ExchangeRate) GetToCurrency() string {
+	if x != nil {
+		return x.ToCurrency
+	}
+	return ""
+}
+
+func (x *ExchangeRate) GetRate() string {
+	if x != nil {
+		return x.Rate
+	}
+	return ""
+}
+
+func (x *ExchangeRate) GetAsOf() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AsOf
+	}
+	return nil
+}
+
+func (x *ExchangeRate) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+type OrderItem struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProductId string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity  int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	// Deprecated: Marked as deprecated in proto/order/order.proto.
+	Price             float32      `protobuf:"fixed32,3,opt,name=price,proto3" json:"price,omitempty"` // Use unit_price
+	ProductName       string       `protobuf:"bytes,4,opt,name=product_name,json=productName,proto3" json:"product_name,omitempty"`
+	UnitPrice         *money.Money `protobuf:"bytes,5,opt,name=unit_price,json=unitPrice,proto3" json:"unit_price,omitempty"`
+	OriginalUnitPrice *money.Money `protobuf:"bytes,6,opt,name=original_unit_price,json=originalUnitPrice,proto3" json:"original_unit_price,omitempty"` // Product's own price, set when it was converted
+	Subtotal          *money.Money `protobuf:"bytes,7,opt,name=subtotal,proto3" json:"subtotal,omitempty"`                                              // Line amount after discounts, excluding tax
+	Tax               *money.Money `protobuf:"bytes,8,opt,name=tax,proto3" json:"tax,omitempty"`
+	TaxLines          []*TaxLine   `protobuf:"bytes,9,rep,name=tax_lines,json=taxLines,proto3" json:"tax_lines,omitempty"`
+	Discount          *money.Money `protobuf:"bytes,10,opt,name=discount,proto3" json:"discount,omitempty"` // Discounts on the line, as a positive amount
+	RefundedQuantity  int32        `protobuf:"varint,11,opt,name=refunded_quantity,json=refundedQuantity,proto3" json:"refunded_quantity,omitempty"`
+	RefundedAmount    *money.Money `protobuf:"bytes,12,opt,name=refunded_amount,json=refundedAmount,proto3" json:"refunded_amount,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *OrderItem) Reset() {
+	*x = OrderItem{}
+	mi := &file_proto_order_order_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderItem) ProtoMessage() {}
+
+func (x *OrderItem) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_order_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderItem.ProtoReflect.Descriptor instead.
+func (*OrderItem) Descriptor() ([]byte, []int) {
+	return file_proto_order_order_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *OrderItem) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *OrderItem) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+// Deprecated: Marked as deprecated in proto/order/order.proto.
+func (x *OrderItem) GetPrice() float32 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *OrderItem) GetProductName() string {
+	if x != nil {
+		return x.ProductName
+	}
+	return ""
+}
+
+func (x *OrderItem) GetUnitPrice() *money.Money {
+	if x != nil {
+		return x.UnitPrice
+	}
+	return nil
+}
+
+func (x *OrderItem) GetOriginalUnitPrice() *money.Money {
+	if x != nil {
+		return x.OriginalUnitPrice
+	}
+	return nil
+}
+
+func (x *OrderItem) GetSubtotal() *money.Money {
+	if x != nil {
+		return x.Subtotal
+	}
+	return nil
+}
+
+func (x *OrderItem) GetTax() *money.Money {
+	if x != nil {
+		return x.Tax
+	}
+	return nil
+}
+
+func (x *OrderItem) GetTaxLines() []*TaxLine {
+	if x != nil {
+		return x.TaxLines
+	}
+	return nil
+}
+
+func (x *OrderItem) GetDiscount() *money.Money {
+	if x != nil {
+		return x.Discount
+	}
+	return nil
+}
+
+func (x *OrderItem) GetRefundedQuantity() int32 {
+	if x != nil {
+		return x.RefundedQuantity
+	}
+	return 0
+}
+
+func (x *OrderItem) GetRefundedAmount() *money.Money {
+	if x != nil {
+		return x.RefundedAmount
+	}
+	return nil
+}
+
+type CreateOrderRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Each product may be listed once.
+	Items []*OrderItemRequest `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	// Currency to price the order in. Defaults to the products' currency, in
+	// which case all products must share one.
+	Currency string `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`
+	// Region the order ships to, e.g. "US-CA" or "DE", which selects the
+	// tax rules.
+	ShippingRegion string `protobuf:"bytes,4,opt,name=shipping_region,json=shippingRegion,proto3" json:"shipping_region,omitempty"`
+	// Discount codes, applied in the given order.
+	CouponCodes []string `protobuf:"bytes,5,rep,name=coupon_codes,json=couponCodes,proto3" json:"coupon_codes,omitempty"`
+	// Makes retries safe: a retry with the same key and request returns the
+	// original order instead of creating another. May instead be sent as
+	// Idempotency-Key metadata or HTTP header. Keys are scoped to user_id
+	// and remembered for a limited time.
+	IdempotencyKey string `protobuf:"bytes,6,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	// Where to ship: an entry of the user's address book or an address
+	// given inline. Defaults to the user's default shipping address, if
+	// any. Unless shipping_region is set it is derived from the address.
+	//
+	// Types that are valid to be assigned to Shipping:
+	//
+	//	*CreateOrderRequest_ShippingAddressId
+	//	*CreateOrderRequest_ShippingAddress
+	Shipping      isCreateOrderRequest_Shipping `protobuf_oneof:"shipping"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateOrderRequest) Reset() {
+	*x = CreateOrderRequest{}
+	mi := &file_proto_order_order_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOrderRequest) ProtoMessage() {}
+
+func (x *CreateOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_order_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOrderRequest.ProtoReflect.Descriptor instead.
+func (*CreateOrderRequest) Descriptor() ([]byte, []int) {
+	return file_proto_order_order_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CreateOrderRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CreateOrderRequest) GetItems() []*OrderItemRequest {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *CreateOrderRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *CreateOrderRequest) GetShippingRegion() string {
+	if x != nil {
+		return x.ShippingRegion
+	}
+	return ""
+}
+
+func (x *CreateOrderRequest) GetCouponCodes() []string {
+	if x != nil {
+		return x.CouponCodes
+	}
+	return nil
+}
+
+func (x *CreateOrderRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *CreateOrderRequest) GetShipping() isCreateOrderRequest_Shipping {
+	if x != nil {
+		return x.Shipping
+	}
+	return nil
+}
+
+func (x *CreateOrderRequest) GetShippingAddressId() string {
+	if x != nil {
+		if x, ok := x.Shipping.(*CreateOrderRequest_ShippingAddressId); ok {
+			return x.ShippingAddressId
+		}
+	}
+	return ""
+}
+
+func (x *CreateOrderRequest) GetShippingAddress() *user.PostalAddress {
+	if x != nil {
+		if x, ok := x.Shipping.(*CreateOrderRequest_ShippingAddress); ok {
+			return x.ShippingAddress
+		}
+	}
+	return nil
+}
+
+type isCreateOrderRequest_Shipping interface {
+	isCreateOrderRequest_Shipping()
+}
+
+type CreateOrderRequest_ShippingAddressId struct {
+	ShippingAddressId string `protobuf:"bytes,7,opt,name=shipping_address_id,json=shippingAddressId,proto3,oneof"`
+}
+
+type CreateOrderRequest_ShippingAddress struct {
+	ShippingAddress *user.PostalAddress `protobuf:"bytes,8,opt,name=shipping_address,json=shippingAddress,proto3,oneof"`
+}
+
+func (*CreateOrderRequest_ShippingAddressId) isCreateOrderRequest_Shipping() {}
+
+func (*CreateOrderRequest_ShippingAddress) isCreateOrderRequest_Shipping() {}
+
+type OrderItemRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderItemRequest) Reset() {
+	*x = OrderItemRequest{}
+	mi := &file_proto_order_order_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderItemRequest) ProtoMessage() {}
+
+func (x *OrderItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_order_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderItemRequest.ProtoReflect.Descriptor instead.
+func (*OrderItemRequest) Descriptor() ([]byte, []int) {
+	return file_proto_order_order_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *OrderItemRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *OrderItemRequest) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+type GetOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	IncludeUser   bool                   `protobuf:"varint,2,opt,name=include_user,json=includeUser,proto3" json:"include_user,omitempty"` // Populate Order.user from the user service
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderRequest) Reset() {
+	*x = GetOrderRequest{}
+	mi := &file_proto_order_order_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderRequest) ProtoMessage() {}
+
+func (x *GetOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_order_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderRequest.ProtoReflect.Descriptor instead.
+func (*GetOrderRequest) Descriptor() ([]byte, []int) {
+	return file_proto_order_order_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetOrderRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetOrderRequest) GetIncludeUser() bool {
+	if x != nil {
+		return x.IncludeUser
+	}
+	return false
+}
+
+type ListOrdersRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Deprecated: 1-based page number for offset pagination; use page_token.
+	Page        int32 `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	Limit       int32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	IncludeUser bool  `protobuf:"varint,4,opt,name=include_user,json=includeUser,proto3" json:"include_user,omitempty"` // Populate Order.user from the user service
+	// Opaque token from a previous response's next_page_token. Takes
+	// precedence over page.
+	PageToken string `protobuf:"bytes,5,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Compute total, which costs an extra COUNT query. Always computed in
+	// the legacy page mode.
+	IncludeTotal  bool `protobuf:"varint,6,opt,name=include_total,json=includeTotal,proto3" json:"include_total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrdersRequest) Reset() {
+	*x = ListOrdersRequest{}
+	mi := &file_proto_order_order_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrdersRequest) ProtoMessage() {}
+
+func (x *ListOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_order_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrdersRequest.ProtoReflect.Descriptor instead.
+func (*ListOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_proto_order_order_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListOrdersRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListOrdersRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListOrdersRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListOrdersRequest) GetIncludeUser() bool {
+	if x != nil {
+		return x.IncludeUser
+	}
+	return false
+}
+
+func (x *ListOrdersRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListOrdersRequest) GetIncludeTotal() bool {
+	if x != nil {
+		return x.IncludeTotal
+	}
+	return false
+}
+
+type ListOrdersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Orders        []*Order               `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"` // Only set when requested, see include_total
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	Warnings      []*Warning             `protobuf:"bytes,5,rep,name=warnings,proto3" json:"warnings,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,6,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // Empty on the last page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrdersResponse) Reset() {
+	*x = ListOrdersResponse{}
+	mi := &file_proto_order_order_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrdersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrdersResponse) ProtoMessage() {}
+
+func (x *ListOrdersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_order_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrdersResponse.ProtoReflect.Descriptor instead.
+func (*ListOrdersResponse) Descriptor() ([]byte, []int) {
+	return file_proto_order_order_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListOrdersResponse) GetOrders() []*Order {
+	if x != nil {
+		return x.Orders
+	}
+	return nil
+}
+
+func (x *ListOrdersResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListOrdersResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListOrdersResponse) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListOrdersResponse) GetWarnings() []*Warning {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
+func (x *ListOrdersResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type UpdateOrderStatusRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	OrderId string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	// Only pending to confirmed may be set directly. "cancelled" is handled
+	// as CancelOrder; orders become shipped and delivered through their
+	// shipments. Other changes fail with FAILED_PRECONDITION.
+	Status string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	// Recorded in the order's history.
+	Reason        string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateOrderStatusRequest) Reset() {
+	*x = UpdateOrderStatusRequest{}
+	mi := &file_proto_order_order_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateOrderStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateOrderStatusRequest) ProtoMessage() {}
+
+func (x *UpdateOrderStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_order_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateOrderStatusRequest.ProtoReflect.Descriptor instead.
+func (*UpdateOrderStatusRequest) Descriptor() ([]byte, []int) {
+	return file_proto_order_order_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *UpdateOrderStatusRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *UpdateOrderStatusRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *UpdateOrderStatusRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type CancelOrderRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	OrderId string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Reason  string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	// Deprecated: ignored. Whether the customer or an admin cancelled is
+	// taken from the caller's token.
+	//
+	// Deprecated: Marked as deprecated in proto/order/order.proto.
+	CancelledBy   string `protobuf:"bytes,3,opt,name=cancelled_by,json=cancelledBy,proto3" json:"cancelled_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelOrderRequest) Reset() {
+	*x = CancelOrderRequest{}
+	mi := &file_proto_order_order_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelOrderRequest) ProtoMessage() {}
+
+func (x *CancelOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_order_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelOrderRequest.ProtoReflect.Descriptor instead.
+func (*CancelOrderRequest) Descriptor() ([]byte, []int) {
+	return file_proto_order_order_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CancelOrderRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *CancelOrderRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// Deprecated: Marked as deprecated in proto/order/order.proto.
+func (x *CancelOrderRequest) GetCancelledBy() string {
+	if x != nil {
+		return x.CancelledBy
+	}
+	return ""
+}
+
+type RefundOrderRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	OrderId string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Reason  string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	// Quantities to refund. Empty refunds everything not refunded yet.
+	Items         []*RefundItem `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefundOrderRequest) Reset() {
+	*x = RefundOrderRequest{}
+	mi := &file_proto_order_order_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefundOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefundOrderRequest) ProtoMessage() {}
+
+func (x *RefundOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_order_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefundOrderRequest.ProtoReflect.Descriptor instead.
+func (*RefundOrderRequest) Descriptor() ([]byte, []int) {
+	return file_proto_order_order_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *RefundOrderRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *RefundOrderRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *RefundOrderRequest) GetItems() []*RefundItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type RefundItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefundItem) Reset() {
+	*x = RefundItem{}
+	mi := &file_proto_order_order_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefundItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefundItem) ProtoMessage() {}
+
+func (x *RefundItem) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_order_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefundItem.ProtoReflect.Descriptor instead.
+func (*RefundItem) Descriptor() ([]byte, []int) {
+	return file_proto_order_order_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *RefundItem) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *RefundItem) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+// Refund records money returned for part or all of an order. Each line's
+// amount is its share of what was paid for the item, discounts and tax
+// included.
+type Refund struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrderId       string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Items         []*RefundLine          `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`
+	Amount        *money.Money           `protobuf:"bytes,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	Reason        string                 `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Refund) Reset() {
+	*x = Refund{}
+	mi := &file_proto_order_order_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Refund) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Refund) ProtoMessage() {}
+
+func (x *Refund) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_order_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Refund.ProtoReflect.Descriptor instead.
+func (*Refund) Descriptor() ([]byte, []int) {
+	return file_proto_order_order_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Refund) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Refund) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
 	}
 	return ""
 }
 
-func (x *Order) GetCreatedAt() *timestamppb.Timestamp {
+func (x *Refund) GetItems() []*RefundLine {
 	if x != nil {
-		return x.CreatedAt
+		return x.Items
 	}
 	return nil
 }
 
-func (x *Order) GetUpdatedAt() *timestamppb.Timestamp {
+func (x *Refund) GetAmount() *money.Money {
 	if x != nil {
-		return x.UpdatedAt
+		return x.Amount
 	}
 	return nil
 }
 
-func (x *Order) GetUser() *user.User {
+func (x *Refund) GetReason() string {
 	if x != nil {
-		return x.User
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *Refund) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
 	}
 	return nil
 }
 
-type OrderItem struct {
+type RefundLine struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
 	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
-	Price         float32                `protobuf:"fixed32,3,opt,name=price,proto3" json:"price,omitempty"`
-	ProductName   string                 `protobuf:"bytes,4,opt,name=product_name,json=productName,proto3" json:"product_name,omitempty"`
+	Amount        *money.Money           `protobuf:"bytes,3,opt,name=amount,proto3" json:"amount,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *OrderItem) Reset() {
-	*x = OrderItem{}
-	mi := &file_proto_order_order_proto_msgTypes[1]
+func (x *RefundLine) Reset() {
+	*x = RefundLine{}
+	mi := &file_proto_order_order_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *OrderItem) String() string {
+func (x *RefundLine) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OrderItem) ProtoMessage() {}
+func (*RefundLine) ProtoMessage() {}
 
-func (x *OrderItem) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_order_order_proto_msgTypes[1]
+func (x *RefundLine) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_order_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -158,62 +1376,55 @@ func (x *OrderItem) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OrderItem.ProtoReflect.Descriptor instead.
-func (*OrderItem) Descriptor() ([]byte, []int) {
-	return file_proto_order_order_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use RefundLine.ProtoReflect.Descriptor instead.
+func (*RefundLine) Descriptor() ([]byte, []int) {
+	return file_proto_order_order_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *OrderItem) GetProductId() string {
+func (x *RefundLine) GetProductId() string {
 	if x != nil {
 		return x.ProductId
 	}
 	return ""
 }
 
-func (x *OrderItem) GetQuantity() int32 {
+func (x *RefundLine) GetQuantity() int32 {
 	if x != nil {
 		return x.Quantity
 	}
 	return 0
 }
 
-func (x *OrderItem) GetPrice() float32 {
-	if x != nil {
-		return x.Price
-	}
-	return 0
-}
-
-func (x *OrderItem) GetProductName() string {
+func (x *RefundLine) GetAmount() *money.Money {
 	if x != nil {
-		return x.ProductName
+		return x.Amount
 	}
-	return ""
+	return nil
 }
 
-type CreateOrderRequest struct {
+type RefundOrderResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Items         []*OrderItemRequest    `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	Refund        *Refund                `protobuf:"bytes,2,opt,name=refund,proto3" json:"refund,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateOrderRequest) Reset() {
-	*x = CreateOrderRequest{}
-	mi := &file_proto_order_order_proto_msgTypes[2]
+func (x *RefundOrderResponse) Reset() {
+	*x = RefundOrderResponse{}
+	mi := &file_proto_order_order_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateOrderRequest) String() string {
+func (x *RefundOrderResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateOrderRequest) ProtoMessage() {}
+func (*RefundOrderResponse) ProtoMessage() {}
 
-func (x *CreateOrderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_order_order_proto_msgTypes[2]
+func (x *RefundOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_order_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -224,48 +1435,47 @@ func (x *CreateOrderRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateOrderRequest.ProtoReflect.Descriptor instead.
-func (*CreateOrderRequest) Descriptor() ([]byte, []int) {
-	return file_proto_order_order_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use RefundOrderResponse.ProtoReflect.Descriptor instead.
+func (*RefundOrderResponse) Descriptor() ([]byte, []int) {
+	return file_proto_order_order_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *CreateOrderRequest) GetUserId() string {
+func (x *RefundOrderResponse) GetOrder() *Order {
 	if x != nil {
-		return x.UserId
+		return x.Order
 	}
-	return ""
+	return nil
 }
 
-func (x *CreateOrderRequest) GetItems() []*OrderItemRequest {
+func (x *RefundOrderResponse) GetRefund() *Refund {
 	if x != nil {
-		return x.Items
+		return x.Refund
 	}
 	return nil
 }
 
-type OrderItemRequest struct {
+type GetOrderHistoryRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
-	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *OrderItemRequest) Reset() {
-	*x = OrderItemRequest{}
-	mi := &file_proto_order_order_proto_msgTypes[3]
+func (x *GetOrderHistoryRequest) Reset() {
+	*x = GetOrderHistoryRequest{}
+	mi := &file_proto_order_order_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *OrderItemRequest) String() string {
+func (x *GetOrderHistoryRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OrderItemRequest) ProtoMessage() {}
+func (*GetOrderHistoryRequest) ProtoMessage() {}
 
-func (x *OrderItemRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_order_order_proto_msgTypes[3]
+func (x *GetOrderHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_order_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -276,47 +1486,40 @@ func (x *OrderItemRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OrderItemRequest.ProtoReflect.Descriptor instead.
-func (*OrderItemRequest) Descriptor() ([]byte, []int) {
-	return file_proto_order_order_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use GetOrderHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetOrderHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_order_order_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *OrderItemRequest) GetProductId() string {
+func (x *GetOrderHistoryRequest) GetOrderId() string {
 	if x != nil {
-		return x.ProductId
+		return x.OrderId
 	}
 	return ""
 }
 
-func (x *OrderItemRequest) GetQuantity() int32 {
-	if x != nil {
-		return x.Quantity
-	}
-	return 0
-}
-
-type GetOrderRequest struct {
+type GetOrderHistoryResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Events        []*OrderEvent          `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetOrderRequest) Reset() {
-	*x = GetOrderRequest{}
-	mi := &file_proto_order_order_proto_msgTypes[4]
+func (x *GetOrderHistoryResponse) Reset() {
+	*x = GetOrderHistoryResponse{}
+	mi := &file_proto_order_order_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetOrderRequest) String() string {
+func (x *GetOrderHistoryResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetOrderRequest) ProtoMessage() {}
+func (*GetOrderHistoryResponse) ProtoMessage() {}
 
-func (x *GetOrderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_order_order_proto_msgTypes[4]
+func (x *GetOrderHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_order_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -327,42 +1530,49 @@ func (x *GetOrderRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetOrderRequest.ProtoReflect.Descriptor instead.
-func (*GetOrderRequest) Descriptor() ([]byte, []int) {
-	return file_proto_order_order_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use GetOrderHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetOrderHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_order_order_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *GetOrderRequest) GetId() string {
+func (x *GetOrderHistoryResponse) GetEvents() []*OrderEvent {
 	if x != nil {
-		return x.Id
+		return x.Events
 	}
-	return ""
-}
-
-type ListOrdersRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
-	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	return nil
 }
 
-func (x *ListOrdersRequest) Reset() {
-	*x = ListOrdersRequest{}
-	mi := &file_proto_order_order_proto_msgTypes[5]
+// OrderEvent is one change to an order, written in the transaction that
+// made it.
+type OrderEvent struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type           string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`                                           // created, status_changed, cancelled or refunded
+	PreviousStatus string                 `protobuf:"bytes,3,opt,name=previous_status,json=previousStatus,proto3" json:"previous_status,omitempty"` // Empty for created
+	NewStatus      string                 `protobuf:"bytes,4,opt,name=new_status,json=newStatus,proto3" json:"new_status,omitempty"`
+	Reason         string                 `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	Actor          *Actor                 `protobuf:"bytes,6,opt,name=actor,proto3" json:"actor,omitempty"`
+	Data           map[string]string      `protobuf:"bytes,7,rep,name=data,proto3" json:"data,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Type-specific details, e.g. refund_id
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *OrderEvent) Reset() {
+	*x = OrderEvent{}
+	mi := &file_proto_order_order_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListOrdersRequest) String() string {
+func (x *OrderEvent) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListOrdersRequest) ProtoMessage() {}
+func (*OrderEvent) ProtoMessage() {}
 
-func (x *ListOrdersRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_order_order_proto_msgTypes[5]
+func (x *OrderEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_order_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -373,57 +1583,93 @@ func (x *ListOrdersRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListOrdersRequest.ProtoReflect.Descriptor instead.
-func (*ListOrdersRequest) Descriptor() ([]byte, []int) {
-	return file_proto_order_order_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use OrderEvent.ProtoReflect.Descriptor instead.
+func (*OrderEvent) Descriptor() ([]byte, []int) {
+	return file_proto_order_order_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *ListOrdersRequest) GetUserId() string {
+func (x *OrderEvent) GetId() int64 {
 	if x != nil {
-		return x.UserId
+		return x.Id
+	}
+	return 0
+}
+
+func (x *OrderEvent) GetType() string {
+	if x != nil {
+		return x.Type
 	}
 	return ""
 }
 
-func (x *ListOrdersRequest) GetPage() int32 {
+func (x *OrderEvent) GetPreviousStatus() string {
 	if x != nil {
-		return x.Page
+		return x.PreviousStatus
 	}
-	return 0
+	return ""
 }
 
-func (x *ListOrdersRequest) GetLimit() int32 {
+func (x *OrderEvent) GetNewStatus() string {
 	if x != nil {
-		return x.Limit
+		return x.NewStatus
 	}
-	return 0
+	return ""
 }
 
-type ListOrdersResponse struct {
+func (x *OrderEvent) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *OrderEvent) GetActor() *Actor {
+	if x != nil {
+		return x.Actor
+	}
+	return nil
+}
+
+func (x *OrderEvent) GetData() map[string]string {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *OrderEvent) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// Actor is who made a change, from the caller's token. user_id is empty
+// for changes made without a token or by the service itself.
+type Actor struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Orders        []*Order               `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
-	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
-	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
-	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"` // Only returned to admins
+	Role          string                 `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`   // Token role, or e.g. "customer" or "admin" as stated in the request
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListOrdersResponse) Reset() {
-	*x = ListOrdersResponse{}
-	mi := &file_proto_order_order_proto_msgTypes[6]
+func (x *Actor) Reset() {
+	*x = Actor{}
+	mi := &file_proto_order_order_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListOrdersResponse) String() string {
+func (x *Actor) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListOrdersResponse) ProtoMessage() {}
+func (*Actor) ProtoMessage() {}
 
-func (x *ListOrdersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_order_order_proto_msgTypes[6]
+func (x *Actor) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_order_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -434,62 +1680,55 @@ func (x *ListOrdersResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListOrdersResponse.ProtoReflect.Descriptor instead.
-func (*ListOrdersResponse) Descriptor() ([]byte, []int) {
-	return file_proto_order_order_proto_rawDescGZIP(), []int{6}
-}
-
-func (x *ListOrdersResponse) GetOrders() []*Order {
-	if x != nil {
-		return x.Orders
-	}
-	return nil
+// Deprecated: Use Actor.ProtoReflect.Descriptor instead.
+func (*Actor) Descriptor() ([]byte, []int) {
+	return file_proto_order_order_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *ListOrdersResponse) GetTotal() int32 {
+func (x *Actor) GetUserId() string {
 	if x != nil {
-		return x.Total
+		return x.UserId
 	}
-	return 0
+	return ""
 }
 
-func (x *ListOrdersResponse) GetPage() int32 {
+func (x *Actor) GetEmail() string {
 	if x != nil {
-		return x.Page
+		return x.Email
 	}
-	return 0
+	return ""
 }
 
-func (x *ListOrdersResponse) GetLimit() int32 {
+func (x *Actor) GetRole() string {
 	if x != nil {
-		return x.Limit
+		return x.Role
 	}
-	return 0
+	return ""
 }
 
-type UpdateOrderStatusRequest struct {
+type OrderResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
-	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	Warnings      []*Warning             `protobuf:"bytes,2,rep,name=warnings,proto3" json:"warnings,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateOrderStatusRequest) Reset() {
-	*x = UpdateOrderStatusRequest{}
-	mi := &file_proto_order_order_proto_msgTypes[7]
+func (x *OrderResponse) Reset() {
+	*x = OrderResponse{}
+	mi := &file_proto_order_order_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateOrderStatusRequest) String() string {
+func (x *OrderResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateOrderStatusRequest) ProtoMessage() {}
+func (*OrderResponse) ProtoMessage() {}
 
-func (x *UpdateOrderStatusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_order_order_proto_msgTypes[7]
+func (x *OrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_order_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -500,47 +1739,50 @@ func (x *UpdateOrderStatusRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateOrderStatusRequest.ProtoReflect.Descriptor instead.
-func (*UpdateOrderStatusRequest) Descriptor() ([]byte, []int) {
-	return file_proto_order_order_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use OrderResponse.ProtoReflect.Descriptor instead.
+func (*OrderResponse) Descriptor() ([]byte, []int) {
+	return file_proto_order_order_proto_rawDescGZIP(), []int{21}
 }
 
-func (x *UpdateOrderStatusRequest) GetOrderId() string {
+func (x *OrderResponse) GetOrder() *Order {
 	if x != nil {
-		return x.OrderId
+		return x.Order
 	}
-	return ""
+	return nil
 }
 
-func (x *UpdateOrderStatusRequest) GetStatus() string {
+func (x *OrderResponse) GetWarnings() []*Warning {
 	if x != nil {
-		return x.Status
+		return x.Warnings
 	}
-	return ""
+	return nil
 }
 
-type OrderResponse struct {
+// Warning reports data that could not be included in an otherwise
+// successful response, e.g. user info while the user service is down.
+type Warning struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	Reason        string                 `protobuf:"bytes,1,opt,name=reason,proto3" json:"reason,omitempty"` // e.g. USER_UNAVAILABLE
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *OrderResponse) Reset() {
-	*x = OrderResponse{}
-	mi := &file_proto_order_order_proto_msgTypes[8]
+func (x *Warning) Reset() {
+	*x = Warning{}
+	mi := &file_proto_order_order_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *OrderResponse) String() string {
+func (x *Warning) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OrderResponse) ProtoMessage() {}
+func (*Warning) ProtoMessage() {}
 
-func (x *OrderResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_order_order_proto_msgTypes[8]
+func (x *Warning) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_order_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -551,70 +1793,201 @@ func (x *OrderResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OrderResponse.ProtoReflect.Descriptor instead.
-func (*OrderResponse) Descriptor() ([]byte, []int) {
-	return file_proto_order_order_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use Warning.ProtoReflect.Descriptor instead.
+func (*Warning) Descriptor() ([]byte, []int) {
+	return file_proto_order_order_proto_rawDescGZIP(), []int{22}
 }
 
-func (x *OrderResponse) GetOrder() *Order {
+func (x *Warning) GetReason() string {
 	if x != nil {
-		return x.Order
+		return x.Reason
 	}
-	return nil
+	return ""
+}
+
+func (x *Warning) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
 }
 
 var File_proto_order_order_proto protoreflect.FileDescriptor
 
 const file_proto_order_order_proto_rawDesc = "" +
 	"\n" +
-	"\x17proto/order/order.proto\x12\x05order\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x15proto/user/user.proto\"\xa9\x02\n" +
+	"\x17proto/order/order.proto\x12\x05order\x1a\x1bbuf/validate/validate.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x17proto/money/money.proto\x1a\x15proto/user/user.proto\"\xaf\a\n" +
 	"\x05Order\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\x12&\n" +
-	"\x05items\x18\x03 \x03(\v2\x10.order.OrderItemR\x05items\x12!\n" +
-	"\ftotal_amount\x18\x04 \x01(\x02R\vtotalAmount\x12\x16\n" +
+	"\x05items\x18\x03 \x03(\v2\x10.order.OrderItemR\x05items\x12%\n" +
+	"\ftotal_amount\x18\x04 \x01(\x02B\x02\x18\x01R\vtotalAmount\x12\x16\n" +
 	"\x06status\x18\x05 \x01(\tR\x06status\x129\n" +
 	"\n" +
 	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
 	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x1e\n" +
 	"\x04user\x18\b \x01(\v2\n" +
-	".user.UserR\x04user\"\x7f\n" +
+	".user.UserR\x04user\x12\"\n" +
+	"\x05total\x18\t \x01(\v2\f.money.MoneyR\x05total\x12:\n" +
+	"\x0eexchange_rates\x18\n" +
+	" \x03(\v2\x13.order.ExchangeRateR\rexchangeRates\x12(\n" +
+	"\bsubtotal\x18\v \x01(\v2\f.money.MoneyR\bsubtotal\x12)\n" +
+	"\ttax_total\x18\f \x01(\v2\f.money.MoneyR\btaxTotal\x12'\n" +
+	"\x0fshipping_region\x18\r \x01(\tR\x0eshippingRegion\x12#\n" +
+	"\rtax_inclusive\x18\x0e \x01(\bR\ftaxInclusive\x123\n" +
+	"\vadjustments\x18\x0f \x03(\v2\x11.order.AdjustmentR\vadjustments\x123\n" +
+	"\x0ediscount_total\x18\x10 \x01(\v2\f.money.MoneyR\rdiscountTotal\x123\n" +
+	"\x0erefunded_total\x18\x11 \x01(\v2\f.money.MoneyR\rrefundedTotal\x12/\n" +
+	"\x13cancellation_reason\x18\x12 \x01(\tR\x12cancellationReason\x12=\n" +
+	"\fcancelled_at\x18\x13 \x01(\v2\x1a.google.protobuf.TimestampR\vcancelledAt\x12>\n" +
+	"\x10shipping_address\x18\x14 \x01(\v2\x13.user.PostalAddressR\x0fshippingAddress\x12.\n" +
+	"\x13shipping_address_id\x18\x15 \x01(\tR\x11shippingAddressId\"\xbe\x01\n" +
+	"\n" +
+	"Adjustment\x12\x12\n" +
+	"\x04kind\x18\x01 \x01(\tR\x04kind\x12!\n" +
+	"\fpromotion_id\x18\x02 \x01(\tR\vpromotionId\x12\x12\n" +
+	"\x04code\x18\x03 \x01(\tR\x04code\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x05 \x01(\tR\tproductId\x12$\n" +
+	"\x06amount\x18\x06 \x01(\v2\f.money.MoneyR\x06amount\"W\n" +
+	"\aTaxLine\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04rate\x18\x02 \x01(\tR\x04rate\x12$\n" +
+	"\x06amount\x18\x03 \x01(\v2\f.money.MoneyR\x06amount\"\xb1\x01\n" +
+	"\fExchangeRate\x12#\n" +
+	"\rfrom_currency\x18\x01 \x01(\tR\ffromCurrency\x12\x1f\n" +
+	"\vto_currency\x18\x02 \x01(\tR\n" +
+	"toCurrency\x12\x12\n" +
+	"\x04rate\x18\x03 \x01(\tR\x04rate\x12/\n" +
+	"\x05as_of\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x04asOf\x12\x16\n" +
+	"\x06source\x18\x05 \x01(\tR\x06source\"\xf3\x03\n" +
 	"\tOrderItem\x12\x1d\n" +
 	"\n" +
 	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1a\n" +
-	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12\x14\n" +
-	"\x05price\x18\x03 \x01(\x02R\x05price\x12!\n" +
-	"\fproduct_name\x18\x04 \x01(\tR\vproductName\"\\\n" +
-	"\x12CreateOrderRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\x12-\n" +
-	"\x05items\x18\x02 \x03(\v2\x17.order.OrderItemRequestR\x05items\"M\n" +
-	"\x10OrderItemRequest\x12\x1d\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12\x18\n" +
+	"\x05price\x18\x03 \x01(\x02B\x02\x18\x01R\x05price\x12!\n" +
+	"\fproduct_name\x18\x04 \x01(\tR\vproductName\x12+\n" +
 	"\n" +
-	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1a\n" +
-	"\bquantity\x18\x02 \x01(\x05R\bquantity\"!\n" +
-	"\x0fGetOrderRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"V\n" +
-	"\x11ListOrdersRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
-	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x14\n" +
-	"\x05limit\x18\x03 \x01(\x05R\x05limit\"z\n" +
+	"unit_price\x18\x05 \x01(\v2\f.money.MoneyR\tunitPrice\x12<\n" +
+	"\x13original_unit_price\x18\x06 \x01(\v2\f.money.MoneyR\x11originalUnitPrice\x12(\n" +
+	"\bsubtotal\x18\a \x01(\v2\f.money.MoneyR\bsubtotal\x12\x1e\n" +
+	"\x03tax\x18\b \x01(\v2\f.money.MoneyR\x03tax\x12+\n" +
+	"\ttax_lines\x18\t \x03(\v2\x0e.order.TaxLineR\btaxLines\x12(\n" +
+	"\bdiscount\x18\n" +
+	" \x01(\v2\f.money.MoneyR\bdiscount\x12+\n" +
+	"\x11refunded_quantity\x18\v \x01(\x05R\x10refundedQuantity\x125\n" +
+	"\x0frefunded_amount\x18\f \x01(\v2\f.money.MoneyR\x0erefundedAmount\"\xdd\x03\n" +
+	"\x12CreateOrderRequest\x12 \n" +
+	"\auser_id\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x06userId\x129\n" +
+	"\x05items\x18\x02 \x03(\v2\x17.order.OrderItemRequestB\n" +
+	"\xbaH\a\x92\x01\x04\b\x01\x10dR\x05items\x120\n" +
+	"\bcurrency\x18\x03 \x01(\tB\x14\xbaH\x11\xd8\x01\x01r\f2\n" +
+	"^[A-Z]{3}$R\bcurrency\x12N\n" +
+	"\x0fshipping_region\x18\x04 \x01(\tB%\xbaH\"\xd8\x01\x01r\x1d2\x1b^[A-Z]{2}(-[A-Z0-9]{1,3})?$R\x0eshippingRegion\x125\n" +
+	"\fcoupon_codes\x18\x05 \x03(\tB\x12\xbaH\x0f\x92\x01\f\x10\x05\x18\x01\"\x06r\x04\x10\x01\x18 R\vcouponCodes\x121\n" +
+	"\x0fidempotency_key\x18\x06 \x01(\tB\b\xbaH\x05r\x03\x18\x80\x01R\x0eidempotencyKey\x120\n" +
+	"\x13shipping_address_id\x18\a \x01(\tH\x00R\x11shippingAddressId\x12@\n" +
+	"\x10shipping_address\x18\b \x01(\v2\x13.user.PostalAddressH\x00R\x0fshippingAddressB\n" +
+	"\n" +
+	"\bshipping\"_\n" +
+	"\x10OrderItemRequest\x12&\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\tproductId\x12#\n" +
+	"\bquantity\x18\x02 \x01(\x05B\a\xbaH\x04\x1a\x02 \x00R\bquantity\"M\n" +
+	"\x0fGetOrderRequest\x12\x17\n" +
+	"\x02id\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x02id\x12!\n" +
+	"\finclude_user\x18\x02 \x01(\bR\vincludeUser\"\xe4\x01\n" +
+	"\x11ListOrdersRequest\x12 \n" +
+	"\auser_id\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x06userId\x12\x1b\n" +
+	"\x04page\x18\x02 \x01(\x05B\a\xbaH\x04\x1a\x02(\x00R\x04page\x12\x1f\n" +
+	"\x05limit\x18\x03 \x01(\x05B\t\xbaH\x06\x1a\x04\x18d(\x01R\x05limit\x12!\n" +
+	"\finclude_user\x18\x04 \x01(\bR\vincludeUser\x12'\n" +
+	"\n" +
+	"page_token\x18\x05 \x01(\tB\b\xbaH\x05r\x03\x18\x80\x04R\tpageToken\x12#\n" +
+	"\rinclude_total\x18\x06 \x01(\bR\fincludeTotal\"\xce\x01\n" +
 	"\x12ListOrdersResponse\x12$\n" +
 	"\x06orders\x18\x01 \x03(\v2\f.order.OrderR\x06orders\x12\x14\n" +
 	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x12\n" +
 	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x14\n" +
-	"\x05limit\x18\x04 \x01(\x05R\x05limit\"M\n" +
-	"\x18UpdateOrderStatusRequest\x12\x19\n" +
-	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x16\n" +
-	"\x06status\x18\x02 \x01(\tR\x06status\"3\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\x12*\n" +
+	"\bwarnings\x18\x05 \x03(\v2\x0e.order.WarningR\bwarnings\x12&\n" +
+	"\x0fnext_page_token\x18\x06 \x01(\tR\rnextPageToken\"\xb2\x01\n" +
+	"\x18UpdateOrderStatusRequest\x12\"\n" +
+	"\border_id\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\aorderId\x12P\n" +
+	"\x06status\x18\x02 \x01(\tB8\xbaH5r3R\apendingR\tconfirmedR\ashippedR\tdeliveredR\tcancelledR\x06status\x12 \n" +
+	"\x06reason\x18\x03 \x01(\tB\b\xbaH\x05r\x03\x18\xf4\x03R\x06reason\"\x9c\x01\n" +
+	"\x12CancelOrderRequest\x12\"\n" +
+	"\border_id\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\aorderId\x12\"\n" +
+	"\x06reason\x18\x02 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\xf4\x03R\x06reason\x12>\n" +
+	"\fcancelled_by\x18\x03 \x01(\tB\x1b\xbaH\x16\xd8\x01\x01r\x11R\bcustomerR\x05admin\x18\x01R\vcancelledBy\"\x8f\x01\n" +
+	"\x12RefundOrderRequest\x12\"\n" +
+	"\border_id\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\aorderId\x12\"\n" +
+	"\x06reason\x18\x02 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\xf4\x03R\x06reason\x121\n" +
+	"\x05items\x18\x03 \x03(\v2\x11.order.RefundItemB\b\xbaH\x05\x92\x01\x02\x10dR\x05items\"Y\n" +
+	"\n" +
+	"RefundItem\x12&\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\tproductId\x12#\n" +
+	"\bquantity\x18\x02 \x01(\x05B\a\xbaH\x04\x1a\x02 \x00R\bquantity\"\xd5\x01\n" +
+	"\x06Refund\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\x12'\n" +
+	"\x05items\x18\x03 \x03(\v2\x11.order.RefundLineR\x05items\x12$\n" +
+	"\x06amount\x18\x04 \x01(\v2\f.money.MoneyR\x06amount\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"m\n" +
+	"\n" +
+	"RefundLine\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12$\n" +
+	"\x06amount\x18\x03 \x01(\v2\f.money.MoneyR\x06amount\"`\n" +
+	"\x13RefundOrderResponse\x12\"\n" +
+	"\x05order\x18\x01 \x01(\v2\f.order.OrderR\x05order\x12%\n" +
+	"\x06refund\x18\x02 \x01(\v2\r.order.RefundR\x06refund\"<\n" +
+	"\x16GetOrderHistoryRequest\x12\"\n" +
+	"\border_id\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\aorderId\"D\n" +
+	"\x17GetOrderHistoryResponse\x12)\n" +
+	"\x06events\x18\x01 \x03(\v2\x11.order.OrderEventR\x06events\"\xd9\x02\n" +
+	"\n" +
+	"OrderEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12'\n" +
+	"\x0fprevious_status\x18\x03 \x01(\tR\x0epreviousStatus\x12\x1d\n" +
+	"\n" +
+	"new_status\x18\x04 \x01(\tR\tnewStatus\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\x12\"\n" +
+	"\x05actor\x18\x06 \x01(\v2\f.order.ActorR\x05actor\x12/\n" +
+	"\x04data\x18\a \x03(\v2\x1b.order.OrderEvent.DataEntryR\x04data\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x1a7\n" +
+	"\tDataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"J\n" +
+	"\x05Actor\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12\x12\n" +
+	"\x04role\x18\x03 \x01(\tR\x04role\"_\n" +
 	"\rOrderResponse\x12\"\n" +
-	"\x05order\x18\x01 \x01(\v2\f.order.OrderR\x05order2\x9f\x02\n" +
+	"\x05order\x18\x01 \x01(\v2\f.order.OrderR\x05order\x12*\n" +
+	"\bwarnings\x18\x02 \x03(\v2\x0e.order.WarningR\bwarnings\";\n" +
+	"\aWarning\x12\x16\n" +
+	"\x06reason\x18\x01 \x01(\tR\x06reason\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage2\xfd\x03\n" +
 	"\fOrderService\x12@\n" +
 	"\vCreateOrder\x12\x19.order.CreateOrderRequest\x1a\x14.order.OrderResponse\"\x00\x12:\n" +
 	"\bGetOrder\x12\x16.order.GetOrderRequest\x1a\x14.order.OrderResponse\"\x00\x12C\n" +
 	"\n" +
 	"ListOrders\x12\x18.order.ListOrdersRequest\x1a\x19.order.ListOrdersResponse\"\x00\x12L\n" +
-	"\x11UpdateOrderStatus\x12\x1f.order.UpdateOrderStatusRequest\x1a\x14.order.OrderResponse\"\x00B=Z;github.com/dipendra-mule/microservice-with-grpc/proto/orderb\x06proto3"
+	"\x11UpdateOrderStatus\x12\x1f.order.UpdateOrderStatusRequest\x1a\x14.order.OrderResponse\"\x00\x12@\n" +
+	"\vCancelOrder\x12\x19.order.CancelOrderRequest\x1a\x14.order.OrderResponse\"\x00\x12F\n" +
+	"\vRefundOrder\x12\x19.order.RefundOrderRequest\x1a\x1a.order.RefundOrderResponse\"\x00\x12R\n" +
+	"\x0fGetOrderHistory\x12\x1d.order.GetOrderHistoryRequest\x1a\x1e.order.GetOrderHistoryResponse\"\x00B=Z;github.com/dipendra-mule/microservice-with-grpc/proto/orderb\x06proto3"
 
 var (
 	file_proto_order_order_proto_rawDescOnce sync.Once
@@ -628,41 +2001,97 @@ func file_proto_order_order_proto_rawDescGZIP() []byte {
 	return file_proto_order_order_proto_rawDescData
 }
 
-var file_proto_order_order_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_proto_order_order_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
 var file_proto_order_order_proto_goTypes = []any{
 	(*Order)(nil),                    // 0: order.Order
-	(*OrderItem)(nil),                // 1: order.OrderItem
-	(*CreateOrderRequest)(nil),       // 2: order.CreateOrderRequest
-	(*OrderItemRequest)(nil),         // 3: order.OrderItemRequest
-	(*GetOrderRequest)(nil),          // 4: order.GetOrderRequest
-	(*ListOrdersRequest)(nil),        // 5: order.ListOrdersRequest
-	(*ListOrdersResponse)(nil),       // 6: order.ListOrdersResponse
-	(*UpdateOrderStatusRequest)(nil), // 7: order.UpdateOrderStatusRequest
-	(*OrderResponse)(nil),            // 8: order.OrderResponse
-	(*timestamppb.Timestamp)(nil),    // 9: google.protobuf.Timestamp
-	(*user.User)(nil),                // 10: user.User
+	(*Adjustment)(nil),               // 1: order.Adjustment
+	(*TaxLine)(nil),                  // 2: order.TaxLine
+	(*ExchangeRate)(nil),             // 3: order.ExchangeRate
+	(*OrderItem)(nil),                // 4: order.OrderItem
+	(*CreateOrderRequest)(nil),       // 5: order.CreateOrderRequest
+	(*OrderItemRequest)(nil),         // 6: order.OrderItemRequest
+	(*GetOrderRequest)(nil),          // 7: order.GetOrderRequest
+	(*ListOrdersRequest)(nil),        // 8: order.ListOrdersRequest
+	(*ListOrdersResponse)(nil),       // 9: order.ListOrdersResponse
+	(*UpdateOrderStatusRequest)(nil), // 10: order.UpdateOrderStatusRequest
+	(*CancelOrderRequest)(nil),       // 11: order.CancelOrderRequest
+	(*RefundOrderRequest)(nil),       // 12: order.RefundOrderRequest
+	(*RefundItem)(nil),               // 13: order.RefundItem
+	(*Refund)(nil),                   // 14: order.Refund
+	(*RefundLine)(nil),               // 15: order.RefundLine
+	(*RefundOrderResponse)(nil),      // 16: order.RefundOrderResponse
+	(*GetOrderHistoryRequest)(nil),   // 17: order.GetOrderHistoryRequest
+	(*GetOrderHistoryResponse)(nil),  // 18: order.GetOrderHistoryResponse
+	(*OrderEvent)(nil),               // 19: order.OrderEvent
+	(*Actor)(nil),                    // 20: order.Actor
+	(*OrderResponse)(nil),            // 21: order.OrderResponse
+	(*Warning)(nil),                  // 22: order.Warning
+	nil,                              // 23: order.OrderEvent.DataEntry
+	(*timestamppb.Timestamp)(nil),    // 24: google.protobuf.Timestamp
+	(*user.User)(nil),                // 25: user.User
+	(*money.Money)(nil),              // 26: money.Money
+	(*user.PostalAddress)(nil),       // 27: user.PostalAddress
 }
 var file_proto_order_order_proto_depIdxs = []int32{
-	1,  // 0: order.Order.items:type_name -> order.OrderItem
-	9,  // 1: order.Order.created_at:type_name -> google.protobuf.Timestamp
-	9,  // 2: order.Order.updated_at:type_name -> google.protobuf.Timestamp
-	10, // 3: order.Order.user:type_name -> user.User
-	3,  // 4: order.CreateOrderRequest.items:type_name -> order.OrderItemRequest
-	0,  // 5: order.ListOrdersResponse.orders:type_name -> order.Order
-	0,  // 6: order.OrderResponse.order:type_name -> order.Order
-	2,  // 7: order.OrderService.CreateOrder:input_type -> order.CreateOrderRequest
-	4,  // 8: order.OrderService.GetOrder:input_type -> order.GetOrderRequest
-	5,  // 9: order.OrderService.ListOrders:input_type -> order.ListOrdersRequest
-	7,  // 10: order.OrderService.UpdateOrderStatus:input_type -> order.UpdateOrderStatusRequest
-	8,  // 11: order.OrderService.CreateOrder:output_type -> order.OrderResponse
-	8,  // 12: order.OrderService.GetOrder:output_type -> order.OrderResponse
-	6,  // 13: order.OrderService.ListOrders:output_type -> order.ListOrdersResponse
-	8,  // 14: order.OrderService.UpdateOrderStatus:output_type -> order.OrderResponse
-	11, // [11:15] is the sub-list for method output_type
-	7,  // [7:11] is the sub-list for method input_type
-	7,  // [7:7] is the sub-list for extension type_name
-	7,  // [7:7] is the sub-list for extension extendee
-	0,  // [0:7] is the sub-list for field type_name
+	4,  // 0: order.Order.items:type_name -> order.OrderItem
+	24, // 1: order.Order.created_at:type_name -> google.protobuf.Timestamp
+	24, // 2: order.Order.updated_at:type_name -> google.protobuf.Timestamp
+	25, // 3: order.Order.user:type_name -> user.User
+	26, // 4: order.Order.total:type_name -> money.Money
+	3,  // 5: order.Order.exchange_rates:type_name -> order.ExchangeRate
+	26, // 6: order.Order.subtotal:type_name -> money.Money
+	26, // 7: order.Order.tax_total:type_name -> money.Money
+	1,  // 8: order.Order.adjustments:type_name -> order.Adjustment
+	26, // 9: order.Order.discount_total:type_name -> money.Money
+	26, // 10: order.Order.refunded_total:type_name -> money.Money
+	24, // 11: order.Order.cancelled_at:type_name -> google.protobuf.Timestamp
+	27, // 12: order.Order.shipping_address:type_name -> user.PostalAddress
+	26, // 13: order.Adjustment.amount:type_name -> money.Money
+	26, // 14: order.TaxLine.amount:type_name -> money.Money
+	24, // 15: order.ExchangeRate.as_of:type_name -> google.protobuf.Timestamp
+	26, // 16: order.OrderItem.unit_price:type_name -> money.Money
+	26, // 17: order.OrderItem.original_unit_price:type_name -> money.Money
+	26, // 18: order.OrderItem.subtotal:type_name -> money.Money
+	26, // 19: order.OrderItem.tax:type_name -> money.Money
+	2,  // 20: order.OrderItem.tax_lines:type_name -> order.TaxLine
+	26, // 21: order.OrderItem.discount:type_name -> money.Money
+	26, // 22: order.OrderItem.refunded_amount:type_name -> money.Money
+	6,  // 23: order.CreateOrderRequest.items:type_name -> order.OrderItemRequest
+	27, // 24: order.CreateOrderRequest.shipping_address:type_name -> user.PostalAddress
+	0,  // 25: order.ListOrdersResponse.orders:type_name -> order.Order
+	22, // 26: order.ListOrdersResponse.warnings:type_name -> order.Warning
+	13, // 27: order.RefundOrderRequest.items:type_name -> order.RefundItem
+	15, // 28: order.Refund.items:type_name -> order.RefundLine
+	26, // 29: order.Refund.amount:type_name -> money.Money
+	24, // 30: order.Refund.created_at:type_name -> google.protobuf.Timestamp
+	26, // 31: order.RefundLine.amount:type_name -> money.Money
+	0,  // 32: order.RefundOrderResponse.order:type_name -> order.Order
+	14, // 33: order.RefundOrderResponse.refund:type_name -> order.Refund
+	19, // 34: order.GetOrderHistoryResponse.events:type_name -> order.OrderEvent
+	20, // 35: order.OrderEvent.actor:type_name -> order.Actor
+	23, // 36: order.OrderEvent.data:type_name -> order.OrderEvent.DataEntry
+	24, // 37: order.OrderEvent.created_at:type_name -> google.protobuf.Timestamp
+	0,  // 38: order.OrderResponse.order:type_name -> order.Order
+	22, // 39: order.OrderResponse.warnings:type_name -> order.Warning
+	5,  // 40: order.OrderService.CreateOrder:input_type -> order.CreateOrderRequest
+	7,  // 41: order.OrderService.GetOrder:input_type -> order.GetOrderRequest
+	8,  // 42: order.OrderService.ListOrders:input_type -> order.ListOrdersRequest
+	10, // 43: order.OrderService.UpdateOrderStatus:input_type -> order.UpdateOrderStatusRequest
+	11, // 44: order.OrderService.CancelOrder:input_type -> order.CancelOrderRequest
+	12, // 45: order.OrderService.RefundOrder:input_type -> order.RefundOrderRequest
+	17, // 46: order.OrderService.GetOrderHistory:input_type -> order.GetOrderHistoryRequest
+	21, // 47: order.OrderService.CreateOrder:output_type -> order.OrderResponse
+	21, // 48: order.OrderService.GetOrder:output_type -> order.OrderResponse
+	9,  // 49: order.OrderService.ListOrders:output_type -> order.ListOrdersResponse
+	21, // 50: order.OrderService.UpdateOrderStatus:output_type -> order.OrderResponse
+	21, // 51: order.OrderService.CancelOrder:output_type -> order.OrderResponse
+	16, // 52: order.OrderService.RefundOrder:output_type -> order.RefundOrderResponse
+	18, // 53: order.OrderService.GetOrderHistory:output_type -> order.GetOrderHistoryResponse
+	47, // [47:54] is the sub-list for method output_type
+	40, // [40:47] is the sub-list for method input_type
+	40, // [40:40] is the sub-list for extension type_name
+	40, // [40:40] is the sub-list for extension extendee
+	0,  // [0:40] is the sub-list for field type_name
 }
 
 func init() { file_proto_order_order_proto_init() }
@@ -670,13 +2099,17 @@ func file_proto_order_order_proto_init() {
 	if File_proto_order_order_proto != nil {
 		return
 	}
+	file_proto_order_order_proto_msgTypes[5].OneofWrappers = []any{
+		(*CreateOrderRequest_ShippingAddressId)(nil),
+		(*CreateOrderRequest_ShippingAddress)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_order_order_proto_rawDesc), len(file_proto_order_order_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   9,
+			NumMessages:   24,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
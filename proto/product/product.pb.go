@@ -7,6 +7,8 @@
 package product
 
 import (
+	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	money "github.com/dipendra-mule/microservice-with-grpc/proto/money"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
@@ -23,15 +25,17 @@ const (
 )
 
 type Product struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	Price         float32                `protobuf:"fixed32,4,opt,name=price,proto3" json:"price,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// Deprecated: Marked as deprecated in proto/product/product.proto.
+	Price         float32                `protobuf:"fixed32,4,opt,name=price,proto3" json:"price,omitempty"` // Use unit_price
 	Stock         int32                  `protobuf:"varint,5,opt,name=stock,proto3" json:"stock,omitempty"`
 	Category      string                 `protobuf:"bytes,6,opt,name=category,proto3" json:"category,omitempty"`
 	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	UnitPrice     *money.Money           `protobuf:"bytes,9,opt,name=unit_price,json=unitPrice,proto3" json:"unit_price,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -87,6 +91,7 @@ func (x *Product) GetDescription() string {
 	return ""
 }
 
+// Deprecated: Marked as deprecated in proto/product/product.proto.
 func (x *Product) GetPrice() float32 {
 	if x != nil {
 		return x.Price
@@ -122,13 +127,22 @@ func (x *Product) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Product) GetUnitPrice() *money.Money {
+	if x != nil {
+		return x.UnitPrice
+	}
+	return nil
+}
+
 type CreateProductRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
-	Price         float32                `protobuf:"fixed32,3,opt,name=price,proto3" json:"price,omitempty"`
-	Stock         int32                  `protobuf:"varint,4,opt,name=stock,proto3" json:"stock,omitempty"`
-	Category      string                 `protobuf:"bytes,5,opt,name=category,proto3" json:"category,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Name        string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	// Deprecated: Marked as deprecated in proto/product/product.proto.
+	Price         float32      `protobuf:"fixed32,3,opt,name=price,proto3" json:"price,omitempty"` // Use unit_price
+	Stock         int32        `protobuf:"varint,4,opt,name=stock,proto3" json:"stock,omitempty"`
+	Category      string       `protobuf:"bytes,5,opt,name=category,proto3" json:"category,omitempty"`
+	UnitPrice     *money.Money `protobuf:"bytes,6,opt,name=unit_price,json=unitPrice,proto3" json:"unit_price,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -177,6 +191,7 @@ func (x *CreateProductRequest) GetDescription() string {
 	return ""
 }
 
+// Deprecated: Marked as deprecated in proto/product/product.proto.
 func (x *CreateProductRequest) GetPrice() float32 {
 	if x != nil {
 		return x.Price
@@ -198,6 +213,13 @@ func (x *CreateProductRequest) GetCategory() string {
 	return ""
 }
 
+func (x *CreateProductRequest) GetUnitPrice() *money.Money {
+	if x != nil {
+		return x.UnitPrice
+	}
+	return nil
+}
+
 type GetProductRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -243,10 +265,17 @@ func (x *GetProductRequest) GetId() string {
 }
 
 type ListProductsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Category      string                 `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
-	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
-	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Category string                 `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
+	// Deprecated: 1-based page number for offset pagination; use page_token.
+	Page  int32 `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	Limit int32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Opaque token from a previous response's next_page_token. Takes
+	// precedence over page.
+	PageToken string `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Compute total, which costs an extra COUNT query. Always computed in
+	// the legacy page mode.
+	IncludeTotal  bool `protobuf:"varint,5,opt,name=include_total,json=includeTotal,proto3" json:"include_total,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -302,12 +331,27 @@ func (x *ListProductsRequest) GetLimit() int32 {
 	return 0
 }
 
+func (x *ListProductsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListProductsRequest) GetIncludeTotal() bool {
+	if x != nil {
+		return x.IncludeTotal
+	}
+	return false
+}
+
 type ListProductsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Products      []*Product             `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
-	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"` // Only set when requested, see include_total
 	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
 	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,5,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // Empty on the last page
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -370,13 +414,22 @@ func (x *ListProductsResponse) GetLimit() int32 {
 	return 0
 }
 
+func (x *ListProductsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
 type UpdateProductRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	Price         float32                `protobuf:"fixed32,4,opt,name=price,proto3" json:"price,omitempty"`
-	Stock         int32                  `protobuf:"varint,5,opt,name=stock,proto3" json:"stock,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// Deprecated: Marked as deprecated in proto/product/product.proto.
+	Price         float32      `protobuf:"fixed32,4,opt,name=price,proto3" json:"price,omitempty"` // Use unit_price
+	Stock         int32        `protobuf:"varint,5,opt,name=stock,proto3" json:"stock,omitempty"`
+	UnitPrice     *money.Money `protobuf:"bytes,6,opt,name=unit_price,json=unitPrice,proto3" json:"unit_price,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -432,6 +485,7 @@ func (x *UpdateProductRequest) GetDescription() string {
 	return ""
 }
 
+// Deprecated: Marked as deprecated in proto/product/product.proto.
 func (x *UpdateProductRequest) GetPrice() float32 {
 	if x != nil {
 		return x.Price
@@ -446,6 +500,13 @@ func (x *UpdateProductRequest) GetStock() int32 {
 	return 0
 }
 
+func (x *UpdateProductRequest) GetUnitPrice() *money.Money {
+	if x != nil {
+		return x.UnitPrice
+	}
+	return nil
+}
+
 type ValidateProductsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Items         []*ProductValidation   `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
@@ -698,51 +759,204 @@ func (x *ProductResponse) GetProduct() *Product {
 	return nil
 }
 
+type ReleaseStockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reference     string                 `protobuf:"bytes,1,opt,name=reference,proto3" json:"reference,omitempty"`
+	Items         []*StockQuantity       `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseStockRequest) Reset() {
+	*x = ReleaseStockRequest{}
+	mi := &file_proto_product_product_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseStockRequest) ProtoMessage() {}
+
+func (x *ReleaseStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_product_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseStockRequest.ProtoReflect.Descriptor instead.
+func (*ReleaseStockRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_product_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ReleaseStockRequest) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+func (x *ReleaseStockRequest) GetItems() []*StockQuantity {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type StockQuantity struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StockQuantity) Reset() {
+	*x = StockQuantity{}
+	mi := &file_proto_product_product_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockQuantity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockQuantity) ProtoMessage() {}
+
+func (x *StockQuantity) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_product_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockQuantity.ProtoReflect.Descriptor instead.
+func (*StockQuantity) Descriptor() ([]byte, []int) {
+	return file_proto_product_product_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *StockQuantity) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *StockQuantity) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+type ReleaseStockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseStockResponse) Reset() {
+	*x = ReleaseStockResponse{}
+	mi := &file_proto_product_product_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseStockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseStockResponse) ProtoMessage() {}
+
+func (x *ReleaseStockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_product_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseStockResponse.ProtoReflect.Descriptor instead.
+func (*ReleaseStockResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_product_proto_rawDescGZIP(), []int{13}
+}
+
 var File_proto_product_product_proto protoreflect.FileDescriptor
 
 const file_proto_product_product_proto_rawDesc = "" +
 	"\n" +
-	"\x1bproto/product/product.proto\x12\aproduct\x1a\x1fgoogle/protobuf/timestamp.proto\"\x8d\x02\n" +
+	"\x1bproto/product/product.proto\x12\aproduct\x1a\x1bbuf/validate/validate.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x17proto/money/money.proto\"\xbe\x02\n" +
 	"\aProduct\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
-	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x14\n" +
-	"\x05price\x18\x04 \x01(\x02R\x05price\x12\x14\n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x18\n" +
+	"\x05price\x18\x04 \x01(\x02B\x02\x18\x01R\x05price\x12\x14\n" +
 	"\x05stock\x18\x05 \x01(\x05R\x05stock\x12\x1a\n" +
 	"\bcategory\x18\x06 \x01(\tR\bcategory\x129\n" +
 	"\n" +
 	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\x94\x01\n" +
-	"\x14CreateProductRequest\x12\x12\n" +
-	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
-	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x14\n" +
-	"\x05price\x18\x03 \x01(\x02R\x05price\x12\x14\n" +
-	"\x05stock\x18\x04 \x01(\x05R\x05stock\x12\x1a\n" +
-	"\bcategory\x18\x05 \x01(\tR\bcategory\"#\n" +
-	"\x11GetProductRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"[\n" +
+	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12+\n" +
+	"\n" +
+	"unit_price\x18\t \x01(\v2\f.money.MoneyR\tunitPrice\"\xee\x01\n" +
+	"\x14CreateProductRequest\x12\x1e\n" +
+	"\x04name\x18\x01 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\xc8\x01R\x04name\x12*\n" +
+	"\vdescription\x18\x02 \x01(\tB\b\xbaH\x05r\x03\x18\xd0\x0fR\vdescription\x12\"\n" +
+	"\x05price\x18\x03 \x01(\x02B\f\xbaH\a\n" +
+	"\x05-\x00\x00\x00\x00\x18\x01R\x05price\x12\x1d\n" +
+	"\x05stock\x18\x04 \x01(\x05B\a\xbaH\x04\x1a\x02(\x00R\x05stock\x12\x1a\n" +
+	"\bcategory\x18\x05 \x01(\tR\bcategory\x12+\n" +
+	"\n" +
+	"unit_price\x18\x06 \x01(\v2\f.money.MoneyR\tunitPrice\",\n" +
+	"\x11GetProductRequest\x12\x17\n" +
+	"\x02id\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x02id\"\xbd\x01\n" +
 	"\x13ListProductsRequest\x12\x1a\n" +
-	"\bcategory\x18\x01 \x01(\tR\bcategory\x12\x12\n" +
-	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x14\n" +
-	"\x05limit\x18\x03 \x01(\x05R\x05limit\"\x84\x01\n" +
+	"\bcategory\x18\x01 \x01(\tR\bcategory\x12\x1b\n" +
+	"\x04page\x18\x02 \x01(\x05B\a\xbaH\x04\x1a\x02(\x00R\x04page\x12\x1f\n" +
+	"\x05limit\x18\x03 \x01(\x05B\t\xbaH\x06\x1a\x04\x18d(\x01R\x05limit\x12'\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\tB\b\xbaH\x05r\x03\x18\x80\x04R\tpageToken\x12#\n" +
+	"\rinclude_total\x18\x05 \x01(\bR\fincludeTotal\"\xac\x01\n" +
 	"\x14ListProductsResponse\x12,\n" +
 	"\bproducts\x18\x01 \x03(\v2\x10.product.ProductR\bproducts\x12\x14\n" +
 	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x12\n" +
 	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x14\n" +
-	"\x05limit\x18\x04 \x01(\x05R\x05limit\"\x88\x01\n" +
-	"\x14UpdateProductRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
-	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x14\n" +
-	"\x05price\x18\x04 \x01(\x02R\x05price\x12\x14\n" +
-	"\x05stock\x18\x05 \x01(\x05R\x05stock\"K\n" +
-	"\x17ValidateProductsRequest\x120\n" +
-	"\x05items\x18\x01 \x03(\v2\x1a.product.ProductValidationR\x05items\"N\n" +
-	"\x11ProductValidation\x12\x1d\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\x12&\n" +
+	"\x0fnext_page_token\x18\x05 \x01(\tR\rnextPageToken\"\xe9\x01\n" +
+	"\x14UpdateProductRequest\x12\x17\n" +
+	"\x02id\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x02id\x12\x1c\n" +
+	"\x04name\x18\x02 \x01(\tB\b\xbaH\x05r\x03\x18\xc8\x01R\x04name\x12*\n" +
+	"\vdescription\x18\x03 \x01(\tB\b\xbaH\x05r\x03\x18\xd0\x0fR\vdescription\x12\"\n" +
+	"\x05price\x18\x04 \x01(\x02B\f\xbaH\a\n" +
+	"\x05-\x00\x00\x00\x00\x18\x01R\x05price\x12\x1d\n" +
+	"\x05stock\x18\x05 \x01(\x05B\a\xbaH\x04\x1a\x02(\x00R\x05stock\x12+\n" +
 	"\n" +
-	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1a\n" +
-	"\bquantity\x18\x02 \x01(\x05R\bquantity\"\x90\x01\n" +
+	"unit_price\x18\x06 \x01(\v2\f.money.MoneyR\tunitPrice\"U\n" +
+	"\x17ValidateProductsRequest\x12:\n" +
+	"\x05items\x18\x01 \x03(\v2\x1a.product.ProductValidationB\b\xbaH\x05\x92\x01\x02\b\x01R\x05items\"`\n" +
+	"\x11ProductValidation\x12&\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\tproductId\x12#\n" +
+	"\bquantity\x18\x02 \x01(\x05B\a\xbaH\x04\x1a\x02 \x00R\bquantity\"\x90\x01\n" +
 	"\x18ValidateProductsResponse\x12\x14\n" +
 	"\x05valid\x18\x01 \x01(\bR\x05valid\x120\n" +
 	"\x06errors\x18\x02 \x03(\v2\x18.product.ValidationErrorR\x06errors\x12,\n" +
@@ -752,14 +966,25 @@ const file_proto_product_product_proto_rawDesc = "" +
 	"product_id\x18\x01 \x01(\tR\tproductId\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\"=\n" +
 	"\x0fProductResponse\x12*\n" +
-	"\aproduct\x18\x01 \x01(\v2\x10.product.ProductR\aproduct2\x98\x03\n" +
+	"\aproduct\x18\x01 \x01(\v2\x10.product.ProductR\aproduct\"y\n" +
+	"\x13ReleaseStockRequest\x12(\n" +
+	"\treference\x18\x01 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\xc8\x01R\treference\x128\n" +
+	"\x05items\x18\x02 \x03(\v2\x16.product.StockQuantityB\n" +
+	"\xbaH\a\x92\x01\x04\b\x01\x10dR\x05items\"\\\n" +
+	"\rStockQuantity\x12&\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\tproductId\x12#\n" +
+	"\bquantity\x18\x02 \x01(\x05B\a\xbaH\x04\x1a\x02 \x00R\bquantity\"\x16\n" +
+	"\x14ReleaseStockResponse2\xe7\x03\n" +
 	"\x0eProductService\x12J\n" +
 	"\rCreateProduct\x12\x1d.product.CreateProductRequest\x1a\x18.product.ProductResponse\"\x00\x12D\n" +
 	"\n" +
 	"GetProduct\x12\x1a.product.GetProductRequest\x1a\x18.product.ProductResponse\"\x00\x12M\n" +
 	"\fListProducts\x12\x1c.product.ListProductsRequest\x1a\x1d.product.ListProductsResponse\"\x00\x12J\n" +
 	"\rUpdateProduct\x12\x1d.product.UpdateProductRequest\x1a\x18.product.ProductResponse\"\x00\x12Y\n" +
-	"\x10ValidateProducts\x12 .product.ValidateProductsRequest\x1a!.product.ValidateProductsResponse\"\x00B?Z=github.com/dipendra-mule/microservice-with-grpc/proto/productb\x06proto3"
+	"\x10ValidateProducts\x12 .product.ValidateProductsRequest\x1a!.product.ValidateProductsResponse\"\x00\x12M\n" +
+	"\fReleaseStock\x12\x1c.product.ReleaseStockRequest\x1a\x1d.product.ReleaseStockResponse\"\x00B?Z=github.com/dipendra-mule/microservice-with-grpc/proto/productb\x06proto3"
 
 var (
 	file_proto_product_product_proto_rawDescOnce sync.Once
@@ -773,7 +998,7 @@ func file_proto_product_product_proto_rawDescGZIP() []byte {
 	return file_proto_product_product_proto_rawDescData
 }
 
-var file_proto_product_product_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_proto_product_product_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
 var file_proto_product_product_proto_goTypes = []any{
 	(*Product)(nil),                  // 0: product.Product
 	(*CreateProductRequest)(nil),     // 1: product.CreateProductRequest
@@ -786,31 +1011,41 @@ var file_proto_product_product_proto_goTypes = []any{
 	(*ValidateProductsResponse)(nil), // 8: product.ValidateProductsResponse
 	(*ValidationError)(nil),          // 9: product.ValidationError
 	(*ProductResponse)(nil),          // 10: product.ProductResponse
-	(*timestamppb.Timestamp)(nil),    // 11: google.protobuf.Timestamp
+	(*ReleaseStockRequest)(nil),      // 11: product.ReleaseStockRequest
+	(*StockQuantity)(nil),            // 12: product.StockQuantity
+	(*ReleaseStockResponse)(nil),     // 13: product.ReleaseStockResponse
+	(*timestamppb.Timestamp)(nil),    // 14: google.protobuf.Timestamp
+	(*money.Money)(nil),              // 15: money.Money
 }
 var file_proto_product_product_proto_depIdxs = []int32{
-	11, // 0: product.Product.created_at:type_name -> google.protobuf.Timestamp
-	11, // 1: product.Product.updated_at:type_name -> google.protobuf.Timestamp
-	0,  // 2: product.ListProductsResponse.products:type_name -> product.Product
-	7,  // 3: product.ValidateProductsRequest.items:type_name -> product.ProductValidation
-	9,  // 4: product.ValidateProductsResponse.errors:type_name -> product.ValidationError
-	0,  // 5: product.ValidateProductsResponse.products:type_name -> product.Product
-	0,  // 6: product.ProductResponse.product:type_name -> product.Product
-	1,  // 7: product.ProductService.CreateProduct:input_type -> product.CreateProductRequest
-	2,  // 8: product.ProductService.GetProduct:input_type -> product.GetProductRequest
-	3,  // 9: product.ProductService.ListProducts:input_type -> product.ListProductsRequest
-	5,  // 10: product.ProductService.UpdateProduct:input_type -> product.UpdateProductRequest
-	6,  // 11: product.ProductService.ValidateProducts:input_type -> product.ValidateProductsRequest
-	10, // 12: product.ProductService.CreateProduct:output_type -> product.ProductResponse
-	10, // 13: product.ProductService.GetProduct:output_type -> product.ProductResponse
-	4,  // 14: product.ProductService.ListProducts:output_type -> product.ListProductsResponse
-	10, // 15: product.ProductService.UpdateProduct:output_type -> product.ProductResponse
-	8,  // 16: product.ProductService.ValidateProducts:output_type -> product.ValidateProductsResponse
-	12, // [12:17] is the sub-list for method output_type
-	7,  // [7:12] is the sub-list for method input_type
-	7,  // [7:7] is the sub-list for extension type_name
-	7,  // [7:7] is the sub-list for extension extendee
-	0,  // [0:7] is the sub-list for field type_name
+	14, // 0: product.Product.created_at:type_name -> google.protobuf.Timestamp
+	14, // 1: product.Product.updated_at:type_name -> google.protobuf.Timestamp
+	15, // 2: product.Product.unit_price:type_name -> money.Money
+	15, // 3: product.CreateProductRequest.unit_price:type_name -> money.Money
+	0,  // 4: product.ListProductsResponse.products:type_name -> product.Product
+	15, // 5: product.UpdateProductRequest.unit_price:type_name -> money.Money
+	7,  // 6: product.ValidateProductsRequest.items:type_name -> product.ProductValidation
+	9,  // 7: product.ValidateProductsResponse.errors:type_name -> product.ValidationError
+	0,  // 8: product.ValidateProductsResponse.products:type_name -> product.Product
+	0,  // 9: product.ProductResponse.product:type_name -> product.Product
+	12, // 10: product.ReleaseStockRequest.items:type_name -> product.StockQuantity
+	1,  // 11: product.ProductService.CreateProduct:input_type -> product.CreateProductRequest
+	2,  // 12: product.ProductService.GetProduct:input_type -> product.GetProductRequest
+	3,  // 13: product.ProductService.ListProducts:input_type -> product.ListProductsRequest
+	5,  // 14: product.ProductService.UpdateProduct:input_type -> product.UpdateProductRequest
+	6,  // 15: product.ProductService.ValidateProducts:input_type -> product.ValidateProductsRequest
+	11, // 16: product.ProductService.ReleaseStock:input_type -> product.ReleaseStockRequest
+	10, // 17: product.ProductService.CreateProduct:output_type -> product.ProductResponse
+	10, // 18: product.ProductService.GetProduct:output_type -> product.ProductResponse
+	4,  // 19: product.ProductService.ListProducts:output_type -> product.ListProductsResponse
+	10, // 20: product.ProductService.UpdateProduct:output_type -> product.ProductResponse
+	8,  // 21: product.ProductService.ValidateProducts:output_type -> product.ValidateProductsResponse
+	13, // 22: product.ProductService.ReleaseStock:output_type -> product.ReleaseStockResponse
+	17, // [17:23] is the sub-list for method output_type
+	11, // [11:17] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
 }
 
 func init() { file_proto_product_product_proto_init() }
@@ -824,7 +1059,7 @@ func file_proto_product_product_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_product_product_proto_rawDesc), len(file_proto_product_product_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   11,
+			NumMessages:   14,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -0,0 +1,708 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v6.33.0
+// source: proto/promotion/promotion.proto
+
+package promotion
+
+import (
+	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	money "github.com/dipendra-mule/microservice-with-grpc/proto/money"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Promotion struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Code        string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"` // Upper case
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// Types that are valid to be assigned to Discount:
+	//
+	//	*Promotion_PercentOff
+	//	*Promotion_AmountOff
+	//	*Promotion_BuyXGetY
+	Discount       isPromotion_Discount   `protobuf_oneof:"discount"`
+	Category       string                 `protobuf:"bytes,7,opt,name=category,proto3" json:"category,omitempty"`                                         // Only items in this product category are eligible; empty for all
+	MinOrderValue  *money.Money           `protobuf:"bytes,8,opt,name=min_order_value,json=minOrderValue,proto3" json:"min_order_value,omitempty"`        // Order amount before discounts required to apply
+	MaxUses        int32                  `protobuf:"varint,9,opt,name=max_uses,json=maxUses,proto3" json:"max_uses,omitempty"`                           // Total redemptions allowed, 0 for unlimited
+	MaxUsesPerUser int32                  `protobuf:"varint,10,opt,name=max_uses_per_user,json=maxUsesPerUser,proto3" json:"max_uses_per_user,omitempty"` // 0 for unlimited
+	TimesUsed      int32                  `protobuf:"varint,11,opt,name=times_used,json=timesUsed,proto3" json:"times_used,omitempty"`
+	StartsAt       *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=starts_at,json=startsAt,proto3" json:"starts_at,omitempty"`
+	EndsAt         *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=ends_at,json=endsAt,proto3" json:"ends_at,omitempty"` // Unset for no end
+	Active         bool                   `protobuf:"varint,14,opt,name=active,proto3" json:"active,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Promotion) Reset() {
+	*x = Promotion{}
+	mi := &file_proto_promotion_promotion_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Promotion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Promotion) ProtoMessage() {}
+
+func (x *Promotion) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_promotion_promotion_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Promotion.ProtoReflect.Descriptor instead.
+func (*Promotion) Descriptor() ([]byte, []int) {
+	return file_proto_promotion_promotion_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Promotion) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Promotion) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Promotion) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Promotion) GetDiscount() isPromotion_Discount {
+	if x != nil {
+		return x.Discount
+	}
+	return nil
+}
+
+func (x *Promotion) GetPercentOff() string {
+	if x != nil {
+		if x, ok := x.Discount.(*Promotion_PercentOff); ok {
+			return x.PercentOff
+		}
+	}
+	return ""
+}
+
+func (x *Promotion) GetAmountOff() *money.Money {
+	if x != nil {
+		if x, ok := x.Discount.(*Promotion_AmountOff); ok {
+			return x.AmountOff
+		}
+	}
+	return nil
+}
+
+func (x *Promotion) GetBuyXGetY() *BuyXGetY {
+	if x != nil {
+		if x, ok := x.Discount.(*Promotion_BuyXGetY); ok {
+			return x.BuyXGetY
+		}
+	}
+	return nil
+}
+
+func (x *Promotion) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *Promotion) GetMinOrderValue() *money.Money {
+	if x != nil {
+		return x.MinOrderValue
+	}
+	return nil
+}
+
+func (x *Promotion) GetMaxUses() int32 {
+	if x != nil {
+		return x.MaxUses
+	}
+	return 0
+}
+
+func (x *Promotion) GetMaxUsesPerUser() int32 {
+	if x != nil {
+		return x.MaxUsesPerUser
+	}
+	return 0
+}
+
+func (x *Promotion) GetTimesUsed() int32 {
+	if x != nil {
+		return x.TimesUsed
+	}
+	return 0
+}
+
+func (x *Promotion) GetStartsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartsAt
+	}
+	return nil
+}
+
+func (x *Promotion) GetEndsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndsAt
+	}
+	return nil
+}
+
+func (x *Promotion) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+func (x *Promotion) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type isPromotion_Discount interface {
+	isPromotion_Discount()
+}
+
+type Promotion_PercentOff struct {
+	PercentOff string `protobuf:"bytes,4,opt,name=percent_off,json=percentOff,proto3,oneof"` // Decimal, e.g. "15" or "12.5"
+}
+
+type Promotion_AmountOff struct {
+	AmountOff *money.Money `protobuf:"bytes,5,opt,name=amount_off,json=amountOff,proto3,oneof"` // Off the eligible items as a whole
+}
+
+type Promotion_BuyXGetY struct {
+	BuyXGetY *BuyXGetY `protobuf:"bytes,6,opt,name=buy_x_get_y,json=buyXGetY,proto3,oneof"`
+}
+
+func (*Promotion_PercentOff) isPromotion_Discount() {}
+
+func (*Promotion_AmountOff) isPromotion_Discount() {}
+
+func (*Promotion_BuyXGetY) isPromotion_Discount() {}
+
+// BuyXGetY makes get_quantity of every buy_quantity + get_quantity units of
+// the same product free.
+type BuyXGetY struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BuyQuantity   int32                  `protobuf:"varint,1,opt,name=buy_quantity,json=buyQuantity,proto3" json:"buy_quantity,omitempty"`
+	GetQuantity   int32                  `protobuf:"varint,2,opt,name=get_quantity,json=getQuantity,proto3" json:"get_quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BuyXGetY) Reset() {
+	*x = BuyXGetY{}
+	mi := &file_proto_promotion_promotion_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BuyXGetY) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BuyXGetY) ProtoMessage() {}
+
+func (x *BuyXGetY) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_promotion_promotion_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BuyXGetY.ProtoReflect.Descriptor instead.
+func (*BuyXGetY) Descriptor() ([]byte, []int) {
+	return file_proto_promotion_promotion_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *BuyXGetY) GetBuyQuantity() int32 {
+	if x != nil {
+		return x.BuyQuantity
+	}
+	return 0
+}
+
+func (x *BuyXGetY) GetGetQuantity() int32 {
+	if x != nil {
+		return x.GetQuantity
+	}
+	return 0
+}
+
+type CreatePromotionRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Code        string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Description string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	// Types that are valid to be assigned to Discount:
+	//
+	//	*CreatePromotionRequest_PercentOff
+	//	*CreatePromotionRequest_AmountOff
+	//	*CreatePromotionRequest_BuyXGetY
+	Discount       isCreatePromotionRequest_Discount `protobuf_oneof:"discount"`
+	Category       string                            `protobuf:"bytes,6,opt,name=category,proto3" json:"category,omitempty"`
+	MinOrderValue  *money.Money                      `protobuf:"bytes,7,opt,name=min_order_value,json=minOrderValue,proto3" json:"min_order_value,omitempty"`
+	MaxUses        int32                             `protobuf:"varint,8,opt,name=max_uses,json=maxUses,proto3" json:"max_uses,omitempty"`
+	MaxUsesPerUser int32                             `protobuf:"varint,9,opt,name=max_uses_per_user,json=maxUsesPerUser,proto3" json:"max_uses_per_user,omitempty"`
+	StartsAt       *timestamppb.Timestamp            `protobuf:"bytes,10,opt,name=starts_at,json=startsAt,proto3" json:"starts_at,omitempty"` // Defaults to now
+	EndsAt         *timestamppb.Timestamp            `protobuf:"bytes,11,opt,name=ends_at,json=endsAt,proto3" json:"ends_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CreatePromotionRequest) Reset() {
+	*x = CreatePromotionRequest{}
+	mi := &file_proto_promotion_promotion_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePromotionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePromotionRequest) ProtoMessage() {}
+
+func (x *CreatePromotionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_promotion_promotion_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePromotionRequest.ProtoReflect.Descriptor instead.
+func (*CreatePromotionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_promotion_promotion_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreatePromotionRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *CreatePromotionRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreatePromotionRequest) GetDiscount() isCreatePromotionRequest_Discount {
+	if x != nil {
+		return x.Discount
+	}
+	return nil
+}
+
+func (x *CreatePromotionRequest) GetPercentOff() string {
+	if x != nil {
+		if x, ok := x.Discount.(*CreatePromotionRequest_PercentOff); ok {
+			return x.PercentOff
+		}
+	}
+	return ""
+}
+
+func (x *CreatePromotionRequest) GetAmountOff() *money.Money {
+	if x != nil {
+		if x, ok := x.Discount.(*CreatePromotionRequest_AmountOff); ok {
+			return x.AmountOff
+		}
+	}
+	return nil
+}
+
+func (x *CreatePromotionRequest) GetBuyXGetY() *BuyXGetY {
+	if x != nil {
+		if x, ok := x.Discount.(*CreatePromotionRequest_BuyXGetY); ok {
+			return x.BuyXGetY
+		}
+	}
+	return nil
+}
+
+func (x *CreatePromotionRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *CreatePromotionRequest) GetMinOrderValue() *money.Money {
+	if x != nil {
+		return x.MinOrderValue
+	}
+	return nil
+}
+
+func (x *CreatePromotionRequest) GetMaxUses() int32 {
+	if x != nil {
+		return x.MaxUses
+	}
+	return 0
+}
+
+func (x *CreatePromotionRequest) GetMaxUsesPerUser() int32 {
+	if x != nil {
+		return x.MaxUsesPerUser
+	}
+	return 0
+}
+
+func (x *CreatePromotionRequest) GetStartsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartsAt
+	}
+	return nil
+}
+
+func (x *CreatePromotionRequest) GetEndsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndsAt
+	}
+	return nil
+}
+
+type isCreatePromotionRequest_Discount interface {
+	isCreatePromotionRequest_Discount()
+}
+
+type CreatePromotionRequest_PercentOff struct {
+	PercentOff string `protobuf:"bytes,3,opt,name=percent_off,json=percentOff,proto3,oneof"`
+}
+
+type CreatePromotionRequest_AmountOff struct {
+	AmountOff *money.Money `protobuf:"bytes,4,opt,name=amount_off,json=amountOff,proto3,oneof"`
+}
+
+type CreatePromotionRequest_BuyXGetY struct {
+	BuyXGetY *BuyXGetY `protobuf:"bytes,5,opt,name=buy_x_get_y,json=buyXGetY,proto3,oneof"`
+}
+
+func (*CreatePromotionRequest_PercentOff) isCreatePromotionRequest_Discount() {}
+
+func (*CreatePromotionRequest_AmountOff) isCreatePromotionRequest_Discount() {}
+
+func (*CreatePromotionRequest_BuyXGetY) isCreatePromotionRequest_Discount() {}
+
+type GetPromotionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPromotionRequest) Reset() {
+	*x = GetPromotionRequest{}
+	mi := &file_proto_promotion_promotion_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPromotionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPromotionRequest) ProtoMessage() {}
+
+func (x *GetPromotionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_promotion_promotion_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPromotionRequest.ProtoReflect.Descriptor instead.
+func (*GetPromotionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_promotion_promotion_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetPromotionRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type DeactivatePromotionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeactivatePromotionRequest) Reset() {
+	*x = DeactivatePromotionRequest{}
+	mi := &file_proto_promotion_promotion_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeactivatePromotionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeactivatePromotionRequest) ProtoMessage() {}
+
+func (x *DeactivatePromotionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_promotion_promotion_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeactivatePromotionRequest.ProtoReflect.Descriptor instead.
+func (*DeactivatePromotionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_promotion_promotion_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DeactivatePromotionRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type PromotionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Promotion     *Promotion             `protobuf:"bytes,1,opt,name=promotion,proto3" json:"promotion,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PromotionResponse) Reset() {
+	*x = PromotionResponse{}
+	mi := &file_proto_promotion_promotion_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromotionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromotionResponse) ProtoMessage() {}
+
+func (x *PromotionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_promotion_promotion_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromotionResponse.ProtoReflect.Descriptor instead.
+func (*PromotionResponse) Descriptor() ([]byte, []int) {
+	return file_proto_promotion_promotion_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *PromotionResponse) GetPromotion() *Promotion {
+	if x != nil {
+		return x.Promotion
+	}
+	return nil
+}
+
+var File_proto_promotion_promotion_proto protoreflect.FileDescriptor
+
+const file_proto_promotion_promotion_proto_rawDesc = "" +
+	"\n" +
+	"\x1fproto/promotion/promotion.proto\x12\tpromotion\x1a\x1bbuf/validate/validate.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x17proto/money/money.proto\"\xdd\x04\n" +
+	"\tPromotion\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12!\n" +
+	"\vpercent_off\x18\x04 \x01(\tH\x00R\n" +
+	"percentOff\x12-\n" +
+	"\n" +
+	"amount_off\x18\x05 \x01(\v2\f.money.MoneyH\x00R\tamountOff\x124\n" +
+	"\vbuy_x_get_y\x18\x06 \x01(\v2\x13.promotion.BuyXGetYH\x00R\bbuyXGetY\x12\x1a\n" +
+	"\bcategory\x18\a \x01(\tR\bcategory\x124\n" +
+	"\x0fmin_order_value\x18\b \x01(\v2\f.money.MoneyR\rminOrderValue\x12\x19\n" +
+	"\bmax_uses\x18\t \x01(\x05R\amaxUses\x12)\n" +
+	"\x11max_uses_per_user\x18\n" +
+	" \x01(\x05R\x0emaxUsesPerUser\x12\x1d\n" +
+	"\n" +
+	"times_used\x18\v \x01(\x05R\ttimesUsed\x127\n" +
+	"\tstarts_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\bstartsAt\x123\n" +
+	"\aends_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\x06endsAt\x12\x16\n" +
+	"\x06active\x18\x0e \x01(\bR\x06active\x129\n" +
+	"\n" +
+	"created_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAtB\n" +
+	"\n" +
+	"\bdiscount\"b\n" +
+	"\bBuyXGetY\x12*\n" +
+	"\fbuy_quantity\x18\x01 \x01(\x05B\a\xbaH\x04\x1a\x02 \x00R\vbuyQuantity\x12*\n" +
+	"\fget_quantity\x18\x02 \x01(\x05B\a\xbaH\x04\x1a\x02 \x00R\vgetQuantity\"\xcd\x04\n" +
+	"\x16CreatePromotionRequest\x120\n" +
+	"\x04code\x18\x01 \x01(\tB\x1c\xbaH\x19r\x172\x15^[A-Za-z0-9_-]{3,32}$R\x04code\x12*\n" +
+	"\vdescription\x18\x02 \x01(\tB\b\xbaH\x05r\x03\x18\xf4\x03R\vdescription\x12E\n" +
+	"\vpercent_off\x18\x03 \x01(\tB\"\xbaH\x1fr\x1d2\x1b^[0-9]{1,3}(\\.[0-9]{1,4})?$H\x00R\n" +
+	"percentOff\x12-\n" +
+	"\n" +
+	"amount_off\x18\x04 \x01(\v2\f.money.MoneyH\x00R\tamountOff\x124\n" +
+	"\vbuy_x_get_y\x18\x05 \x01(\v2\x13.promotion.BuyXGetYH\x00R\bbuyXGetY\x12\x1a\n" +
+	"\bcategory\x18\x06 \x01(\tR\bcategory\x124\n" +
+	"\x0fmin_order_value\x18\a \x01(\v2\f.money.MoneyR\rminOrderValue\x12\"\n" +
+	"\bmax_uses\x18\b \x01(\x05B\a\xbaH\x04\x1a\x02(\x00R\amaxUses\x122\n" +
+	"\x11max_uses_per_user\x18\t \x01(\x05B\a\xbaH\x04\x1a\x02(\x00R\x0emaxUsesPerUser\x127\n" +
+	"\tstarts_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\bstartsAt\x123\n" +
+	"\aends_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\x06endsAtB\x11\n" +
+	"\bdiscount\x12\x05\xbaH\x02\b\x01\"2\n" +
+	"\x13GetPromotionRequest\x12\x1b\n" +
+	"\x04code\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x04code\"9\n" +
+	"\x1aDeactivatePromotionRequest\x12\x1b\n" +
+	"\x04code\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x04code\"G\n" +
+	"\x11PromotionResponse\x122\n" +
+	"\tpromotion\x18\x01 \x01(\v2\x14.promotion.PromotionR\tpromotion2\x96\x02\n" +
+	"\x10PromotionService\x12T\n" +
+	"\x0fCreatePromotion\x12!.promotion.CreatePromotionRequest\x1a\x1c.promotion.PromotionResponse\"\x00\x12N\n" +
+	"\fGetPromotion\x12\x1e.promotion.GetPromotionRequest\x1a\x1c.promotion.PromotionResponse\"\x00\x12\\\n" +
+	"\x13DeactivatePromotion\x12%.promotion.DeactivatePromotionRequest\x1a\x1c.promotion.PromotionResponse\"\x00BAZ?github.com/dipendra-mule/microservice-with-grpc/proto/promotionb\x06proto3"
+
+var (
+	file_proto_promotion_promotion_proto_rawDescOnce sync.Once
+	file_proto_promotion_promotion_proto_rawDescData []byte
+)
+
+func file_proto_promotion_promotion_proto_rawDescGZIP() []byte {
+	file_proto_promotion_promotion_proto_rawDescOnce.Do(func() {
+		file_proto_promotion_promotion_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_promotion_promotion_proto_rawDesc), len(file_proto_promotion_promotion_proto_rawDesc)))
+	})
+	return file_proto_promotion_promotion_proto_rawDescData
+}
+
+var file_proto_promotion_promotion_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_proto_promotion_promotion_proto_goTypes = []any{
+	(*Promotion)(nil),                  // 0: promotion.Promotion
+	(*BuyXGetY)(nil),                   // 1: promotion.BuyXGetY
+	(*CreatePromotionRequest)(nil),     // 2: promotion.CreatePromotionRequest
+	(*GetPromotionRequest)(nil),        // 3: promotion.GetPromotionRequest
+	(*DeactivatePromotionRequest)(nil), // 4: promotion.DeactivatePromotionRequest
+	(*PromotionResponse)(nil),          // 5: promotion.PromotionResponse
+	(*money.Money)(nil),                // 6: money.Money
+	(*timestamppb.Timestamp)(nil),      // 7: google.protobuf.Timestamp
+}
+var file_proto_promotion_promotion_proto_depIdxs = []int32{
+	6,  // 0: promotion.Promotion.amount_off:type_name -> money.Money
+	1,  // 1: promotion.Promotion.buy_x_get_y:type_name -> promotion.BuyXGetY
+	6,  // 2: promotion.Promotion.min_order_value:type_name -> money.Money
+	7,  // 3: promotion.Promotion.starts_at:type_name -> google.protobuf.Timestamp
+	7,  // 4: promotion.Promotion.ends_at:type_name -> google.protobuf.Timestamp
+	7,  // 5: promotion.Promotion.created_at:type_name -> google.protobuf.Timestamp
+	6,  // 6: promotion.CreatePromotionRequest.amount_off:type_name -> money.Money
+	1,  // 7: promotion.CreatePromotionRequest.buy_x_get_y:type_name -> promotion.BuyXGetY
+	6,  // 8: promotion.CreatePromotionRequest.min_order_value:type_name -> money.Money
+	7,  // 9: promotion.CreatePromotionRequest.starts_at:type_name -> google.protobuf.Timestamp
+	7,  // 10: promotion.CreatePromotionRequest.ends_at:type_name -> google.protobuf.Timestamp
+	0,  // 11: promotion.PromotionResponse.promotion:type_name -> promotion.Promotion
+	2,  // 12: promotion.PromotionService.CreatePromotion:input_type -> promotion.CreatePromotionRequest
+	3,  // 13: promotion.PromotionService.GetPromotion:input_type -> promotion.GetPromotionRequest
+	4,  // 14: promotion.PromotionService.DeactivatePromotion:input_type -> promotion.DeactivatePromotionRequest
+	5,  // 15: promotion.PromotionService.CreatePromotion:output_type -> promotion.PromotionResponse
+	5,  // 16: promotion.PromotionService.GetPromotion:output_type -> promotion.PromotionResponse
+	5,  // 17: promotion.PromotionService.DeactivatePromotion:output_type -> promotion.PromotionResponse
+	15, // [15:18] is the sub-list for method output_type
+	12, // [12:15] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
+}
+
+func init() { file_proto_promotion_promotion_proto_init() }
+func file_proto_promotion_promotion_proto_init() {
+	if File_proto_promotion_promotion_proto != nil {
+		return
+	}
+	file_proto_promotion_promotion_proto_msgTypes[0].OneofWrappers = []any{
+		(*Promotion_PercentOff)(nil),
+		(*Promotion_AmountOff)(nil),
+		(*Promotion_BuyXGetY)(nil),
+	}
+	file_proto_promotion_promotion_proto_msgTypes[2].OneofWrappers = []any{
+		(*CreatePromotionRequest_PercentOff)(nil),
+		(*CreatePromotionRequest_AmountOff)(nil),
+		(*CreatePromotionRequest_BuyXGetY)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_promotion_promotion_proto_rawDesc), len(file_proto_promotion_promotion_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_promotion_promotion_proto_goTypes,
+		DependencyIndexes: file_proto_promotion_promotion_proto_depIdxs,
+		MessageInfos:      file_proto_promotion_promotion_proto_msgTypes,
+	}.Build()
+	File_proto_promotion_promotion_proto = out.File
+	file_proto_promotion_promotion_proto_goTypes = nil
+	file_proto_promotion_promotion_proto_depIdxs = nil
+}
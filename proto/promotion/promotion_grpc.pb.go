@@ -0,0 +1,203 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v6.33.0
+// source: proto/promotion/promotion.proto
+
+package promotion
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	PromotionService_CreatePromotion_FullMethodName     = "/promotion.PromotionService/CreatePromotion"
+	PromotionService_GetPromotion_FullMethodName        = "/promotion.PromotionService/GetPromotion"
+	PromotionService_DeactivatePromotion_FullMethodName = "/promotion.PromotionService/DeactivatePromotion"
+)
+
+// PromotionServiceClient is the client API for PromotionService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// PromotionService manages discount codes. Orders redeem them through
+// CreateOrderRequest.coupon_codes.
+type PromotionServiceClient interface {
+	CreatePromotion(ctx context.Context, in *CreatePromotionRequest, opts ...grpc.CallOption) (*PromotionResponse, error)
+	GetPromotion(ctx context.Context, in *GetPromotionRequest, opts ...grpc.CallOption) (*PromotionResponse, error)
+	DeactivatePromotion(ctx context.Context, in *DeactivatePromotionRequest, opts ...grpc.CallOption) (*PromotionResponse, error)
+}
+
+type promotionServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPromotionServiceClient(cc grpc.ClientConnInterface) PromotionServiceClient {
+	return &promotionServiceClient{cc}
+}
+
+func (c *promotionServiceClient) CreatePromotion(ctx context.Context, in *CreatePromotionRequest, opts ...grpc.CallOption) (*PromotionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PromotionResponse)
+	err := c.cc.Invoke(ctx, PromotionService_CreatePromotion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *promotionServiceClient) GetPromotion(ctx context.Context, in *GetPromotionRequest, opts ...grpc.CallOption) (*PromotionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PromotionResponse)
+	err := c.cc.Invoke(ctx, PromotionService_GetPromotion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *promotionServiceClient) DeactivatePromotion(ctx context.Context, in *DeactivatePromotionRequest, opts ...grpc.CallOption) (*PromotionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PromotionResponse)
+	err := c.cc.Invoke(ctx, PromotionService_DeactivatePromotion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PromotionServiceServer is the server API for PromotionService service.
+// All implementations must embed UnimplementedPromotionServiceServer
+// for forward compatibility.
+//
+// PromotionService manages discount codes. Orders redeem them through
+// CreateOrderRequest.coupon_codes.
+type PromotionServiceServer interface {
+	CreatePromotion(context.Context, *CreatePromotionRequest) (*PromotionResponse, error)
+	GetPromotion(context.Context, *GetPromotionRequest) (*PromotionResponse, error)
+	DeactivatePromotion(context.Context, *DeactivatePromotionRequest) (*PromotionResponse, error)
+	mustEmbedUnimplementedPromotionServiceServer()
+}
+
+// UnimplementedPromotionServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedPromotionServiceServer struct{}
+
+func (UnimplementedPromotionServiceServer) CreatePromotion(context.Context, *CreatePromotionRequest) (*PromotionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePromotion not implemented")
+}
+func (UnimplementedPromotionServiceServer) GetPromotion(context.Context, *GetPromotionRequest) (*PromotionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPromotion not implemented")
+}
+func (UnimplementedPromotionServiceServer) DeactivatePromotion(context.Context, *DeactivatePromotionRequest) (*PromotionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeactivatePromotion not implemented")
+}
+func (UnimplementedPromotionServiceServer) mustEmbedUnimplementedPromotionServiceServer() {}
+func (UnimplementedPromotionServiceServer) testEmbeddedByValue()                          {}
+
+// UnsafePromotionServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PromotionServiceServer will
+// result in compilation errors.
+type UnsafePromotionServiceServer interface {
+	mustEmbedUnimplementedPromotionServiceServer()
+}
+
+func RegisterPromotionServiceServer(s grpc.ServiceRegistrar, srv PromotionServiceServer) {
+	// If the following call pancis, it indicates UnimplementedPromotionServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&PromotionService_ServiceDesc, srv)
+}
+
+func _PromotionService_CreatePromotion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePromotionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PromotionServiceServer).CreatePromotion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PromotionService_CreatePromotion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PromotionServiceServer).CreatePromotion(ctx, req.(*CreatePromotionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PromotionService_GetPromotion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPromotionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PromotionServiceServer).GetPromotion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PromotionService_GetPromotion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PromotionServiceServer).GetPromotion(ctx, req.(*GetPromotionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PromotionService_DeactivatePromotion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeactivatePromotionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PromotionServiceServer).DeactivatePromotion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PromotionService_DeactivatePromotion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PromotionServiceServer).DeactivatePromotion(ctx, req.(*DeactivatePromotionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PromotionService_ServiceDesc is the grpc.ServiceDesc for PromotionService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PromotionService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "promotion.PromotionService",
+	HandlerType: (*PromotionServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreatePromotion",
+			Handler:    _PromotionService_CreatePromotion_Handler,
+		},
+		{
+			MethodName: "GetPromotion",
+			Handler:    _PromotionService_GetPromotion_Handler,
+		},
+		{
+			MethodName: "DeactivatePromotion",
+			Handler:    _PromotionService_DeactivatePromotion_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/promotion/promotion.proto",
+}
@@ -0,0 +1,869 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v6.33.0
+// source: proto/shipment/shipment.proto
+
+package shipment
+
+import (
+	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Shipment struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrderId        string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Carrier        string                 `protobuf:"bytes,3,opt,name=carrier,proto3" json:"carrier,omitempty"` // e.g. "ups"
+	TrackingNumber string                 `protobuf:"bytes,4,opt,name=tracking_number,json=trackingNumber,proto3" json:"tracking_number,omitempty"`
+	Items          []*ShipmentItem        `protobuf:"bytes,5,rep,name=items,proto3" json:"items,omitempty"`
+	// in_transit, out_for_delivery, delivered or exception. Follows the
+	// latest tracking event; delivered is final.
+	Status        string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	Events        []*TrackingEvent       `protobuf:"bytes,7,rep,name=events,proto3" json:"events,omitempty"` // Oldest first
+	ShippedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=shipped_at,json=shippedAt,proto3" json:"shipped_at,omitempty"`
+	DeliveredAt   *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=delivered_at,json=deliveredAt,proto3" json:"delivered_at,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Shipment) Reset() {
+	*x = Shipment{}
+	mi := &file_proto_shipment_shipment_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Shipment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Shipment) ProtoMessage() {}
+
+func (x *Shipment) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shipment_shipment_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Shipment.ProtoReflect.Descriptor instead.
+func (*Shipment) Descriptor() ([]byte, []int) {
+	return file_proto_shipment_shipment_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Shipment) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Shipment) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *Shipment) GetCarrier() string {
+	if x != nil {
+		return x.Carrier
+	}
+	return ""
+}
+
+func (x *Shipment) GetTrackingNumber() string {
+	if x != nil {
+		return x.TrackingNumber
+	}
+	return ""
+}
+
+func (x *Shipment) GetItems() []*ShipmentItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *Shipment) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Shipment) GetEvents() []*TrackingEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *Shipment) GetShippedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ShippedAt
+	}
+	return nil
+}
+
+func (x *Shipment) GetDeliveredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeliveredAt
+	}
+	return nil
+}
+
+func (x *Shipment) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Shipment) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type ShipmentItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShipmentItem) Reset() {
+	*x = ShipmentItem{}
+	mi := &file_proto_shipment_shipment_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShipmentItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShipmentItem) ProtoMessage() {}
+
+func (x *ShipmentItem) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shipment_shipment_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShipmentItem.ProtoReflect.Descriptor instead.
+func (*ShipmentItem) Descriptor() ([]byte, []int) {
+	return file_proto_shipment_shipment_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ShipmentItem) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ShipmentItem) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+type TrackingEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       string                 `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"` // Carrier's ID of the event, if it has one
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Location      string                 `protobuf:"bytes,4,opt,name=location,proto3" json:"location,omitempty"`
+	OccurredAt    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	RecordedAt    *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=recorded_at,json=recordedAt,proto3" json:"recorded_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TrackingEvent) Reset() {
+	*x = TrackingEvent{}
+	mi := &file_proto_shipment_shipment_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrackingEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrackingEvent) ProtoMessage() {}
+
+func (x *TrackingEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shipment_shipment_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrackingEvent.ProtoReflect.Descriptor instead.
+func (*TrackingEvent) Descriptor() ([]byte, []int) {
+	return file_proto_shipment_shipment_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *TrackingEvent) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *TrackingEvent) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *TrackingEvent) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *TrackingEvent) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *TrackingEvent) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+func (x *TrackingEvent) GetRecordedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RecordedAt
+	}
+	return nil
+}
+
+type CreateShipmentRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	OrderId        string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Carrier        string                 `protobuf:"bytes,2,opt,name=carrier,proto3" json:"carrier,omitempty"`
+	TrackingNumber string                 `protobuf:"bytes,3,opt,name=tracking_number,json=trackingNumber,proto3" json:"tracking_number,omitempty"`
+	// Quantities in this parcel, at most what is left to ship of each item.
+	Items         []*ShipmentItem        `protobuf:"bytes,4,rep,name=items,proto3" json:"items,omitempty"`
+	ShippedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=shipped_at,json=shippedAt,proto3" json:"shipped_at,omitempty"` // Defaults to now
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateShipmentRequest) Reset() {
+	*x = CreateShipmentRequest{}
+	mi := &file_proto_shipment_shipment_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateShipmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateShipmentRequest) ProtoMessage() {}
+
+func (x *CreateShipmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shipment_shipment_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateShipmentRequest.ProtoReflect.Descriptor instead.
+func (*CreateShipmentRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shipment_shipment_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreateShipmentRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *CreateShipmentRequest) GetCarrier() string {
+	if x != nil {
+		return x.Carrier
+	}
+	return ""
+}
+
+func (x *CreateShipmentRequest) GetTrackingNumber() string {
+	if x != nil {
+		return x.TrackingNumber
+	}
+	return ""
+}
+
+func (x *CreateShipmentRequest) GetItems() []*ShipmentItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *CreateShipmentRequest) GetShippedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ShippedAt
+	}
+	return nil
+}
+
+type GetShipmentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetShipmentRequest) Reset() {
+	*x = GetShipmentRequest{}
+	mi := &file_proto_shipment_shipment_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetShipmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetShipmentRequest) ProtoMessage() {}
+
+func (x *GetShipmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shipment_shipment_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetShipmentRequest.ProtoReflect.Descriptor instead.
+func (*GetShipmentRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shipment_shipment_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetShipmentRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListShipmentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListShipmentsRequest) Reset() {
+	*x = ListShipmentsRequest{}
+	mi := &file_proto_shipment_shipment_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListShipmentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListShipmentsRequest) ProtoMessage() {}
+
+func (x *ListShipmentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shipment_shipment_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListShipmentsRequest.ProtoReflect.Descriptor instead.
+func (*ListShipmentsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shipment_shipment_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListShipmentsRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+type ListShipmentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Shipments     []*Shipment            `protobuf:"bytes,1,rep,name=shipments,proto3" json:"shipments,omitempty"` // Oldest first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListShipmentsResponse) Reset() {
+	*x = ListShipmentsResponse{}
+	mi := &file_proto_shipment_shipment_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListShipmentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListShipmentsResponse) ProtoMessage() {}
+
+func (x *ListShipmentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shipment_shipment_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListShipmentsResponse.ProtoReflect.Descriptor instead.
+func (*ListShipmentsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_shipment_shipment_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListShipmentsResponse) GetShipments() []*Shipment {
+	if x != nil {
+		return x.Shipments
+	}
+	return nil
+}
+
+type RecordTrackingEventRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Shipment:
+	//
+	//	*RecordTrackingEventRequest_ShipmentId
+	//	*RecordTrackingEventRequest_Tracking
+	Shipment      isRecordTrackingEventRequest_Shipment `protobuf_oneof:"shipment"`
+	EventId       string                                `protobuf:"bytes,3,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	Status        string                                `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	Description   string                                `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	Location      string                                `protobuf:"bytes,6,opt,name=location,proto3" json:"location,omitempty"`
+	OccurredAt    *timestamppb.Timestamp                `protobuf:"bytes,7,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"` // Defaults to now
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordTrackingEventRequest) Reset() {
+	*x = RecordTrackingEventRequest{}
+	mi := &file_proto_shipment_shipment_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordTrackingEventRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordTrackingEventRequest) ProtoMessage() {}
+
+func (x *RecordTrackingEventRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shipment_shipment_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordTrackingEventRequest.ProtoReflect.Descriptor instead.
+func (*RecordTrackingEventRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shipment_shipment_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *RecordTrackingEventRequest) GetShipment() isRecordTrackingEventRequest_Shipment {
+	if x != nil {
+		return x.Shipment
+	}
+	return nil
+}
+
+func (x *RecordTrackingEventRequest) GetShipmentId() string {
+	if x != nil {
+		if x, ok := x.Shipment.(*RecordTrackingEventRequest_ShipmentId); ok {
+			return x.ShipmentId
+		}
+	}
+	return ""
+}
+
+func (x *RecordTrackingEventRequest) GetTracking() *CarrierTracking {
+	if x != nil {
+		if x, ok := x.Shipment.(*RecordTrackingEventRequest_Tracking); ok {
+			return x.Tracking
+		}
+	}
+	return nil
+}
+
+func (x *RecordTrackingEventRequest) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *RecordTrackingEventRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *RecordTrackingEventRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *RecordTrackingEventRequest) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *RecordTrackingEventRequest) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+type isRecordTrackingEventRequest_Shipment interface {
+	isRecordTrackingEventRequest_Shipment()
+}
+
+type RecordTrackingEventRequest_ShipmentId struct {
+	ShipmentId string `protobuf:"bytes,1,opt,name=shipment_id,json=shipmentId,proto3,oneof"`
+}
+
+type RecordTrackingEventRequest_Tracking struct {
+	Tracking *CarrierTracking `protobuf:"bytes,2,opt,name=tracking,proto3,oneof"`
+}
+
+func (*RecordTrackingEventRequest_ShipmentId) isRecordTrackingEventRequest_Shipment() {}
+
+func (*RecordTrackingEventRequest_Tracking) isRecordTrackingEventRequest_Shipment() {}
+
+// CarrierTracking identifies a shipment the way its carrier does.
+type CarrierTracking struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Carrier        string                 `protobuf:"bytes,1,opt,name=carrier,proto3" json:"carrier,omitempty"`
+	TrackingNumber string                 `protobuf:"bytes,2,opt,name=tracking_number,json=trackingNumber,proto3" json:"tracking_number,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CarrierTracking) Reset() {
+	*x = CarrierTracking{}
+	mi := &file_proto_shipment_shipment_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CarrierTracking) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CarrierTracking) ProtoMessage() {}
+
+func (x *CarrierTracking) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shipment_shipment_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CarrierTracking.ProtoReflect.Descriptor instead.
+func (*CarrierTracking) Descriptor() ([]byte, []int) {
+	return file_proto_shipment_shipment_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CarrierTracking) GetCarrier() string {
+	if x != nil {
+		return x.Carrier
+	}
+	return ""
+}
+
+func (x *CarrierTracking) GetTrackingNumber() string {
+	if x != nil {
+		return x.TrackingNumber
+	}
+	return ""
+}
+
+type ShipmentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Shipment      *Shipment              `protobuf:"bytes,1,opt,name=shipment,proto3" json:"shipment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShipmentResponse) Reset() {
+	*x = ShipmentResponse{}
+	mi := &file_proto_shipment_shipment_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShipmentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShipmentResponse) ProtoMessage() {}
+
+func (x *ShipmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shipment_shipment_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShipmentResponse.ProtoReflect.Descriptor instead.
+func (*ShipmentResponse) Descriptor() ([]byte, []int) {
+	return file_proto_shipment_shipment_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ShipmentResponse) GetShipment() *Shipment {
+	if x != nil {
+		return x.Shipment
+	}
+	return nil
+}
+
+var File_proto_shipment_shipment_proto protoreflect.FileDescriptor
+
+const file_proto_shipment_shipment_proto_rawDesc = "" +
+	"\n" +
+	"\x1dproto/shipment/shipment.proto\x12\bshipment\x1a\x1bbuf/validate/validate.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xdf\x03\n" +
+	"\bShipment\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\x12\x18\n" +
+	"\acarrier\x18\x03 \x01(\tR\acarrier\x12'\n" +
+	"\x0ftracking_number\x18\x04 \x01(\tR\x0etrackingNumber\x12,\n" +
+	"\x05items\x18\x05 \x03(\v2\x16.shipment.ShipmentItemR\x05items\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12/\n" +
+	"\x06events\x18\a \x03(\v2\x17.shipment.TrackingEventR\x06events\x129\n" +
+	"\n" +
+	"shipped_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tshippedAt\x12=\n" +
+	"\fdelivered_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\vdeliveredAt\x129\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"[\n" +
+	"\fShipmentItem\x12&\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\tproductId\x12#\n" +
+	"\bquantity\x18\x02 \x01(\x05B\a\xbaH\x04\x1a\x02 \x00R\bquantity\"\xfa\x01\n" +
+	"\rTrackingEvent\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\tR\aeventId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1a\n" +
+	"\blocation\x18\x04 \x01(\tR\blocation\x12;\n" +
+	"\voccurred_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\x12;\n" +
+	"\vrecorded_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"recordedAt\"\x99\x02\n" +
+	"\x15CreateShipmentRequest\x12\"\n" +
+	"\border_id\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\aorderId\x123\n" +
+	"\acarrier\x18\x02 \x01(\tB\x19\xbaH\x16r\x142\x12^[a-z0-9_-]{1,32}$R\acarrier\x122\n" +
+	"\x0ftracking_number\x18\x03 \x01(\tB\t\xbaH\x06r\x04\x10\x01\x18@R\x0etrackingNumber\x128\n" +
+	"\x05items\x18\x04 \x03(\v2\x16.shipment.ShipmentItemB\n" +
+	"\xbaH\a\x92\x01\x04\b\x01\x10dR\x05items\x129\n" +
+	"\n" +
+	"shipped_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tshippedAt\".\n" +
+	"\x12GetShipmentRequest\x12\x18\n" +
+	"\x02id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\x02id\":\n" +
+	"\x14ListShipmentsRequest\x12\"\n" +
+	"\border_id\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\aorderId\"I\n" +
+	"\x15ListShipmentsResponse\x120\n" +
+	"\tshipments\x18\x01 \x03(\v2\x12.shipment.ShipmentR\tshipments\"\x9c\x03\n" +
+	"\x1aRecordTrackingEventRequest\x12+\n" +
+	"\vshipment_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01H\x00R\n" +
+	"shipmentId\x127\n" +
+	"\btracking\x18\x02 \x01(\v2\x19.shipment.CarrierTrackingH\x00R\btracking\x12#\n" +
+	"\bevent_id\x18\x03 \x01(\tB\b\xbaH\x05r\x03\x18\x80\x01R\aeventId\x12Q\n" +
+	"\x06status\x18\x04 \x01(\tB9\xbaH6r4R\n" +
+	"in_transitR\x10out_for_deliveryR\tdeliveredR\texceptionR\x06status\x12*\n" +
+	"\vdescription\x18\x05 \x01(\tB\b\xbaH\x05r\x03\x18\xf4\x03R\vdescription\x12$\n" +
+	"\blocation\x18\x06 \x01(\tB\b\xbaH\x05r\x03\x18\xc8\x01R\blocation\x12;\n" +
+	"\voccurred_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAtB\x11\n" +
+	"\bshipment\x12\x05\xbaH\x02\b\x01\"f\n" +
+	"\x0fCarrierTracking\x12!\n" +
+	"\acarrier\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\acarrier\x120\n" +
+	"\x0ftracking_number\x18\x02 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x0etrackingNumber\"B\n" +
+	"\x10ShipmentResponse\x12.\n" +
+	"\bshipment\x18\x01 \x01(\v2\x12.shipment.ShipmentR\bshipment2\xdc\x02\n" +
+	"\x0fShipmentService\x12O\n" +
+	"\x0eCreateShipment\x12\x1f.shipment.CreateShipmentRequest\x1a\x1a.shipment.ShipmentResponse\"\x00\x12I\n" +
+	"\vGetShipment\x12\x1c.shipment.GetShipmentRequest\x1a\x1a.shipment.ShipmentResponse\"\x00\x12R\n" +
+	"\rListShipments\x12\x1e.shipment.ListShipmentsRequest\x1a\x1f.shipment.ListShipmentsResponse\"\x00\x12Y\n" +
+	"\x13RecordTrackingEvent\x12$.shipment.RecordTrackingEventRequest\x1a\x1a.shipment.ShipmentResponse\"\x00B@Z>github.com/dipendra-mule/microservice-with-grpc/proto/shipmentb\x06proto3"
+
+var (
+	file_proto_shipment_shipment_proto_rawDescOnce sync.Once
+	file_proto_shipment_shipment_proto_rawDescData []byte
+)
+
+func file_proto_shipment_shipment_proto_rawDescGZIP() []byte {
+	file_proto_shipment_shipment_proto_rawDescOnce.Do(func() {
+		file_proto_shipment_shipment_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_shipment_shipment_proto_rawDesc), len(file_proto_shipment_shipment_proto_rawDesc)))
+	})
+	return file_proto_shipment_shipment_proto_rawDescData
+}
+
+var file_proto_shipment_shipment_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_proto_shipment_shipment_proto_goTypes = []any{
+	(*Shipment)(nil),                   // 0: shipment.Shipment
+	(*ShipmentItem)(nil),               // 1: shipment.ShipmentItem
+	(*TrackingEvent)(nil),              // 2: shipment.TrackingEvent
+	(*CreateShipmentRequest)(nil),      // 3: shipment.CreateShipmentRequest
+	(*GetShipmentRequest)(nil),         // 4: shipment.GetShipmentRequest
+	(*ListShipmentsRequest)(nil),       // 5: shipment.ListShipmentsRequest
+	(*ListShipmentsResponse)(nil),      // 6: shipment.ListShipmentsResponse
+	(*RecordTrackingEventRequest)(nil), // 7: shipment.RecordTrackingEventRequest
+	(*CarrierTracking)(nil),            // 8: shipment.CarrierTracking
+	(*ShipmentResponse)(nil),           // 9: shipment.ShipmentResponse
+	(*timestamppb.Timestamp)(nil),      // 10: google.protobuf.Timestamp
+}
+var file_proto_shipment_shipment_proto_depIdxs = []int32{
+	1,  // 0: shipment.Shipment.items:type_name -> shipment.ShipmentItem
+	2,  // 1: shipment.Shipment.events:type_name -> shipment.TrackingEvent
+	10, // 2: shipment.Shipment.shipped_at:type_name -> google.protobuf.Timestamp
+	10, // 3: shipment.Shipment.delivered_at:type_name -> google.protobuf.Timestamp
+	10, // 4: shipment.Shipment.created_at:type_name -> google.protobuf.Timestamp
+	10, // 5: shipment.Shipment.updated_at:type_name -> google.protobuf.Timestamp
+	10, // 6: shipment.TrackingEvent.occurred_at:type_name -> google.protobuf.Timestamp
+	10, // 7: shipment.TrackingEvent.recorded_at:type_name -> google.protobuf.Timestamp
+	1,  // 8: shipment.CreateShipmentRequest.items:type_name -> shipment.ShipmentItem
+	10, // 9: shipment.CreateShipmentRequest.shipped_at:type_name -> google.protobuf.Timestamp
+	0,  // 10: shipment.ListShipmentsResponse.shipments:type_name -> shipment.Shipment
+	8,  // 11: shipment.RecordTrackingEventRequest.tracking:type_name -> shipment.CarrierTracking
+	10, // 12: shipment.RecordTrackingEventRequest.occurred_at:type_name -> google.protobuf.Timestamp
+	0,  // 13: shipment.ShipmentResponse.shipment:type_name -> shipment.Shipment
+	3,  // 14: shipment.ShipmentService.CreateShipment:input_type -> shipment.CreateShipmentRequest
+	4,  // 15: shipment.ShipmentService.GetShipment:input_type -> shipment.GetShipmentRequest
+	5,  // 16: shipment.ShipmentService.ListShipments:input_type -> shipment.ListShipmentsRequest
+	7,  // 17: shipment.ShipmentService.RecordTrackingEvent:input_type -> shipment.RecordTrackingEventRequest
+	9,  // 18: shipment.ShipmentService.CreateShipment:output_type -> shipment.ShipmentResponse
+	9,  // 19: shipment.ShipmentService.GetShipment:output_type -> shipment.ShipmentResponse
+	6,  // 20: shipment.ShipmentService.ListShipments:output_type -> shipment.ListShipmentsResponse
+	9,  // 21: shipment.ShipmentService.RecordTrackingEvent:output_type -> shipment.ShipmentResponse
+	18, // [18:22] is the sub-list for method output_type
+	14, // [14:18] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
+}
+
+func init() { file_proto_shipment_shipment_proto_init() }
+func file_proto_shipment_shipment_proto_init() {
+	if File_proto_shipment_shipment_proto != nil {
+		return
+	}
+	file_proto_shipment_shipment_proto_msgTypes[7].OneofWrappers = []any{
+		(*RecordTrackingEventRequest_ShipmentId)(nil),
+		(*RecordTrackingEventRequest_Tracking)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_shipment_shipment_proto_rawDesc), len(file_proto_shipment_shipment_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_shipment_shipment_proto_goTypes,
+		DependencyIndexes: file_proto_shipment_shipment_proto_depIdxs,
+		MessageInfos:      file_proto_shipment_shipment_proto_msgTypes,
+	}.Build()
+	File_proto_shipment_shipment_proto = out.File
+	file_proto_shipment_shipment_proto_goTypes = nil
+	file_proto_shipment_shipment_proto_depIdxs = nil
+}
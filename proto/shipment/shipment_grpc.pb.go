@@ -0,0 +1,255 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v6.33.0
+// source: proto/shipment/shipment.proto
+
+package shipment
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ShipmentService_CreateShipment_FullMethodName      = "/shipment.ShipmentService/CreateShipment"
+	ShipmentService_GetShipment_FullMethodName         = "/shipment.ShipmentService/GetShipment"
+	ShipmentService_ListShipments_FullMethodName       = "/shipment.ShipmentService/ListShipments"
+	ShipmentService_RecordTrackingEvent_FullMethodName = "/shipment.ShipmentService/RecordTrackingEvent"
+)
+
+// ShipmentServiceClient is the client API for ShipmentService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ShipmentService tracks the parcels an order ships in. An order can ship
+// in several shipments; it becomes "shipped" once all its items are in
+// shipments and "delivered" once all of those are delivered.
+type ShipmentServiceClient interface {
+	// CreateShipment is for admins.
+	CreateShipment(ctx context.Context, in *CreateShipmentRequest, opts ...grpc.CallOption) (*ShipmentResponse, error)
+	GetShipment(ctx context.Context, in *GetShipmentRequest, opts ...grpc.CallOption) (*ShipmentResponse, error)
+	ListShipments(ctx context.Context, in *ListShipmentsRequest, opts ...grpc.CallOption) (*ListShipmentsResponse, error)
+	// RecordTrackingEvent adds a carrier's status update to a shipment.
+	// Events with an event_id already recorded for the shipment are ignored,
+	// so carriers may deliver them more than once. Only admins and the
+	// gateway may call it; the gateway does not publish it, and carriers post
+	// to its signed webhook instead.
+	RecordTrackingEvent(ctx context.Context, in *RecordTrackingEventRequest, opts ...grpc.CallOption) (*ShipmentResponse, error)
+}
+
+type shipmentServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewShipmentServiceClient(cc grpc.ClientConnInterface) ShipmentServiceClient {
+	return &shipmentServiceClient{cc}
+}
+
+func (c *shipmentServiceClient) CreateShipment(ctx context.Context, in *CreateShipmentRequest, opts ...grpc.CallOption) (*ShipmentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ShipmentResponse)
+	err := c.cc.Invoke(ctx, ShipmentService_CreateShipment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shipmentServiceClient) GetShipment(ctx context.Context, in *GetShipmentRequest, opts ...grpc.CallOption) (*ShipmentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ShipmentResponse)
+	err := c.cc.Invoke(ctx, ShipmentService_GetShipment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shipmentServiceClient) ListShipments(ctx context.Context, in *ListShipmentsRequest, opts ...grpc.CallOption) (*ListShipmentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListShipmentsResponse)
+	err := c.cc.Invoke(ctx, ShipmentService_ListShipments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shipmentServiceClient) RecordTrackingEvent(ctx context.Context, in *RecordTrackingEventRequest, opts ...grpc.CallOption) (*ShipmentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ShipmentResponse)
+	err := c.cc.Invoke(ctx, ShipmentService_RecordTrackingEvent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ShipmentServiceServer is the server API for ShipmentService service.
+// All implementations must embed UnimplementedShipmentServiceServer
+// for forward compatibility.
+//
+// ShipmentService tracks the parcels an order ships in. An order can ship
+// in several shipments; it becomes "shipped" once all its items are in
+// shipments and "delivered" once all of those are delivered.
+type ShipmentServiceServer interface {
+	// CreateShipment is for admins.
+	CreateShipment(context.Context, *CreateShipmentRequest) (*ShipmentResponse, error)
+	GetShipment(context.Context, *GetShipmentRequest) (*ShipmentResponse, error)
+	ListShipments(context.Context, *ListShipmentsRequest) (*ListShipmentsResponse, error)
+	// RecordTrackingEvent adds a carrier's status update to a shipment.
+	// Events with an event_id already recorded for the shipment are ignored,
+	// so carriers may deliver them more than once. Only admins and the
+	// gateway may call it; the gateway does not publish it, and carriers post
+	// to its signed webhook instead.
+	RecordTrackingEvent(context.Context, *RecordTrackingEventRequest) (*ShipmentResponse, error)
+	mustEmbedUnimplementedShipmentServiceServer()
+}
+
+// UnimplementedShipmentServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedShipmentServiceServer struct{}
+
+func (UnimplementedShipmentServiceServer) CreateShipment(context.Context, *CreateShipmentRequest) (*ShipmentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateShipment not implemented")
+}
+func (UnimplementedShipmentServiceServer) GetShipment(context.Context, *GetShipmentRequest) (*ShipmentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetShipment not implemented")
+}
+func (UnimplementedShipmentServiceServer) ListShipments(context.Context, *ListShipmentsRequest) (*ListShipmentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListShipments not implemented")
+}
+func (UnimplementedShipmentServiceServer) RecordTrackingEvent(context.Context, *RecordTrackingEventRequest) (*ShipmentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecordTrackingEvent not implemented")
+}
+func (UnimplementedShipmentServiceServer) mustEmbedUnimplementedShipmentServiceServer() {}
+func (UnimplementedShipmentServiceServer) testEmbeddedByValue()                         {}
+
+// UnsafeShipmentServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ShipmentServiceServer will
+// result in compilation errors.
+type UnsafeShipmentServiceServer interface {
+	mustEmbedUnimplementedShipmentServiceServer()
+}
+
+func RegisterShipmentServiceServer(s grpc.ServiceRegistrar, srv ShipmentServiceServer) {
+	// If the following call pancis, it indicates UnimplementedShipmentServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ShipmentService_ServiceDesc, srv)
+}
+
+func _ShipmentService_CreateShipment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateShipmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShipmentServiceServer).CreateShipment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShipmentService_CreateShipment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShipmentServiceServer).CreateShipment(ctx, req.(*CreateShipmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShipmentService_GetShipment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetShipmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShipmentServiceServer).GetShipment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShipmentService_GetShipment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShipmentServiceServer).GetShipment(ctx, req.(*GetShipmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShipmentService_ListShipments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListShipmentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShipmentServiceServer).ListShipments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShipmentService_ListShipments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShipmentServiceServer).ListShipments(ctx, req.(*ListShipmentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShipmentService_RecordTrackingEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecordTrackingEventRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShipmentServiceServer).RecordTrackingEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShipmentService_RecordTrackingEvent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShipmentServiceServer).RecordTrackingEvent(ctx, req.(*RecordTrackingEventRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ShipmentService_ServiceDesc is the grpc.ServiceDesc for ShipmentService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ShipmentService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "shipment.ShipmentService",
+	HandlerType: (*ShipmentServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateShipment",
+			Handler:    _ShipmentService_CreateShipment_Handler,
+		},
+		{
+			MethodName: "GetShipment",
+			Handler:    _ShipmentService_GetShipment_Handler,
+		},
+		{
+			MethodName: "ListShipments",
+			Handler:    _ShipmentService_ListShipments_Handler,
+		},
+		{
+			MethodName: "RecordTrackingEvent",
+			Handler:    _ShipmentService_RecordTrackingEvent_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/shipment/shipment.proto",
+}